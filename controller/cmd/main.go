@@ -19,18 +19,22 @@ package main
 import (
 	"github.com/tinymultiverse/tinyapp/controller/internal"
 	"github.com/tinymultiverse/tinyapp/controller/reconciler"
+	"github.com/tinymultiverse/tinyapp/controller/reconciler/builder"
 	"github.com/tinymultiverse/tinyapp/controller/util"
+	tinyappwebhook "github.com/tinymultiverse/tinyapp/controller/webhook"
 	v1alpha12 "github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
 	"github.com/tinymultiverse/tinyapp/util/logging"
 
 	"github.com/caarlos0/env/v10"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // Fix 'no Auth Provider found for name \"oidc\"'
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -38,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var envVars internal.EnvVars
@@ -66,6 +71,8 @@ func main() {
 		Namespace:              envVars.TinyAppNamespace,
 		MetricsBindAddress:     ":" + envVars.ControllerMetricsPort,
 		HealthProbeBindAddress: ":8082",
+		Port:                   envVars.WebhookPort,
+		CertDir:                envVars.WebhookCertDir,
 	}
 
 	// Instantiate controllers manager
@@ -89,6 +96,23 @@ func main() {
 		zap.S().Fatalw("failed to add health check", "error", err)
 	}
 
+	// Shared by reconciler & validating webhook, so that both accept app types in app runtimes ConfigMap
+	runtimes := builder.NewRuntimeRegistry(mgr.GetClient(), envVars.TinyAppNamespace, envVars.AppRuntimesConfigMap)
+
+	if envVars.WebhookEnabled {
+		validator, err := tinyappwebhook.NewValidator(mgr.GetScheme(), runtimes, envVars)
+		if err != nil {
+			zap.S().Fatalw("failed to instantiate TinyApp validator", "error", err)
+		}
+		mgr.GetWebhookServer().Register(tinyappwebhook.ValidatePath, &webhook.Admission{Handler: validator})
+
+		defaulter, err := tinyappwebhook.NewDefaulter(mgr.GetScheme(), envVars)
+		if err != nil {
+			zap.S().Fatalw("failed to instantiate TinyApp defaulter", "error", err)
+		}
+		mgr.GetWebhookServer().Register(tinyappwebhook.DefaultPath, &webhook.Admission{Handler: defaulter})
+	}
+
 	// Instantiate controllers
 	k8sConfig := config.GetConfigOrDie()
 	k8sClient, err := kubernetes.NewForConfig(k8sConfig)
@@ -97,7 +121,8 @@ func main() {
 	}
 
 	c, err := controller.New(util.ControllerName, mgr, controller.Options{
-		Reconciler: reconciler.NewReconciler(mgr.GetClient(), k8sClient, envVars),
+		Reconciler: reconciler.NewReconciler(mgr.GetClient(), k8sClient, envVars,
+			mgr.GetEventRecorderFor(util.ControllerName), runtimes),
 	})
 
 	if err != nil {
@@ -144,6 +169,28 @@ func main() {
 		zap.S().Fatalw("failed to register Service watcher", "error", err)
 	}
 
+	// Watch for horizontal pod autoscaler
+	if err = c.Watch(
+		&source.Kind{Type: &autoscalingv2.HorizontalPodAutoscaler{}},
+		&handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &v1alpha12.TinyApp{}},
+		dependentPredicate); err != nil {
+		zap.S().Fatalw("failed to register HorizontalPodAutoscaler watcher", "error", err)
+	}
+
+	// Watch for app runtimes ConfigMap, so that apps are redeployed with changed runtimes
+	if envVars.AppRuntimesConfigMap != "" {
+		if err = c.Watch(
+			&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(reconciler.EnqueueAllApps(mgr.GetClient(), envVars.TinyAppNamespace)),
+			predicate.NewPredicateFuncs(func(object client.Object) bool {
+				return object.GetName() == envVars.AppRuntimesConfigMap
+			})); err != nil {
+			zap.S().Fatalw("failed to register app runtimes ConfigMap watcher", "error", err)
+		}
+	}
+
 	zap.S().Info("Starting TinyApp controller")
 	if err = mgr.Start(signals.SetupSignalHandler()); err != nil {
 		zap.S().Fatalw("failed to start controllers manager", "error", err)
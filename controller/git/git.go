@@ -0,0 +1,188 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package git reads refs of remote git repositories over the smart HTTP protocol, without cloning them.
+package git
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+	// Git hosts only check password when it is a token, but username can't be empty
+	tokenUsername = "tinyapp"
+	// Upper bound of ref advertisement read, for repos with a large number of refs
+	maxAdvertisementSize = 32 << 20
+)
+
+var httpClient = &http.Client{Timeout: requestTimeout}
+
+// advertisement holds refs advertised by remote repository.
+type advertisement struct {
+	// Hashes of refs, keyed by ref name
+	refs map[string]string
+	// Ref that HEAD points to (ex. refs/heads/main), if advertised
+	head string
+}
+
+// ResolveRef returns the commit hash that branch or tag points to in remote repository, like git ls-remote.
+// Token is used as password if not empty.
+func ResolveRef(ctx context.Context, repoURL, ref string, isTag bool, token string) (string, error) {
+	adv, err := listRefs(ctx, repoURL, token)
+	if err != nil {
+		return "", err
+	}
+	refs := adv.refs
+
+	if isTag {
+		// Annotated tags point to tag objects, peeled refs point to the tagged commit
+		if hash, ok := refs["refs/tags/"+ref+"^{}"]; ok {
+			return hash, nil
+		}
+		if hash, ok := refs["refs/tags/"+ref]; ok {
+			return hash, nil
+		}
+		return "", errors.Errorf("tag %q not found", ref)
+	}
+
+	if hash, ok := refs["refs/heads/"+ref]; ok {
+		return hash, nil
+	}
+	return "", errors.Errorf("branch %q not found", ref)
+}
+
+// ResolveDefaultBranch returns the branch that HEAD of remote repository points to (ex. main).
+// Token is used as password if not empty.
+func ResolveDefaultBranch(ctx context.Context, repoURL, token string) (string, error) {
+	adv, err := listRefs(ctx, repoURL, token)
+	if err != nil {
+		return "", err
+	}
+
+	branch, ok := strings.CutPrefix(adv.head, "refs/heads/")
+	if !ok || branch == "" {
+		return "", errors.New("git repo does not advertise its default branch")
+	}
+	return branch, nil
+}
+
+// listRefs returns all refs advertised by remote repository.
+func listRefs(ctx context.Context, repoURL, token string) (*advertisement, error) {
+	refsURL := strings.TrimSuffix(repoURL, "/") + "/info/refs?service=git-upload-pack"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, refsURL, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid git url")
+	}
+	req.Header.Set("User-Agent", "git/tinyapp-controller")
+	if token != "" {
+		req.SetBasicAuth(tokenUsername, token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to reach git repo")
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, errors.Errorf("not authorized to read git repo (status %d)", resp.StatusCode)
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.New("git repo not found")
+	case resp.StatusCode != http.StatusOK:
+		return nil, errors.Errorf("unexpected status %d from git repo", resp.StatusCode)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/x-git-upload-pack-advertisement" {
+		return nil, errors.Errorf("git repo does not support smart http protocol (content type %q)", contentType)
+	}
+
+	return parseAdvertisement(io.LimitReader(resp.Body, maxAdvertisementSize))
+}
+
+// parseAdvertisement parses pkt-lines of a smart HTTP ref advertisement.
+// See https://git-scm.com/docs/http-protocol#_smart_clients
+func parseAdvertisement(r io.Reader) (*advertisement, error) {
+	reader := bufio.NewReader(r)
+	adv := &advertisement{refs: make(map[string]string)}
+
+	for {
+		line, err := readPktLine(reader)
+		if err == io.EOF {
+			return adv, nil
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid ref advertisement")
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" || strings.HasPrefix(line, "# service=") {
+			continue
+		}
+
+		// First ref is followed by capabilities, which tell where HEAD points to (ex. symref=HEAD:refs/heads/main)
+		line, capabilities, _ := strings.Cut(line, "\x00")
+		for _, capability := range strings.Fields(capabilities) {
+			if head, ok := strings.CutPrefix(capability, "symref=HEAD:"); ok {
+				adv.head = head
+			}
+		}
+
+		hash, name, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, errors.Errorf("invalid ref line %q", line)
+		}
+		adv.refs[name] = hash
+	}
+}
+
+// readPktLine returns payload of the next pkt-line, which is empty for flush packets.
+func readPktLine(reader *bufio.Reader) (string, error) {
+	lengthHex := make([]byte, 4)
+	if _, err := io.ReadFull(reader, lengthHex); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return "", errors.New("truncated pkt-line length")
+		}
+		return "", err
+	}
+
+	length, err := strconv.ParseUint(string(lengthHex), 16, 16)
+	if err != nil {
+		return "", errors.Errorf("invalid pkt-line length %q", lengthHex)
+	}
+	if length == 0 {
+		return "", nil
+	}
+	if length < 4 {
+		return "", errors.Errorf("invalid pkt-line length %d", length)
+	}
+
+	payload := make([]byte, length-4)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return "", errors.WithMessage(err, "truncated pkt-line")
+	}
+
+	return string(payload), nil
+}
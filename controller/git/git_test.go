@@ -0,0 +1,56 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// pktLine returns payload encoded as pkt-line.
+func pktLine(payload string) string {
+	return fmt.Sprintf("%04x%s", len(payload)+4, payload)
+}
+
+func TestParseAdvertisement(t *testing.T) {
+	body := pktLine("# service=git-upload-pack\n") + "0000" +
+		pktLine("0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c HEAD\x00multi_ack thin-pack symref=HEAD:refs/heads/main agent=git/2.43.0\n") +
+		pktLine("0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c refs/heads/main\n") +
+		pktLine("da1560886d4f094c3e6c9ef40349f7d38b5d27d7 refs/tags/v1.0.0\n") +
+		pktLine("82b3d5ae55f7080f1e6022629cdb57bfae7cccc7 refs/tags/v1.0.0^{}\n") + "0000"
+
+	adv, err := parseAdvertisement(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to parse advertisement: %v", err)
+	}
+
+	if adv.head != "refs/heads/main" {
+		t.Errorf("got head %q, want refs/heads/main", adv.head)
+	}
+	wantRefs := map[string]string{
+		"HEAD":                "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c",
+		"refs/heads/main":     "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c",
+		"refs/tags/v1.0.0":    "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+		"refs/tags/v1.0.0^{}": "82b3d5ae55f7080f1e6022629cdb57bfae7cccc7",
+	}
+	for name, hash := range wantRefs {
+		if adv.refs[name] != hash {
+			t.Errorf("got %s %q, want %q", name, adv.refs[name], hash)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package image resolves container image tags to digests over the OCI distribution API, without pulling images.
+package image
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+	// Upper bound of manifest read, when registry doesn't return manifest digest in header
+	maxManifestSize = 4 << 20
+	// Docker Hub is the registry of images without registry domain, and is served from another host
+	dockerHubDomain = "docker.io"
+	dockerHubHost   = "registry-1.docker.io"
+	defaultTag      = "latest"
+)
+
+// Manifest types to accept, so that multi-platform images resolve to the digest of their index like they do for
+// container runtimes
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var httpClient = &http.Client{Timeout: requestTimeout}
+
+// Credentials log in to an image registry.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Reference is a parsed image reference, such as python:3.11 or ghcr.io/org/app@sha256:...
+type Reference struct {
+	// Registry domain, such as docker.io or ghcr.io
+	Domain     string
+	Repository string
+	// Empty if reference has a digest only
+	Tag    string
+	Digest string
+}
+
+// ParseReference parses image reference the way container runtimes do. Images without registry domain are
+// pulled from Docker Hub, and images without tag or digest are tagged latest.
+func ParseReference(image string) (Reference, error) {
+	ref := Reference{}
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+		if !strings.Contains(ref.Digest, ":") {
+			return Reference{}, errors.Errorf("invalid digest in image %q", image)
+		}
+	}
+
+	// Tag follows the last colon after the last slash, which tells it apart from registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+
+	ref.Domain = dockerHubDomain
+	if i := strings.Index(name, "/"); i >= 0 {
+		if domain := name[:i]; strings.ContainsAny(domain, ".:") || domain == "localhost" {
+			ref.Domain, name = domain, name[i+1:]
+		}
+	}
+	if ref.Domain == dockerHubDomain && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+
+	if name == "" || strings.ToLower(name) != name {
+		return Reference{}, errors.Errorf("invalid repository in image %q", image)
+	}
+	ref.Repository = name
+
+	return ref, nil
+}
+
+// PinDigest returns image referenced by digest instead of tag (ex. ghcr.io/org/app@sha256:...), so that the same image
+// is pulled while its tag moves. Images that already have a digest are returned as is.
+func PinDigest(image, digest string) string {
+	if digest == "" || strings.Contains(image, "@") {
+		return image
+	}
+
+	// Tag follows the last colon after the last slash, which tells it apart from registry port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + "@" + digest
+}
+
+// ResolveDigest returns the digest that image tag currently points to in its registry. Digest of images referenced
+// by digest is returned as is. Credentials are used if registry asks for them and they are not nil.
+func ResolveDigest(ctx context.Context, image string, credentials *Credentials) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+
+	host := ref.Domain
+	if host == dockerHubDomain {
+		host = dockerHubHost
+	}
+	manifestURL := "https://" + host + "/v2/" + ref.Repository + "/manifests/" + ref.Tag
+
+	resp, err := requestManifest(ctx, http.MethodHead, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	// Registries tell how to log in, even for anonymous pulls of public images
+	authorization := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err = authorize(ctx, resp.Header.Get("WWW-Authenticate"), ref.Repository, credentials)
+		if err != nil {
+			return "", err
+		}
+
+		resp, err = requestManifest(ctx, http.MethodHead, manifestURL, authorization)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+	}
+
+	if err := checkStatus(resp, image); err != nil {
+		return "", err
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// Digest of manifest is the hash of its content
+	resp, err = requestManifest(ctx, http.MethodGet, manifestURL, authorization)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, image); err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.LimitReader(resp.Body, maxManifestSize)); err != nil {
+		return "", errors.WithMessage(err, "failed to read image manifest")
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func requestManifest(ctx context.Context, method, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid image registry url")
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to reach image registry")
+	}
+	return resp, nil
+}
+
+func checkStatus(resp *http.Response, image string) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return errors.Errorf("image %q not found", image)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errors.Errorf("not allowed to pull image %q", image)
+	default:
+		return errors.Errorf("image registry returned status %d", resp.StatusCode)
+	}
+}
+
+// authorize returns Authorization header value that answers registry challenge. Bearer challenges are answered
+// with a pull token from registry auth server, which credentials log in to if not nil.
+func authorize(ctx context.Context, challenge, repository string, credentials *Credentials) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if credentials == nil {
+			return "", errors.New("image registry requires credentials")
+		}
+		return "Basic " + basicAuth(credentials), nil
+	case "bearer":
+		token, err := fetchToken(ctx, params, repository, credentials)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", errors.Errorf("unsupported image registry auth challenge %q", challenge)
+	}
+}
+
+// fetchToken returns token that allows pulling repository, issued by auth server of bearer challenge.
+func fetchToken(ctx context.Context, params map[string]string, repository string, credentials *Credentials) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", errors.New("image registry auth challenge has no realm")
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", errors.WithMessage(err, "invalid image registry auth realm")
+	}
+	query := tokenURL.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + repository + ":pull"
+	}
+	query.Set("scope", scope)
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", errors.WithMessage(err, "invalid image registry auth realm")
+	}
+	if credentials != nil {
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to reach image registry auth server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("image registry auth server returned status %d", resp.StatusCode)
+	}
+
+	body := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&body); err != nil {
+		return "", errors.WithMessage(err, "failed to parse image registry token")
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", errors.New("image registry auth server returned no token")
+}
+
+// parseChallenge returns scheme & params of WWW-Authenticate header, such as
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io".
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+
+	params := make(map[string]string)
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			params[key] = value
+		}
+	}
+
+	return scheme, params
+}
+
+func basicAuth(credentials *Credentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + credentials.Password))
+}
+
+// CredentialsFromDockerConfig returns credentials of registry domain in docker config json, as stored in
+// kubernetes.io/dockerconfigjson image pull secrets. Returns nil if config has none for registry.
+func CredentialsFromDockerConfig(config []byte, domain string) (*Credentials, error) {
+	dockerConfig := struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(config, &dockerConfig); err != nil {
+		return nil, errors.WithMessage(err, "failed to parse docker config")
+	}
+
+	for server, auth := range dockerConfig.Auths {
+		if normalizeDomain(server) != domain {
+			continue
+		}
+
+		if auth.Username != "" || auth.Password != "" {
+			return &Credentials{Username: auth.Username, Password: auth.Password}, nil
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid auth of registry %s in docker config", server)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return &Credentials{Username: username, Password: password}, nil
+	}
+
+	return nil, nil
+}
+
+// normalizeDomain returns registry domain of docker config server, which may be a url (ex. https://index.docker.io/v1/).
+func normalizeDomain(server string) string {
+	domain := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	domain, _, _ = strings.Cut(domain, "/")
+
+	switch domain {
+	case "index.docker.io", dockerHubHost:
+		return dockerHubDomain
+	default:
+		return domain
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testDigest = "sha256:6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b"
+
+const testIndex = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`
+
+// testRegistry serves manifest of team/app:1.0, and pull tokens for it if auth is "bearer".
+type testRegistry struct {
+	*httptest.Server
+	// Challenge scheme of registry: "", "basic" or "bearer"
+	auth string
+	// Credentials that registry or its auth server require, if not nil
+	credentials *Credentials
+	// Whether manifest responses leave out Docker-Content-Digest, like some registries do for manifest lists
+	noDigestHeader bool
+
+	tokenRequests int
+	accept        string
+}
+
+func newTestRegistry(t *testing.T, auth string, credentials *Credentials) *testRegistry {
+	registry := &testRegistry{auth: auth, credentials: credentials}
+	registry.Server = httptest.NewTLSServer(http.HandlerFunc(registry.serveHTTP))
+	t.Cleanup(registry.Close)
+
+	// Registry serves a self-signed certificate
+	client := httpClient
+	httpClient = registry.Client()
+	t.Cleanup(func() { httpClient = client })
+
+	return registry
+}
+
+// image returns reference of image at given path of registry, such as 127.0.0.1:1234/team/app:1.0.
+func (r *testRegistry) image(path string) string {
+	return strings.TrimPrefix(r.URL, "https://") + "/" + path
+}
+
+func (r *testRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		r.tokenRequests++
+		if req.URL.Query().Get("scope") != "repository:team/app:pull" || req.URL.Query().Get("service") != "test-registry" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.credentials != nil {
+			if username, password, ok := req.BasicAuth(); !ok || username != r.credentials.Username || password != r.credentials.Password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		w.Write([]byte(`{"token":"pull-token"}`))
+		return
+	}
+
+	if !r.authorized(req) {
+		switch r.auth {
+		case "basic":
+			w.Header().Set("WWW-Authenticate", `Basic realm="test-registry"`)
+		case "bearer":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+r.URL+`/token",service="test-registry"`)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if req.URL.Path != "/v2/team/app/manifests/1.0" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	r.accept = req.Header.Get("Accept")
+	w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json")
+	if !r.noDigestHeader {
+		w.Header().Set("Docker-Content-Digest", testDigest)
+	}
+	if req.Method == http.MethodGet {
+		w.Write([]byte(testIndex))
+	}
+}
+
+func (r *testRegistry) authorized(req *http.Request) bool {
+	switch r.auth {
+	case "basic":
+		username, password, ok := req.BasicAuth()
+		return ok && username == r.credentials.Username && password == r.credentials.Password
+	case "bearer":
+		return req.Header.Get("Authorization") == "Bearer pull-token"
+	default:
+		return true
+	}
+}
+
+func TestResolveDigest(t *testing.T) {
+	t.Run("anonymous", func(t *testing.T) {
+		registry := newTestRegistry(t, "", nil)
+
+		digest, err := ResolveDigest(context.Background(), registry.image("team/app:1.0"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if digest != testDigest {
+			t.Errorf("got digest %s, want %s", digest, testDigest)
+		}
+		// Multi-platform images must resolve to their index, not to one of its manifests
+		for _, mediaType := range manifestMediaTypes {
+			if !strings.Contains(registry.accept, mediaType) {
+				t.Errorf("manifest request accepts %q, want %s as well", registry.accept, mediaType)
+			}
+		}
+	})
+
+	t.Run("token handshake", func(t *testing.T) {
+		registry := newTestRegistry(t, "bearer", nil)
+
+		digest, err := ResolveDigest(context.Background(), registry.image("team/app:1.0"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if digest != testDigest || registry.tokenRequests != 1 {
+			t.Errorf("got digest %s after %d token requests, want %s after 1", digest, registry.tokenRequests, testDigest)
+		}
+	})
+
+	t.Run("token handshake with pull secret", func(t *testing.T) {
+		credentials := &Credentials{Username: "robot", Password: "secret"}
+		registry := newTestRegistry(t, "bearer", credentials)
+
+		if _, err := ResolveDigest(context.Background(), registry.image("team/app:1.0"), nil); err == nil {
+			t.Error("got no error without credentials, want error")
+		}
+		digest, err := ResolveDigest(context.Background(), registry.image("team/app:1.0"), credentials)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if digest != testDigest {
+			t.Errorf("got digest %s, want %s", digest, testDigest)
+		}
+	})
+
+	t.Run("basic auth with pull secret", func(t *testing.T) {
+		credentials := &Credentials{Username: "robot", Password: "secret"}
+		registry := newTestRegistry(t, "basic", credentials)
+
+		if _, err := ResolveDigest(context.Background(), registry.image("team/app:1.0"), nil); err == nil {
+			t.Error("got no error without credentials, want error")
+		}
+		if _, err := ResolveDigest(context.Background(), registry.image("team/app:1.0"), &Credentials{Username: "robot"}); err == nil {
+			t.Error("got no error with wrong credentials, want error")
+		}
+		digest, err := ResolveDigest(context.Background(), registry.image("team/app:1.0"), credentials)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if digest != testDigest {
+			t.Errorf("got digest %s, want %s", digest, testDigest)
+		}
+	})
+
+	t.Run("manifest list without digest header", func(t *testing.T) {
+		registry := newTestRegistry(t, "bearer", nil)
+		registry.noDigestHeader = true
+
+		digest, err := ResolveDigest(context.Background(), registry.image("team/app:1.0"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		hash := sha256.Sum256([]byte(testIndex))
+		if want := "sha256:" + hex.EncodeToString(hash[:]); digest != want {
+			t.Errorf("got digest %s, want %s", digest, want)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		registry := newTestRegistry(t, "bearer", nil)
+
+		if _, err := ResolveDigest(context.Background(), registry.image("team/app:2.0"), nil); err == nil {
+			t.Error("got no error, want error")
+		}
+	})
+
+	t.Run("digest reference", func(t *testing.T) {
+		// Resolved without reaching registry
+		digest, err := ResolveDigest(context.Background(), "127.0.0.1:1/team/app@"+testDigest, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if digest != testDigest {
+			t.Errorf("got digest %s, want %s", digest, testDigest)
+		}
+	})
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image   string
+		want    Reference
+		wantErr bool
+	}{
+		{image: "python", want: Reference{Domain: "docker.io", Repository: "library/python", Tag: "latest"}},
+		{image: "python:3.11", want: Reference{Domain: "docker.io", Repository: "library/python", Tag: "3.11"}},
+		{image: "team/app:1.0", want: Reference{Domain: "docker.io", Repository: "team/app", Tag: "1.0"}},
+		{image: "ghcr.io/org/app", want: Reference{Domain: "ghcr.io", Repository: "org/app", Tag: "latest"}},
+		{image: "localhost/app:dev", want: Reference{Domain: "localhost", Repository: "app", Tag: "dev"}},
+		{image: "registry:5000/team/app", want: Reference{Domain: "registry:5000", Repository: "team/app", Tag: "latest"}},
+		{image: "registry:5000/team/app:1.0@" + testDigest, want: Reference{Domain: "registry:5000", Repository: "team/app", Tag: "1.0", Digest: testDigest}},
+		{image: "team/app@" + testDigest, want: Reference{Domain: "docker.io", Repository: "team/app", Digest: testDigest}},
+		{image: "team/app@6b86b273", wantErr: true},
+		{image: "Team/App", wantErr: true},
+		{image: "ghcr.io/", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			ref, err := ParseReference(test.image)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got reference %+v, want error", ref)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ref != test.want {
+				t.Errorf("got reference %+v, want %+v", ref, test.want)
+			}
+		})
+	}
+}
+
+func TestPinDigest(t *testing.T) {
+	tests := []struct {
+		image  string
+		digest string
+		want   string
+	}{
+		{image: "team/app:1.0", digest: testDigest, want: "team/app@" + testDigest},
+		{image: "registry:5000/team/app", digest: testDigest, want: "registry:5000/team/app@" + testDigest},
+		{image: "registry:5000/team/app:1.0", digest: testDigest, want: "registry:5000/team/app@" + testDigest},
+		{image: "team/app:1.0", want: "team/app:1.0"},
+		{image: "team/app@" + testDigest, digest: "sha256:other", want: "team/app@" + testDigest},
+	}
+
+	for _, test := range tests {
+		if got := PinDigest(test.image, test.digest); got != test.want {
+			t.Errorf("got image %s, want %s", got, test.want)
+		}
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/python:pull"`)
+	want := map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/python:pull",
+	}
+	if scheme != "Bearer" || !reflect.DeepEqual(params, want) {
+		t.Errorf("got %s %v, want Bearer %v", scheme, params, want)
+	}
+}
+
+func TestCredentialsFromDockerConfig(t *testing.T) {
+	config := []byte(`{"auths":{
+		"https://index.docker.io/v1/":{"auth":"cm9ib3Q6aHViLXNlY3JldA=="},
+		"ghcr.io":{"username":"robot","password":"ghcr-secret"},
+		"quay.io":{"auth":"not base64!"}
+	}}`)
+
+	tests := []struct {
+		domain  string
+		want    *Credentials
+		wantErr bool
+	}{
+		{domain: "docker.io", want: &Credentials{Username: "robot", Password: "hub-secret"}},
+		{domain: "ghcr.io", want: &Credentials{Username: "robot", Password: "ghcr-secret"}},
+		{domain: "registry.example.com"},
+		{domain: "quay.io", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.domain, func(t *testing.T) {
+			credentials, err := CredentialsFromDockerConfig(config, test.domain)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got credentials %+v, want error", credentials)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(credentials, test.want) {
+				t.Errorf("got credentials %+v, want %+v", credentials, test.want)
+			}
+		})
+	}
+}
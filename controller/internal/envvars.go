@@ -18,7 +18,7 @@ package internal
 
 // EnvVars used throughout code
 type EnvVars struct {
-	TinyAppNamespace  string            `env:"TINY_APP_NAMESPACE,notEmpty""`
+	TinyAppNamespace  string            `env:"TINY_APP_NAMESPACE,notEmpty"`
 	AppServiceAccount string            `env:"APP_SERVICE_ACCOUNT" envDefault:"default"`
 	GitSyncImage      string            `env:"GIT_SYNC_IMAGE" envDefault:"registry.k8s.io/git-sync/git-sync:v3.6.8"`
 	GitSyncEnvVars    map[string]string `env:"GIT_SYNC_ENV_VARS" envKeyValSeparator:"="`
@@ -34,4 +34,34 @@ type EnvVars struct {
 	DefaultAppEnvVars        map[string]string `env:"DEFAULT_APP_ENV_VARS" envKeyValSeparator:"="`
 	PodAnnotations           map[string]string `env:"POD_ANNOTATIONS" envKeyValSeparator:"="`
 	IngressAnnotations       map[string]string `env:"INGRESS_ANNOTATIONS" envKeyValSeparator:"="`
+	// Used for app container when app spec does not set resources
+	DefaultAppCPURequest    string `env:"DEFAULT_APP_CPU_REQUEST" envDefault:"50m"`
+	DefaultAppCPULimit      string `env:"DEFAULT_APP_CPU_LIMIT" envDefault:"1"`
+	DefaultAppMemoryRequest string `env:"DEFAULT_APP_MEMORY_REQUEST" envDefault:"256Mi"`
+	DefaultAppMemoryLimit   string `env:"DEFAULT_APP_MEMORY_LIMIT" envDefault:"3Gi"`
+	// Upper bounds for app container requests & limits. No bound if empty.
+	MaxAppCPU    string `env:"MAX_APP_CPU"`
+	MaxAppMemory string `env:"MAX_APP_MEMORY"`
+	// Service that ingress of sleeping apps routes to. Apps are never scaled to zero if empty.
+	ActivatorServiceName string `env:"ACTIVATOR_SERVICE_NAME"`
+	ActivatorServicePort int32  `env:"ACTIVATOR_SERVICE_PORT" envDefault:"8080"`
+	// OpenID Connect provider that gateway logs users in with, for apps that require login.
+	// Secret must have client-secret & session-secret keys.
+	GatewayOIDCIssuerURL     string `env:"GATEWAY_OIDC_ISSUER_URL"`
+	GatewayOIDCClientID      string `env:"GATEWAY_OIDC_CLIENT_ID"`
+	GatewayOIDCSecretName    string `env:"GATEWAY_OIDC_SECRET_NAME"`
+	GatewayOIDCUsernameClaim string `env:"GATEWAY_OIDC_USERNAME_CLAIM" envDefault:"email"`
+	GatewayOIDCGroupsClaim   string `env:"GATEWAY_OIDC_GROUPS_CLAIM" envDefault:"groups"`
+	// Set by defaulting webhook on TinyApps that leave them empty. Should match tinyapp-server's values.
+	DefaultAppImage       string `env:"DEFAULT_APP_IMAGE"`
+	AppIngressDomain      string `env:"APP_INGRESS_DOMAIN"`
+	AppIngressSubPath     string `env:"APP_INGRESS_SUB_PATH"`
+	AppIngressTlsEnabled  bool   `env:"APP_INGRESS_TLS_ENABLED" envDefault:"true"`
+	DefaultGitTokenSecret string `env:"DEFAULT_GIT_TOKEN_SECRET"`
+	// ConfigMap that maps app types operators add, or override, to their app runtimes. Built-in runtimes only if empty.
+	AppRuntimesConfigMap string `env:"APP_RUNTIMES_CONFIGMAP"`
+	// Serve admission webhooks. Requires a serving certificate (tls.crt & tls.key) in WEBHOOK_CERT_DIR.
+	WebhookEnabled bool   `env:"WEBHOOK_ENABLED" envDefault:"false"`
+	WebhookPort    int    `env:"WEBHOOK_PORT" envDefault:"9443"`
+	WebhookCertDir string `env:"WEBHOOK_CERT_DIR" envDefault:"/tmp/k8s-webhook-server/serving-certs"`
 }
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Env vars read by app start script, which tell app framework the port to listen on and the url path it is served
+// under. Frameworks are passed app path the way they expect it, with or without trailing slash.
+const (
+	StreamlitPortEnvVarName    = "STREAMLIT_PORT"
+	StreamlitBaseUrlEnvVarName = "STREAMLIT_BASE_URL"
+	DashPortEnvVarName         = "DASH_PORT"
+	DashBaseUrlEnvVarName      = "DASH_URL_BASE_PATHNAME"
+	GradioPortEnvVarName       = "GRADIO_SERVER_PORT"
+	GradioHostEnvVarName       = "GRADIO_SERVER_NAME"
+	GradioRootPathEnvVarName   = "GRADIO_ROOT_PATH"
+	PanelPortEnvVarName        = "PANEL_PORT"
+	PanelPrefixEnvVarName      = "PANEL_PREFIX"
+	ShinyPortEnvVarName        = "SHINY_PORT"
+	ShinyRootPathEnvVarName    = "SHINY_ROOT_PATH"
+	VoilaPortEnvVarName        = "VOILA_PORT"
+	VoilaBaseUrlEnvVarName     = "VOILA_BASE_URL"
+	BokehPortEnvVarName        = "BOKEH_PORT"
+	BokehPrefixEnvVarName      = "BOKEH_PREFIX"
+	// Read by Bokeh server, which Panel runs on too. Websocket connections are rejected from other origins.
+	BokehAllowWsOriginEnvVarName = "BOKEH_ALLOW_WS_ORIGIN"
+)
+
+// Templates of runtime params shared by built-in runtimes
+const (
+	portTemplate     = "{{.Port}}"
+	basePathTemplate = "{{.BasePath}}"
+	// Origin that browsers open app websockets from
+	wsOriginTemplate = `{{or .IngressDomain "*"}}`
+)
+
+// Built-in app types are run by start script of app image, which installs requirements and starts the framework of
+// TINY_APP_TYPE with the port & base path of env vars above. See docs/getting_started.md for the full contract.
+var startScriptArgs = []string{"start-tinyapp.py"}
+
+// builtInRuntimeConfigs declares runtimes of built-in app types.
+var builtInRuntimeConfigs = map[v1alpha1.AppType]RuntimeConfig{
+	v1alpha1.AppTypeStreamlit: {
+		Args: startScriptArgs,
+		Env: []RuntimeEnvVar{
+			{Name: StreamlitPortEnvVarName, Value: portTemplate},
+			{Name: StreamlitBaseUrlEnvVarName, Value: basePathTemplate},
+		},
+		Port:       util.DefaultAppPort,
+		HealthPath: "/_stcore/health",
+	},
+	v1alpha1.AppTypeDash: {
+		Args: startScriptArgs,
+		Env: []RuntimeEnvVar{
+			{Name: DashPortEnvVarName, Value: portTemplate},
+			{Name: DashBaseUrlEnvVarName, Value: basePathTemplate},
+		},
+		Port: util.DefaultAppPort,
+		// Required by gunicorn
+		BasePathTrailingSlash: true,
+	},
+	v1alpha1.AppTypeGradio: {
+		Args: startScriptArgs,
+		Env: []RuntimeEnvVar{
+			{Name: GradioPortEnvVarName, Value: portTemplate},
+			// Gradio listens on localhost only by default, which gateway can't reach
+			{Name: GradioHostEnvVarName, Value: "0.0.0.0"},
+			{Name: GradioRootPathEnvVarName, Value: basePathTemplate},
+		},
+		Port: util.DefaultAppPort,
+	},
+	v1alpha1.AppTypePanel: {
+		Args: startScriptArgs,
+		Env: []RuntimeEnvVar{
+			{Name: PanelPortEnvVarName, Value: portTemplate},
+			{Name: PanelPrefixEnvVarName, Value: basePathTemplate},
+			{Name: BokehAllowWsOriginEnvVarName, Value: wsOriginTemplate},
+		},
+		Port: util.DefaultAppPort,
+	},
+	v1alpha1.AppTypeShiny: {
+		Args: startScriptArgs,
+		Env: []RuntimeEnvVar{
+			{Name: ShinyPortEnvVarName, Value: portTemplate},
+			{Name: ShinyRootPathEnvVarName, Value: basePathTemplate},
+		},
+		Port: util.DefaultAppPort,
+	},
+	v1alpha1.AppTypeVoila: {
+		Args: startScriptArgs,
+		Env: []RuntimeEnvVar{
+			{Name: VoilaPortEnvVarName, Value: portTemplate},
+			{Name: VoilaBaseUrlEnvVarName, Value: basePathTemplate},
+		},
+		Port: util.DefaultAppPort,
+		// Jupyter server base url must end with forward slash
+		BasePathTrailingSlash: true,
+	},
+	v1alpha1.AppTypeBokeh: {
+		Args: startScriptArgs,
+		Env: []RuntimeEnvVar{
+			{Name: BokehPortEnvVarName, Value: portTemplate},
+			{Name: BokehPrefixEnvVarName, Value: basePathTemplate},
+			{Name: BokehAllowWsOriginEnvVarName, Value: wsOriginTemplate},
+		},
+		Port: util.DefaultAppPort,
+	},
+}
+
+// builtInRuntimes returns runtimes of built-in app types.
+func builtInRuntimes() map[v1alpha1.AppType]AppRuntime {
+	runtimes := make(map[v1alpha1.AppType]AppRuntime, len(builtInRuntimeConfigs))
+	for appType, config := range builtInRuntimeConfigs {
+		runtime, err := NewConfigRuntime(config)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in runtime of app type %s: %v", appType, err))
+		}
+		runtimes[appType] = runtime
+	}
+	return runtimes
+}
+
+// customRuntime runs app of Custom app type with the command & port set in its spec.
+type customRuntime struct {
+	custom v1alpha1.CustomApp
+}
+
+func (r *customRuntime) Command(_ RuntimeParams) ([]string, []string, error) {
+	return r.custom.Command, r.custom.Args, nil
+}
+
+func (r *customRuntime) EnvVars(params RuntimeParams) ([]corev1.EnvVar, error) {
+	if r.custom.BasePathEnvVarName == "" {
+		return nil, nil
+	}
+	return []corev1.EnvVar{{Name: r.custom.BasePathEnvVarName, Value: params.BasePath}}, nil
+}
+
+func (r *customRuntime) Port() int32 {
+	return r.custom.Port
+}
+
+func (r *customRuntime) HealthPath() string {
+	return "/"
+}
+
+// StripBasePath returns true if app isn't told its base path, in which case it can only be served at root path.
+func (r *customRuntime) StripBasePath() bool {
+	return r.custom.BasePathEnvVarName == ""
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+)
+
+// Built-in app types run the start script, which is told port & base path of app through framework env vars.
+func TestBuildDeploymentBuiltInAppTypes(t *testing.T) {
+	tests := []struct {
+		appType v1alpha1.AppType
+		wantEnv map[string]string
+	}{
+		{
+			appType: v1alpha1.AppTypeStreamlit,
+			wantEnv: map[string]string{StreamlitPortEnvVarName: "5000", StreamlitBaseUrlEnvVarName: "/apps/abc"},
+		},
+		{
+			appType: v1alpha1.AppTypeDash,
+			wantEnv: map[string]string{DashPortEnvVarName: "5000", DashBaseUrlEnvVarName: "/apps/abc/"},
+		},
+		{
+			appType: v1alpha1.AppTypeGradio,
+			wantEnv: map[string]string{
+				GradioPortEnvVarName:     "5000",
+				GradioHostEnvVarName:     "0.0.0.0",
+				GradioRootPathEnvVarName: "/apps/abc",
+			},
+		},
+		{
+			appType: v1alpha1.AppTypePanel,
+			wantEnv: map[string]string{
+				PanelPortEnvVarName:          "5000",
+				PanelPrefixEnvVarName:        "/apps/abc",
+				BokehAllowWsOriginEnvVarName: "apps.example.com",
+			},
+		},
+		{
+			appType: v1alpha1.AppTypeShiny,
+			wantEnv: map[string]string{ShinyPortEnvVarName: "5000", ShinyRootPathEnvVarName: "/apps/abc"},
+		},
+		{
+			appType: v1alpha1.AppTypeVoila,
+			wantEnv: map[string]string{VoilaPortEnvVarName: "5000", VoilaBaseUrlEnvVarName: "/apps/abc/"},
+		},
+		{
+			appType: v1alpha1.AppTypeBokeh,
+			wantEnv: map[string]string{
+				BokehPortEnvVarName:          "5000",
+				BokehPrefixEnvVarName:        "/apps/abc",
+				BokehAllowWsOriginEnvVarName: "apps.example.com",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.appType), func(t *testing.T) {
+			app := newTestGitApp(v1alpha1.GitConfig{GitRef: "main"})
+			app.Spec.AppType = test.appType
+			app.Spec.IngressDomain, app.Spec.IngressSubPath = "apps.example.com", "/apps"
+
+			deployment, err := BuildDeployment(app, newTestEnv(), builtInRuntimes()[test.appType])
+			if err != nil {
+				t.Fatalf("failed to build deployment: %v", err)
+			}
+
+			appContainer := deployment.Spec.Template.Spec.Containers[0]
+			if appContainer.Command != nil || !reflect.DeepEqual(appContainer.Args, []string{"start-tinyapp.py"}) {
+				t.Errorf("got command %v & args %v, want start script args", appContainer.Command, appContainer.Args)
+			}
+			for name, want := range test.wantEnv {
+				if value, ok := envValue(appContainer.Env, name); !ok || value != want {
+					t.Errorf("got %s=%q (set %v), want %q", name, value, ok, want)
+				}
+			}
+			// Start script picks the framework by app type
+			if value, _ := envValue(appContainer.Env, TinyAppTypeEnvVarName); value != strings.ToLower(string(test.appType)) {
+				t.Errorf("got %s=%q, want %q", TinyAppTypeEnvVarName, value, strings.ToLower(string(test.appType)))
+			}
+		})
+	}
+}
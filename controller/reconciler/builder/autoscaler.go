@@ -0,0 +1,95 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+
+	"github.com/pkg/errors"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func BuildHorizontalPodAutoscaler(app *v1alpha1.TinyApp, env internal.EnvVars) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	policy := app.Spec.Autoscaling
+	if policy == nil {
+		return nil, errors.New("autoscaling is not enabled for app")
+	}
+
+	minReplicas := getAutoscalingMinReplicas(policy)
+	if policy.MaxReplicas < minReplicas {
+		return nil, errors.Errorf("autoscaling max replicas %d is less than min replicas %d", policy.MaxReplicas, minReplicas)
+	}
+
+	targetCPUUtilization := util.DefaultTargetCPUUtilizationPercentage
+	if policy.TargetCPUUtilizationPercentage != nil {
+		targetCPUUtilization = *policy.TargetCPUUtilizationPercentage
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            app.Name,
+			Namespace:       env.TinyAppNamespace,
+			Labels:          app.GetLabels(),
+			OwnerReferences: createOwnerRefs(app),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       app.Name,
+			},
+			MinReplicas: pointer.Int32(minReplicas),
+			MaxReplicas: policy.MaxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: pointer.Int32(targetCPUUtilization),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	hash, err := getObjectHash(hpa)
+	if err != nil {
+		return nil, err
+	}
+	hpa.Annotations = map[string]string{
+		util.AnnotationResourceHash: hash,
+	}
+
+	return hpa, nil
+}
+
+// getAutoscalingMinReplicas returns min replicas of autoscaling policy, or the default if not set.
+func getAutoscalingMinReplicas(policy *v1alpha1.AutoscalingPolicy) int32 {
+	if policy.MinReplicas == nil {
+		return util.DefaultAppReplicas
+	}
+
+	return *policy.MinReplicas
+}
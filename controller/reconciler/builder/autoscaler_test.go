@@ -0,0 +1,84 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"k8s.io/utils/pointer"
+)
+
+func TestBuildHorizontalPodAutoscaler(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        *v1alpha1.AutoscalingPolicy
+		wantMin       int32
+		wantMax       int32
+		wantTargetCPU int32
+		wantErr       bool
+	}{
+		{
+			name:          "defaults",
+			policy:        &v1alpha1.AutoscalingPolicy{MaxReplicas: 3},
+			wantMin:       util.DefaultAppReplicas,
+			wantMax:       3,
+			wantTargetCPU: util.DefaultTargetCPUUtilizationPercentage,
+		},
+		{
+			name:          "policy",
+			policy:        &v1alpha1.AutoscalingPolicy{MinReplicas: pointer.Int32(2), MaxReplicas: 5, TargetCPUUtilizationPercentage: pointer.Int32(60)},
+			wantMin:       2,
+			wantMax:       5,
+			wantTargetCPU: 60,
+		},
+		{name: "autoscaling disabled", wantErr: true},
+		{name: "max below min", policy: &v1alpha1.AutoscalingPolicy{MinReplicas: pointer.Int32(3), MaxReplicas: 2}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := newTestGitApp(v1alpha1.GitConfig{GitRef: "main"})
+			app.Spec.Autoscaling = test.policy
+
+			hpa, err := BuildHorizontalPodAutoscaler(app, newTestEnv())
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("got no error, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if target := hpa.Spec.ScaleTargetRef; target.Kind != "Deployment" || target.Name != app.Name {
+				t.Errorf("got scale target %v, want deployment %s", target, app.Name)
+			}
+			if *hpa.Spec.MinReplicas != test.wantMin || hpa.Spec.MaxReplicas != test.wantMax {
+				t.Errorf("got replicas %d-%d, want %d-%d", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas, test.wantMin, test.wantMax)
+			}
+			if len(hpa.Spec.Metrics) != 1 || *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization != test.wantTargetCPU {
+				t.Errorf("got metrics %v, want cpu utilization %d", hpa.Spec.Metrics, test.wantTargetCPU)
+			}
+			if hpa.Annotations[util.AnnotationResourceHash] == "" {
+				t.Error("hpa has no resource hash")
+			}
+		})
+	}
+}
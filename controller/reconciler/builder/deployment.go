@@ -17,6 +17,7 @@ limitations under the License.
 package builder
 
 import (
+	"fmt"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -26,30 +27,32 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/tinymultiverse/tinyapp/controller/image"
 	"github.com/tinymultiverse/tinyapp/controller/internal"
 
 	"github.com/tinymultiverse/tinyapp/controller/util"
 	globalutil "github.com/tinymultiverse/tinyapp/util"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
 )
 
 const (
 	GitSyncBranchEnvVarName    = "GIT_SYNC_BRANCH"
-	GitSyncTagEnvVarName       = "GIT_SYNC_REV"
+	GitSyncRevEnvVarName       = "GIT_SYNC_REV"
 	MainFileEnvVarName         = "MAIN_FILE"
 	MainDirectoryEnvVarName    = "BASE_DIR"
 	RequirementsFileEnvVarName = "REQUIREMENTS_FILE"
 	TinyAppTypeEnvVarName      = "TINY_APP_TYPE"
 	TinyAppNameEnvVarName      = "TINY_APP_NAME"
-	StreamlitPortEnvVarName    = "STREAMLIT_PORT"
-	DashPortEnvVarName         = "DASH_PORT"
-	StreamlitBaseUrlEnvVarName = "STREAMLIT_BASE_URL"
-	DashBaseUrlEnvVarName      = "DASH_URL_BASE_PATHNAME"
 	RequirementsFileName       = "requirements.txt"
 )
 
-func BuildDeployment(app *v1alpha1.TinyApp, env internal.EnvVars) (*appsv1.Deployment, error) {
+// Name of gateway admin port, which gateway probes refer to
+const gatewayAdminPortName = "gatewayadmin"
+
+// BuildDeployment returns deployment of app, whose app container is run by given app runtime.
+func BuildDeployment(app *v1alpha1.TinyApp, env internal.EnvVars, runtime AppRuntime) (*appsv1.Deployment, error) {
 	var volumes []corev1.Volume
 	for _, volumeClaim := range app.Spec.VolumeClaims {
 		volumes = append(volumes, buildVolume(volumeClaim.Name))
@@ -67,16 +70,26 @@ func BuildDeployment(app *v1alpha1.TinyApp, env internal.EnvVars) (*appsv1.Deplo
 		})
 	}
 
-	appContainer, err := buildAppContainer(app, env)
+	params, err := buildRuntimeParams(app, runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	appContainer, err := buildAppContainer(app, env, runtime, params)
 	if err != nil {
 		return nil, err
 	}
 
-	containers := []corev1.Container{appContainer, buildGatewayContainer(app, env)}
+	gatewayContainer, err := buildGatewayContainer(app, env, runtime, params)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := []corev1.Container{appContainer, gatewayContainer}
 	var initContainers []corev1.Container
 
 	if app.Spec.SourceType == v1alpha1.SourceTypeGit {
-		initContainers = append(initContainers, buildGitSyncContainer(app, env))
+		initContainers = append(initContainers, buildGitSyncContainer(app, env), buildGitRevisionContainer(env))
 		volumes = append(volumes, buildGitCloneVolume())
 		volumes = append(volumes, buildGitTokenVolume(app.Spec.GitConfig.TokenSecretName))
 	}
@@ -89,16 +102,15 @@ func BuildDeployment(app *v1alpha1.TinyApp, env internal.EnvVars) (*appsv1.Deplo
 			OwnerReferences: createOwnerRefs(app),
 		},
 		Spec: appsv1.DeploymentSpec{
+			Replicas: buildReplicas(app),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: app.Labels,
 			},
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RecreateDeploymentStrategyType,
-			},
+			Strategy: buildDeploymentStrategy(app),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      app.GetLabels(),
-					Annotations: env.PodAnnotations,
+					Annotations: buildPodAnnotations(app, env),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: env.AppServiceAccount,
@@ -121,55 +133,219 @@ func BuildDeployment(app *v1alpha1.TinyApp, env internal.EnvVars) (*appsv1.Deplo
 	return deployment, nil
 }
 
-func buildAppContainer(app *v1alpha1.TinyApp, env internal.EnvVars) (corev1.Container, error) {
+// buildPodAnnotations returns annotations of app pods. Changing restart annotation of app changes pod template,
+// which rolls app pods.
+func buildPodAnnotations(app *v1alpha1.TinyApp, env internal.EnvVars) map[string]string {
+	annotations := make(map[string]string, len(env.PodAnnotations)+1)
+	for key, value := range env.PodAnnotations {
+		annotations[key] = value
+	}
+
+	if restartedAt := app.Annotations[globalutil.AnnotationRestartedAt]; restartedAt != "" {
+		annotations[globalutil.AnnotationRestartedAt] = restartedAt
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
+// buildReplicas returns the number of app pods to deploy.
+// When autoscaling is enabled this is only the initial count; afterward the HorizontalPodAutoscaler owns it.
+func buildReplicas(app *v1alpha1.TinyApp) *int32 {
+	if app.Status.IsSleeping() {
+		return pointer.Int32(0)
+	}
+
+	if app.Spec.Autoscaling != nil {
+		return pointer.Int32(getAutoscalingMinReplicas(app.Spec.Autoscaling))
+	}
+
+	if app.Spec.Replicas != nil {
+		return pointer.Int32(*app.Spec.Replicas)
+	}
+
+	return pointer.Int32(util.DefaultAppReplicas)
+}
+
+// buildDeploymentStrategy returns rolling update strategy so that updates don't cause downtime.
+// Apps that mount volume claims are recreated instead, since claims may only be mountable by one pod at a time.
+func buildDeploymentStrategy(app *v1alpha1.TinyApp) appsv1.DeploymentStrategy {
+	if len(app.Spec.VolumeClaims) > 0 {
+		return appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+	}
+
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+	}
+}
+
+func buildAppContainer(app *v1alpha1.TinyApp, env internal.EnvVars, runtime AppRuntime, params RuntimeParams) (corev1.Container, error) {
 	if strings.TrimSpace(app.Spec.Image) == "" {
 		return corev1.Container{}, errors.New("image name is empty")
 	}
 
-	envVars, err := buildAppEnvVars(app, env)
+	command, args, err := runtime.Command(params)
 	if err != nil {
 		return corev1.Container{}, err
 	}
 
+	envVars, err := buildAppEnvVars(app, env, runtime, params)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	resources, err := buildAppResources(app, env)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	startupProbe, readinessProbe, livenessProbe := buildAppProbes(app, runtime.Port(), buildAppHealthPath(app, runtime, params))
+	containerImage, imagePullPolicy := buildAppImage(app)
+
 	appContainer := corev1.Container{
 		Name:            util.AppContainerName,
-		Image:           app.Spec.Image,
-		ImagePullPolicy: corev1.PullAlways,
-		Args: []string{
-			"start-tinyapp.py",
+		Image:           containerImage,
+		ImagePullPolicy: imagePullPolicy,
+		Command:         command,
+		Args:            args,
+		Env:             envVars,
+		Resources:       resources,
+		VolumeMounts:    buildAppVolumeMounts(app),
+		StartupProbe:    startupProbe,
+		ReadinessProbe:  readinessProbe,
+		LivenessProbe:   livenessProbe,
+		// Surface startup errors (ex. failed pip install) in container status
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+
+	return appContainer, nil
+}
+
+// buildAppImage returns image of app container and its pull policy. Apps baked into their image run the image
+// digest resolved by controller, which never changes once pulled.
+func buildAppImage(app *v1alpha1.TinyApp) (string, corev1.PullPolicy) {
+	if app.Spec.SourceType == v1alpha1.SourceTypeImage && app.Status.ImageDigest != "" {
+		return image.PinDigest(app.Spec.Image, app.Status.ImageDigest), corev1.PullIfNotPresent
+	}
+
+	return app.Spec.Image, corev1.PullAlways
+}
+
+// buildAppResources returns resource requirements for app container.
+// Values not set in app spec are filled with controller defaults. Returns error if any value exceeds the allowed maximum.
+func buildAppResources(app *v1alpha1.TinyApp, env internal.EnvVars) (corev1.ResourceRequirements, error) {
+	resources := v1alpha1.AppResources{}
+	if app.Spec.Resources != nil {
+		resources = *app.Spec.Resources
+	}
+
+	cpuRequest, cpuLimit, err := buildResourceQuantities("cpu", resources.CPURequest, resources.CPULimit,
+		env.DefaultAppCPURequest, env.DefaultAppCPULimit, env.MaxAppCPU)
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+
+	memoryRequest, memoryLimit, err := buildResourceQuantities("memory", resources.MemoryRequest, resources.MemoryLimit,
+		env.DefaultAppMemoryRequest, env.DefaultAppMemoryLimit, env.MaxAppMemory)
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    cpuRequest,
+			corev1.ResourceMemory: memoryRequest,
 		},
-		Env: envVars,
-		Resources: corev1.ResourceRequirements{
-			Requests: corev1.ResourceList{
-				// TODO make it part of app spec
-				corev1.ResourceCPU:    resource.MustParse(util.AppCPURequest),
-				corev1.ResourceMemory: resource.MustParse(util.AppMemoryRequest),
-			},
-			Limits: corev1.ResourceList{
-				corev1.ResourceCPU:    resource.MustParse(util.AppCPULimit),
-				corev1.ResourceMemory: resource.MustParse(util.AppMemoryLimit),
-			},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    cpuLimit,
+			corev1.ResourceMemory: memoryLimit,
 		},
-		VolumeMounts: buildAppVolumeMounts(app),
+	}, nil
+}
+
+// buildResourceQuantities parses request & limit of a resource, falling back to given defaults for empty values.
+// If only request is set and it is above the default limit, limit is raised to match the request. If only limit is
+// set and it is below the default request, request is lowered to match the limit.
+func buildResourceQuantities(name, request, limit, defaultRequest, defaultLimit, max string) (resource.Quantity, resource.Quantity, error) {
+	requestQuantity, err := parseQuantity(request, defaultRequest)
+	if err != nil {
+		return resource.Quantity{}, resource.Quantity{}, errors.WithMessagef(err, "invalid %s request", name)
 	}
 
-	return appContainer, nil
+	limitQuantity, err := parseQuantity(limit, defaultLimit)
+	if err != nil {
+		return resource.Quantity{}, resource.Quantity{}, errors.WithMessagef(err, "invalid %s limit", name)
+	}
+
+	if requestQuantity.Cmp(limitQuantity) > 0 {
+		if strings.TrimSpace(limit) == "" {
+			limitQuantity = requestQuantity.DeepCopy()
+		} else if strings.TrimSpace(request) == "" {
+			requestQuantity = limitQuantity.DeepCopy()
+		}
+	}
+
+	if requestQuantity.Cmp(limitQuantity) > 0 {
+		return resource.Quantity{}, resource.Quantity{}, errors.Errorf("%s request %s is greater than limit %s",
+			name, requestQuantity.String(), limitQuantity.String())
+	}
+
+	if strings.TrimSpace(max) != "" {
+		maxQuantity, err := resource.ParseQuantity(max)
+		if err != nil {
+			return resource.Quantity{}, resource.Quantity{}, errors.WithMessagef(err, "invalid maximum %s", name)
+		}
+
+		if limitQuantity.Cmp(maxQuantity) > 0 {
+			return resource.Quantity{}, resource.Quantity{}, errors.Errorf("%s limit %s exceeds maximum allowed %s",
+				name, limitQuantity.String(), maxQuantity.String())
+		}
+	}
+
+	return requestQuantity, limitQuantity, nil
+}
+
+// parseQuantity parses value as resource quantity, or defaultValue if value is empty.
+func parseQuantity(value, defaultValue string) (resource.Quantity, error) {
+	if strings.TrimSpace(value) == "" {
+		value = defaultValue
+	}
+
+	return resource.ParseQuantity(value)
 }
 
-func buildAppEnvVars(app *v1alpha1.TinyApp, env internal.EnvVars) ([]corev1.EnvVar, error) {
+// buildRuntimeParams returns the values of app that app runtime is configured with.
+func buildRuntimeParams(app *v1alpha1.TinyApp, runtime AppRuntime) (RuntimeParams, error) {
+	basePath, err := BuildIngressPath(app.Spec.IngressSubPath, app.Name)
+	if err != nil {
+		return RuntimeParams{}, errors.WithMessage(err, "failed to build ingress path")
+	}
+
+	return RuntimeParams{
+		AppName:       app.Name,
+		Port:          runtime.Port(),
+		BasePath:      basePath,
+		IngressDomain: app.Spec.IngressDomain,
+	}, nil
+}
+
+func buildAppEnvVars(app *v1alpha1.TinyApp, env internal.EnvVars, runtime AppRuntime, params RuntimeParams) ([]corev1.EnvVar, error) {
 	mainFileDir, mainFileName := filepath.Split(app.Spec.MainFilePath)
 
 	baseDir := util.GitRootDir + "/" + util.GitDestDir
-	if app.Spec.SourceType == v1alpha1.SourceTypeFileSystem {
+	switch app.Spec.SourceType {
+	case v1alpha1.SourceTypeFileSystem:
 		baseDir = getMainVolumeClaimMountPath(app)
 		if baseDir == "" {
 			return nil, errors.New("main volume claim name not found")
 		}
-	}
-
-	baseUrl, err := BuildIngressPath(app.Spec.IngressSubPath, app.Name)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to build ingress path")
+	case v1alpha1.SourceTypeImage:
+		// Main file path is relative to working directory of image
+		baseDir = "."
 	}
 
 	appEnvVars := []corev1.EnvVar{
@@ -189,28 +365,21 @@ func buildAppEnvVars(app *v1alpha1.TinyApp, env internal.EnvVars) ([]corev1.EnvV
 			Name:  TinyAppNameEnvVarName,
 			Value: app.Name,
 		},
-		{
+	}
+
+	// Dependencies of apps baked into their image are installed already
+	if app.Spec.SourceType != v1alpha1.SourceTypeImage {
+		appEnvVars = append(appEnvVars, corev1.EnvVar{
 			Name:  RequirementsFileEnvVarName,
 			Value: RequirementsFileName,
-		},
-		{
-			Name:  StreamlitPortEnvVarName,
-			Value: util.DefaultAppPort,
-		},
-		{
-			Name:  DashPortEnvVarName,
-			Value: util.DefaultAppPort,
-		},
-		{
-			Name:  StreamlitBaseUrlEnvVarName,
-			Value: baseUrl,
-		},
-		{
-			Name: DashBaseUrlEnvVarName,
-			// Forward slash at the end is required by gunicorn
-			Value: baseUrl + "/",
-		},
+		})
+	}
+
+	runtimeEnvVars, err := runtime.EnvVars(params)
+	if err != nil {
+		return nil, err
 	}
+	appEnvVars = append(appEnvVars, runtimeEnvVars...)
 
 	for key, val := range env.DefaultAppEnvVars {
 		appEnvVars = addEnvVar(key, val, appEnvVars)
@@ -264,8 +433,11 @@ func getMainVolumeClaimMountPath(app *v1alpha1.TinyApp) string {
 	return ""
 }
 
-func buildGatewayContainer(app *v1alpha1.TinyApp, env internal.EnvVars) corev1.Container {
-	envs := buildGatewayEnvVars(app, env)
+func buildGatewayContainer(app *v1alpha1.TinyApp, env internal.EnvVars, runtime AppRuntime, params RuntimeParams) (corev1.Container, error) {
+	envs, err := buildGatewayEnvVars(app, env, runtime, params)
+	if err != nil {
+		return corev1.Container{}, err
+	}
 
 	gatewayContainer := corev1.Container{
 		Name:  util.GatewayContainerName,
@@ -273,12 +445,19 @@ func buildGatewayContainer(app *v1alpha1.TinyApp, env internal.EnvVars) corev1.C
 		Ports: []corev1.ContainerPort{
 			{
 				Protocol:      "TCP",
-				ContainerPort: util.DefaultGatewayPort,
+				ContainerPort: globalutil.DefaultGatewayPort,
 				Name:          "gatewayport",
 			},
+			{
+				Protocol:      "TCP",
+				ContainerPort: globalutil.DefaultGatewayAdminPort,
+				Name:          gatewayAdminPortName,
+			},
 		},
 		ImagePullPolicy: corev1.PullAlways,
 		Env:             envs,
+		ReadinessProbe:  buildGatewayProbe(globalutil.GatewayReadinessPath),
+		LivenessProbe:   buildGatewayProbe(globalutil.GatewayLivenessPath),
 		Resources: corev1.ResourceRequirements{
 			Requests: corev1.ResourceList{
 				corev1.ResourceCPU:    resource.MustParse(util.GatewayContainerCPURequest),
@@ -299,12 +478,14 @@ func buildGatewayContainer(app *v1alpha1.TinyApp, env internal.EnvVars) corev1.C
 		},
 	}
 
-	return gatewayContainer
+	return gatewayContainer, nil
 }
 
-func buildGatewayEnvVars(app *v1alpha1.TinyApp, env internal.EnvVars) []corev1.EnvVar {
+func buildGatewayEnvVars(app *v1alpha1.TinyApp, env internal.EnvVars, runtime AppRuntime, params RuntimeParams) ([]corev1.EnvVar, error) {
 	envVars := []corev1.EnvVar{
-		{Name: "HTTP_PORT", Value: strconv.Itoa(int(util.DefaultGatewayPort))},
+		{Name: "HTTP_PORT", Value: strconv.Itoa(int(globalutil.DefaultGatewayPort))},
+		{Name: "ADMIN_PORT", Value: strconv.Itoa(int(globalutil.DefaultGatewayAdminPort))},
+		{Name: "APP_HEALTH_PATH", Value: buildAppHealthPath(app, runtime, params)},
 		{Name: "TINY_APP_NAME", Value: app.Name},
 		{Name: "METRICS_ENABLED", Value: strconv.FormatBool(env.GatewayMetricsEnabled)},
 		{Name: "METRICS_TLS_ENABLED", Value: strconv.FormatBool(env.GatewayMetricsTlsEnabled)},
@@ -312,21 +493,66 @@ func buildGatewayEnvVars(app *v1alpha1.TinyApp, env internal.EnvVars) []corev1.E
 		{Name: "METRICS_PATH", Value: env.GatewayMetricsPath},
 	}
 
+	// Gateway proxies to the default app port unless told otherwise, which keeps pods of existing apps as they were
+	if runtime.Port() != util.DefaultAppPort {
+		envVars = append(envVars, corev1.EnvVar{Name: "APP_PORT", Value: strconv.Itoa(int(runtime.Port()))})
+	}
+
+	if runtime.StripBasePath() {
+		envVars = append(envVars, corev1.EnvVar{Name: "STRIP_PATH_PREFIX", Value: params.BasePath})
+	}
+
+	if app.Spec.Auth != nil {
+		authEnvVars, err := buildGatewayAuthEnvVars(app, env, params)
+		if err != nil {
+			return nil, err
+		}
+		envVars = append(envVars, authEnvVars...)
+	}
+
 	envVars = append(envVars, buildEnvVarsList(env.GatewayEnvVars)...)
 
-	return envVars
+	return envVars, nil
 }
 
-func buildGitSyncContainer(app *v1alpha1.TinyApp, env internal.EnvVars) corev1.Container {
-	gitConfig := app.Spec.GitConfig
+// buildGatewayAuthEnvVars returns env vars that make gateway require users to log in before using app.
+func buildGatewayAuthEnvVars(app *v1alpha1.TinyApp, env internal.EnvVars, params RuntimeParams) ([]corev1.EnvVar, error) {
+	if env.GatewayOIDCIssuerURL == "" || env.GatewayOIDCClientID == "" || env.GatewayOIDCSecretName == "" {
+		return nil, errors.New("app requires login but gateway OIDC provider is not configured")
+	}
 
-	var gitRefEnvVarName string
-	if gitConfig.IsTag {
-		gitRefEnvVarName = GitSyncTagEnvVarName
-	} else {
-		gitRefEnvVarName = GitSyncBranchEnvVarName
+	appUrl, err := BuildAppURL(app.Spec.IngressDomain, app.Spec.IngressSubPath, app.Name, app.Spec.IngressTlsEnabled)
+	if err != nil {
+		return nil, err
 	}
 
+	return []corev1.EnvVar{
+		{Name: "AUTH_ENABLED", Value: "true"},
+		{Name: "OIDC_ISSUER_URL", Value: env.GatewayOIDCIssuerURL},
+		{Name: "OIDC_CLIENT_ID", Value: env.GatewayOIDCClientID},
+		{Name: "OIDC_CLIENT_SECRET", ValueFrom: buildSecretKeyRef(env.GatewayOIDCSecretName, util.GatewayOIDCClientSecretKey)},
+		{Name: "SESSION_SECRET", ValueFrom: buildSecretKeyRef(env.GatewayOIDCSecretName, util.GatewayOIDCSessionSecretKey)},
+		{Name: "OIDC_REDIRECT_URL", Value: strings.TrimSuffix(appUrl, "/") + globalutil.LoginCallbackPath},
+		{Name: "APP_BASE_PATH", Value: params.BasePath},
+		{Name: "OIDC_USERNAME_CLAIM", Value: env.GatewayOIDCUsernameClaim},
+		{Name: "OIDC_GROUPS_CLAIM", Value: env.GatewayOIDCGroupsClaim},
+		{Name: "ALLOWED_USERS", Value: strings.Join(app.Spec.Auth.AllowedUsers, ",")},
+		{Name: "ALLOWED_GROUPS", Value: strings.Join(app.Spec.Auth.AllowedGroups, ",")},
+	}, nil
+}
+
+func buildSecretKeyRef(secretName, key string) *corev1.EnvVarSource {
+	return &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  key,
+		},
+	}
+}
+
+func buildGitSyncContainer(app *v1alpha1.TinyApp, env internal.EnvVars) corev1.Container {
+	gitConfig := app.Spec.GitConfig
+
 	gitSyncEnvVars := []corev1.EnvVar{
 		{Name: "GIT_SYNC_ROOT", Value: util.GitRootDir},
 		{Name: "GIT_SYNC_DEST", Value: util.GitDestDir},
@@ -336,7 +562,30 @@ func buildGitSyncContainer(app *v1alpha1.TinyApp, env internal.EnvVars) corev1.C
 		{Name: "GIT_SYNC_REPO", Value: gitConfig.GitUrl},
 		{Name: "GIT_SYNC_ONE_TIME", Value: "true"},
 		{Name: "GIT_SYNC_WAIT", Value: "0"},
-		{Name: gitRefEnvVarName, Value: gitConfig.GitRef},
+	}
+
+	// git-sync only fetches its branch, which defaults to master. Tags are fetched like branches, and commits are
+	// fetched from the branch that contains them.
+	refType := gitConfig.GetRefType()
+	branch := gitConfig.GitRef
+	if refType == v1alpha1.GitRefTypeCommit {
+		branch = gitConfig.Branch
+		if branch == "" {
+			branch = app.Status.ResolvedGitBranch
+		}
+	}
+	if branch != "" {
+		gitSyncEnvVars = append(gitSyncEnvVars, corev1.EnvVar{Name: GitSyncBranchEnvVarName, Value: branch})
+	}
+
+	// Check out the commit resolved by controller, so that app pods are rolled when it changes.
+	// Tags & commits are checked out as revisions, and branches are checked out at their head until resolved.
+	rev := app.Status.ResolvedGitCommit
+	if rev == "" && refType != v1alpha1.GitRefTypeBranch {
+		rev = gitConfig.GitRef
+	}
+	if rev != "" {
+		gitSyncEnvVars = append(gitSyncEnvVars, corev1.EnvVar{Name: GitSyncRevEnvVarName, Value: rev})
 	}
 
 	extraEnvVars := buildEnvVarsList(env.GitSyncEnvVars)
@@ -346,6 +595,8 @@ func buildGitSyncContainer(app *v1alpha1.TinyApp, env internal.EnvVars) corev1.C
 		Image:           env.GitSyncImage,
 		ImagePullPolicy: corev1.PullAlways,
 		Env:             append(gitSyncEnvVars, extraEnvVars...),
+		// Surface clone errors in container status
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		Resources: corev1.ResourceRequirements{
 			Requests: corev1.ResourceList{
 				corev1.ResourceCPU:    resource.MustParse(util.GitSyncContainerCPURequest),
@@ -371,3 +622,35 @@ func buildGitSyncContainer(app *v1alpha1.TinyApp, env internal.EnvVars) corev1.C
 
 	return container
 }
+
+// buildGitRevisionContainer returns init container that records the commit checked out by git-sync as its
+// termination message, so that the deployed commit can be read from pod status. It runs in git-sync image, since
+// app image may have no shell (ex. distroless images of Custom apps).
+func buildGitRevisionContainer(env internal.EnvVars) corev1.Container {
+	// git-sync checks out a detached worktree, whose HEAD file holds the commit hash
+	script := fmt.Sprintf(`cd %s && gitdir=$(sed -n 's/^gitdir: //p' .git) && cat "$gitdir/HEAD" > /dev/termination-log`,
+		filepath.Join(util.GitRootDir, util.GitDestDir))
+
+	return corev1.Container{
+		Name:            util.GitRevisionContainerName,
+		Image:           env.GitSyncImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"sh", "-c", script},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(util.GitSyncContainerCPURequest),
+				corev1.ResourceMemory: resource.MustParse(util.GitSyncContainerMemoryRequest),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(util.GitSyncContainerCPULimit),
+				corev1.ResourceMemory: resource.MustParse(util.GitSyncContainerMemoryLimit),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      util.GitCloneVolumeName,
+				MountPath: util.GitRootDir,
+			},
+		},
+	}
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	testCommit       = "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c"
+	testOtherCommit  = "da1560886d4f094c3e6c9ef40349f7d38b5d27d7"
+	testGitSyncImage = "registry.k8s.io/git-sync/git-sync:v3.6.8"
+)
+
+func newTestEnv() internal.EnvVars {
+	return internal.EnvVars{
+		TinyAppNamespace:        "tinyapp",
+		AppServiceAccount:       "default",
+		GitSyncImage:            testGitSyncImage,
+		GatewayImage:            "tinyapp-gateway:latest",
+		GatewayMetricsPort:      "9090",
+		DefaultAppCPURequest:    "50m",
+		DefaultAppCPULimit:      "1",
+		DefaultAppMemoryRequest: "256Mi",
+		DefaultAppMemoryLimit:   "3Gi",
+	}
+}
+
+func newTestGitApp(gitConfig v1alpha1.GitConfig) *v1alpha1.TinyApp {
+	gitConfig.GitUrl = "https://github.com/org/repo.git"
+	gitConfig.TokenSecretName = "git-token"
+
+	return &v1alpha1.TinyApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: "tinyapp"},
+		Spec: v1alpha1.TinyAppSpec{
+			Image:        "python:3.11",
+			AppType:      v1alpha1.AppTypeStreamlit,
+			SourceType:   v1alpha1.SourceTypeGit,
+			GitConfig:    &gitConfig,
+			MainFilePath: "app.py",
+		},
+	}
+}
+
+// envValue returns value of env var with given name, and whether it is set.
+func envValue(envVars []corev1.EnvVar, name string) (string, bool) {
+	for _, envVar := range envVars {
+		if envVar.Name == name {
+			return envVar.Value, true
+		}
+	}
+	return "", false
+}
+
+func TestBuildGitSyncContainerRefs(t *testing.T) {
+	tests := []struct {
+		name       string
+		gitConfig  v1alpha1.GitConfig
+		status     v1alpha1.TinyAppStatus
+		wantBranch string
+		wantRev    string
+	}{
+		{
+			name:       "unresolved branch",
+			gitConfig:  v1alpha1.GitConfig{RefType: v1alpha1.GitRefTypeBranch, GitRef: "main"},
+			wantBranch: "main",
+		},
+		{
+			name:       "resolved branch",
+			gitConfig:  v1alpha1.GitConfig{RefType: v1alpha1.GitRefTypeBranch, GitRef: "main"},
+			status:     v1alpha1.TinyAppStatus{ResolvedGitCommit: testCommit},
+			wantBranch: "main",
+			wantRev:    testCommit,
+		},
+		{
+			name:       "unresolved tag",
+			gitConfig:  v1alpha1.GitConfig{RefType: v1alpha1.GitRefTypeTag, GitRef: "v1.0.0"},
+			wantBranch: "v1.0.0",
+			wantRev:    "v1.0.0",
+		},
+		{
+			name:       "resolved tag",
+			gitConfig:  v1alpha1.GitConfig{IsTag: true, GitRef: "v1.0.0"},
+			status:     v1alpha1.TinyAppStatus{ResolvedGitCommit: testCommit},
+			wantBranch: "v1.0.0",
+			wantRev:    testCommit,
+		},
+		{
+			name:       "commit on branch",
+			gitConfig:  v1alpha1.GitConfig{RefType: v1alpha1.GitRefTypeCommit, GitRef: testCommit, Branch: "release"},
+			status:     v1alpha1.TinyAppStatus{ResolvedGitCommit: testCommit},
+			wantBranch: "release",
+			wantRev:    testCommit,
+		},
+		{
+			name:       "commit on default branch",
+			gitConfig:  v1alpha1.GitConfig{RefType: v1alpha1.GitRefTypeCommit, GitRef: testCommit},
+			status:     v1alpha1.TinyAppStatus{ResolvedGitCommit: testCommit, ResolvedGitBranch: "main"},
+			wantBranch: "main",
+			wantRev:    testCommit,
+		},
+		{
+			name:      "commit before default branch is resolved",
+			gitConfig: v1alpha1.GitConfig{RefType: v1alpha1.GitRefTypeCommit, GitRef: testOtherCommit},
+			wantRev:   testOtherCommit,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := newTestGitApp(test.gitConfig)
+			app.Status = test.status
+
+			container := buildGitSyncContainer(app, newTestEnv())
+
+			branch, _ := envValue(container.Env, GitSyncBranchEnvVarName)
+			if branch != test.wantBranch {
+				t.Errorf("got branch %q, want %q", branch, test.wantBranch)
+			}
+			rev, _ := envValue(container.Env, GitSyncRevEnvVarName)
+			if rev != test.wantRev {
+				t.Errorf("got rev %q, want %q", rev, test.wantRev)
+			}
+		})
+	}
+}
+
+func TestBuildDeploymentGitRevisionContainer(t *testing.T) {
+	app := newTestGitApp(v1alpha1.GitConfig{RefType: v1alpha1.GitRefTypeBranch, GitRef: "main"})
+	// App images may have no shell
+	app.Spec.Image = "gcr.io/distroless/python3"
+
+	runtime, ok := builtInRuntimes()[app.Spec.AppType]
+	if !ok {
+		t.Fatalf("no built-in runtime for %s", app.Spec.AppType)
+	}
+
+	deployment, err := BuildDeployment(app, newTestEnv(), runtime)
+	if err != nil {
+		t.Fatalf("failed to build deployment: %v", err)
+	}
+
+	for _, container := range deployment.Spec.Template.Spec.InitContainers {
+		if container.Name == util.GitRevisionContainerName {
+			if container.Image != testGitSyncImage {
+				t.Errorf("got git revision image %q, want git-sync image %q", container.Image, testGitSyncImage)
+			}
+			return
+		}
+	}
+	t.Errorf("deployment has no %s init container", util.GitRevisionContainerName)
+}
+
+// Collaborators are kept in app spec, so changing them bumps app generation. It must not roll app pods.
+func TestBuildIgnoresAccess(t *testing.T) {
+	app := newTestGitApp(v1alpha1.GitConfig{RefType: v1alpha1.GitRefTypeBranch, GitRef: "main"})
+	app.Generation = 1
+	app.Spec.Access = &v1alpha1.AppAccess{Creator: "alice"}
+	app.Spec.Autoscaling = &v1alpha1.AutoscalingPolicy{MaxReplicas: 3}
+
+	sharedApp := app.DeepCopy()
+	sharedApp.Generation = 2
+	sharedApp.Spec.Access.Owners = []string{"bob"}
+	sharedApp.Spec.Access.Groups = []string{"data-science"}
+
+	runtime, ok := builtInRuntimes()[app.Spec.AppType]
+	if !ok {
+		t.Fatalf("no built-in runtime for %s", app.Spec.AppType)
+	}
+
+	build := func(app *v1alpha1.TinyApp) []metav1.Object {
+		env := newTestEnv()
+		// Ingress annotations are written to, as they are set up by controller main
+		env.IngressAnnotations = map[string]string{}
+
+		deployment, err := BuildDeployment(app, env, runtime)
+		if err != nil {
+			t.Fatalf("failed to build deployment: %v", err)
+		}
+		service, err := BuildService(app, env)
+		if err != nil {
+			t.Fatalf("failed to build service: %v", err)
+		}
+		ingress, err := BuildIngress(app, env)
+		if err != nil {
+			t.Fatalf("failed to build ingress: %v", err)
+		}
+		hpa, err := BuildHorizontalPodAutoscaler(app, env)
+		if err != nil {
+			t.Fatalf("failed to build autoscaler: %v", err)
+		}
+		return []metav1.Object{deployment, service, ingress, hpa}
+	}
+
+	objects, sharedObjects := build(app), build(sharedApp)
+	for i := range objects {
+		if !reflect.DeepEqual(objects[i], sharedObjects[i]) {
+			t.Errorf("%T changed with app access: got %+v, want %+v", objects[i], sharedObjects[i], objects[i])
+		}
+		hash := objects[i].GetAnnotations()[util.AnnotationResourceHash]
+		if hash == "" || sharedObjects[i].GetAnnotations()[util.AnnotationResourceHash] != hash {
+			t.Errorf("%T hash changed with app access", objects[i])
+		}
+	}
+}
+
+func TestBuildResourceQuantities(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     string
+		limit       string
+		max         string
+		wantRequest string
+		wantLimit   string
+		wantErr     bool
+	}{
+		{name: "defaults", wantRequest: "500m", wantLimit: "1"},
+		{name: "request & limit", request: "2", limit: "4", wantRequest: "2", wantLimit: "4"},
+		{name: "request below default limit", request: "250m", wantRequest: "250m", wantLimit: "1"},
+		{name: "request above default limit", request: "2", wantRequest: "2", wantLimit: "2"},
+		{name: "limit above default request", limit: "2", wantRequest: "500m", wantLimit: "2"},
+		{name: "limit below default request", limit: "100m", wantRequest: "100m", wantLimit: "100m"},
+		{name: "request above limit", request: "2", limit: "1", wantErr: true},
+		{name: "limit at maximum", limit: "2", max: "2", wantRequest: "500m", wantLimit: "2"},
+		{name: "limit above maximum", limit: "3", max: "2", wantErr: true},
+		{name: "raised limit above maximum", request: "3", max: "2", wantErr: true},
+		{name: "invalid request", request: "lots", wantErr: true},
+		{name: "invalid limit", limit: "lots", wantErr: true},
+		{name: "invalid maximum", max: "two", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, limit, err := buildResourceQuantities("cpu", test.request, test.limit, "500m", "1", test.max)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got request %s & limit %s, want error", request.String(), limit.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if request.String() != test.wantRequest || limit.String() != test.wantLimit {
+				t.Errorf("got request %s & limit %s, want %s & %s", request.String(), limit.String(), test.wantRequest, test.wantLimit)
+			}
+		})
+	}
+}
+
+func TestBuildReplicas(t *testing.T) {
+	tests := []struct {
+		name        string
+		replicas    *int32
+		autoscaling *v1alpha1.AutoscalingPolicy
+		want        int32
+	}{
+		{name: "default", want: util.DefaultAppReplicas},
+		{name: "replicas", replicas: pointer.Int32(3), want: 3},
+		{name: "zero replicas", replicas: pointer.Int32(0), want: 0},
+		{
+			name:        "autoscaling min replicas",
+			replicas:    pointer.Int32(3),
+			autoscaling: &v1alpha1.AutoscalingPolicy{MinReplicas: pointer.Int32(2), MaxReplicas: 5},
+			want:        2,
+		},
+		{
+			name:        "autoscaling default min replicas",
+			autoscaling: &v1alpha1.AutoscalingPolicy{MaxReplicas: 5},
+			want:        util.DefaultAppReplicas,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := newTestGitApp(v1alpha1.GitConfig{GitRef: "main"})
+			app.Spec.Replicas, app.Spec.Autoscaling = test.replicas, test.autoscaling
+
+			if got := buildReplicas(app); got == nil || *got != test.want {
+				t.Errorf("got replicas %v, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+// Apps baked into their image run it as is: no git clone, no requirements install, and the resolved digest.
+func TestBuildDeploymentImageSource(t *testing.T) {
+	const digest = "sha256:6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b"
+
+	tests := []struct {
+		name           string
+		image          string
+		digest         string
+		wantImage      string
+		wantPullPolicy corev1.PullPolicy
+	}{
+		{
+			name:           "resolved tag",
+			image:          "registry.example.com:5000/team/app:1.0",
+			digest:         digest,
+			wantImage:      "registry.example.com:5000/team/app@" + digest,
+			wantPullPolicy: corev1.PullIfNotPresent,
+		},
+		{
+			// Before controller resolves the tag
+			name:           "unresolved tag",
+			image:          "registry.example.com:5000/team/app:1.0",
+			wantImage:      "registry.example.com:5000/team/app:1.0",
+			wantPullPolicy: corev1.PullAlways,
+		},
+		{
+			name:           "digest",
+			image:          "team/app@" + digest,
+			digest:         digest,
+			wantImage:      "team/app@" + digest,
+			wantPullPolicy: corev1.PullIfNotPresent,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := newTestGitApp(v1alpha1.GitConfig{})
+			app.Spec.SourceType, app.Spec.GitConfig = v1alpha1.SourceTypeImage, nil
+			app.Spec.Image, app.Spec.MainFilePath = test.image, "src/app.py"
+			app.Status.ImageDigest = test.digest
+
+			deployment, err := BuildDeployment(app, newTestEnv(), builtInRuntimes()[app.Spec.AppType])
+			if err != nil {
+				t.Fatalf("failed to build deployment: %v", err)
+			}
+
+			podSpec := deployment.Spec.Template.Spec
+			if len(podSpec.InitContainers) > 0 {
+				t.Errorf("got init containers %v, want none", podSpec.InitContainers)
+			}
+			for _, volume := range podSpec.Volumes {
+				if volume.Name == util.GitCloneVolumeName || volume.Name == util.GitTokenVolumeName {
+					t.Errorf("got git volume %s", volume.Name)
+				}
+			}
+
+			appContainer := podSpec.Containers[0]
+			if appContainer.Image != test.wantImage || appContainer.ImagePullPolicy != test.wantPullPolicy {
+				t.Errorf("got image %s pulled %s, want %s pulled %s", appContainer.Image, appContainer.ImagePullPolicy,
+					test.wantImage, test.wantPullPolicy)
+			}
+			if value, ok := envValue(appContainer.Env, RequirementsFileEnvVarName); ok {
+				t.Errorf("got %s=%s, want it unset", RequirementsFileEnvVarName, value)
+			}
+			if value, _ := envValue(appContainer.Env, MainDirectoryEnvVarName); value != "src" {
+				t.Errorf("got %s=%s, want src", MainDirectoryEnvVarName, value)
+			}
+			for _, mount := range appContainer.VolumeMounts {
+				if mount.Name == util.GitCloneVolumeName {
+					t.Errorf("app container mounts git clone volume")
+				}
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/tinymultiverse/tinyapp/controller/internal"
 	"github.com/tinymultiverse/tinyapp/controller/util"
 	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
 
 	"github.com/pkg/errors"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -38,6 +39,21 @@ func BuildIngress(app *v1alpha1.TinyApp, env internal.EnvVars) (*networkingv1.In
 	}
 	ingressPath = ingressPath + "(/|$)(.*)"
 
+	backend := networkingv1.IngressServiceBackend{
+		Name: app.Name,
+		Port: networkingv1.ServiceBackendPort{
+			Number: globalutil.DefaultGatewayPort,
+		},
+	}
+	if RoutesToActivator(app, env) {
+		backend = networkingv1.IngressServiceBackend{
+			Name: env.ActivatorServiceName,
+			Port: networkingv1.ServiceBackendPort{
+				Number: env.ActivatorServicePort,
+			},
+		}
+	}
+
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Annotations:     env.IngressAnnotations,
@@ -56,12 +72,7 @@ func BuildIngress(app *v1alpha1.TinyApp, env internal.EnvVars) (*networkingv1.In
 								{
 									Path: ingressPath,
 									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: app.Name,
-											Port: networkingv1.ServiceBackendPort{
-												Number: util.DefaultGatewayPort,
-											},
-										},
+										Service: &backend,
 									},
 									PathType: &pathType,
 								},
@@ -91,6 +102,40 @@ func BuildIngress(app *v1alpha1.TinyApp, env internal.EnvVars) (*networkingv1.In
 	return ingress, nil
 }
 
+// RoutesToActivator returns true if app ingress should send requests to the activator instead of app service,
+// which is when app is asleep or has no ready pod while waking up.
+func RoutesToActivator(app *v1alpha1.TinyApp, env internal.EnvVars) bool {
+	if !ScaleToZeroEnabled(app, env) {
+		return false
+	}
+
+	return app.Status.IsSleeping() || app.Status.ReadyReplicas == 0
+}
+
+// ScaleToZeroEnabled returns true if app can be scaled to zero when idle.
+func ScaleToZeroEnabled(app *v1alpha1.TinyApp, env internal.EnvVars) bool {
+	return app.Spec.IdleTimeout != nil && env.ActivatorServiceName != ""
+}
+
+// BuildAppURL returns url that app is served at.
+func BuildAppURL(domain, subPath, appId string, tlsEnabled bool) (string, error) {
+	ingressPath, err := BuildIngressPath(subPath, appId)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to build ingress path")
+	}
+
+	appUrl, err := url.JoinPath(domain, ingressPath)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to join domain and ingress path")
+	}
+
+	if tlsEnabled {
+		return globalutil.Https + appUrl, nil
+	}
+
+	return globalutil.Http + appUrl, nil
+}
+
 func BuildIngressPath(subPath, appId string) (string, error) {
 	if strings.TrimSpace(subPath) == "" {
 		return url.JoinPath("/", appId)
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"path"
+	"strings"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Default probe settings. Startup probe gives apps 10 minutes to start, since requirements are installed at pod start.
+const (
+	defaultProbePeriodSeconds           int32 = 10
+	defaultLivenessProbePeriodSeconds   int32 = 20
+	defaultProbeTimeoutSeconds          int32 = 5
+	defaultProbeFailureThreshold        int32 = 3
+	defaultStartupProbeFailureThreshold int32 = 60
+)
+
+// buildAppHealthPath returns url path that app responds at while healthy, as requested by app container probes.
+// Returns empty string if app probes are disabled.
+func buildAppHealthPath(app *v1alpha1.TinyApp, runtime AppRuntime, params RuntimeParams) string {
+	probes := app.Spec.Probes
+	if probes != nil && probes.Disabled {
+		return ""
+	}
+
+	healthPath := runtime.HealthPath()
+	if probes != nil && probes.Path != "" {
+		healthPath = probes.Path
+	}
+
+	// Apps whose base path is stripped by gateway are served at root path
+	if !runtime.StripBasePath() {
+		joinedPath := path.Join(params.BasePath, healthPath)
+		if strings.HasSuffix(healthPath, "/") {
+			joinedPath += "/"
+		}
+		healthPath = joinedPath
+	}
+
+	return "/" + strings.TrimLeft(healthPath, "/")
+}
+
+// buildAppProbes returns startup, readiness & liveness probes of app container, which request health path of app on
+// given port. Returns nil probes if health path is empty.
+func buildAppProbes(app *v1alpha1.TinyApp, port int32, healthPath string) (*corev1.Probe, *corev1.Probe, *corev1.Probe) {
+	if healthPath == "" {
+		return nil, nil, nil
+	}
+
+	probes := v1alpha1.AppProbes{}
+	if app.Spec.Probes != nil {
+		probes = *app.Spec.Probes
+	}
+
+	handler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path: healthPath,
+			Port: intstr.FromInt(int(port)),
+		},
+	}
+
+	startupProbe := buildProbe(handler, probes.Startup, defaultProbePeriodSeconds, defaultStartupProbeFailureThreshold)
+	readinessProbe := buildProbe(handler, probes.Readiness, defaultProbePeriodSeconds, defaultProbeFailureThreshold)
+	livenessProbe := buildProbe(handler, probes.Liveness, defaultLivenessProbePeriodSeconds, defaultProbeFailureThreshold)
+
+	return startupProbe, readinessProbe, livenessProbe
+}
+
+// buildProbe returns probe with given handler. Settings not set in app spec are filled with given defaults.
+func buildProbe(handler corev1.ProbeHandler, settings *v1alpha1.ProbeSettings, periodSeconds, failureThreshold int32) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler:     handler,
+		PeriodSeconds:    periodSeconds,
+		TimeoutSeconds:   defaultProbeTimeoutSeconds,
+		FailureThreshold: failureThreshold,
+	}
+
+	if settings == nil {
+		return probe
+	}
+	if settings.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *settings.InitialDelaySeconds
+	}
+	if settings.PeriodSeconds != nil {
+		probe.PeriodSeconds = *settings.PeriodSeconds
+	}
+	if settings.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *settings.TimeoutSeconds
+	}
+	if settings.FailureThreshold != nil {
+		probe.FailureThreshold = *settings.FailureThreshold
+	}
+
+	return probe
+}
+
+// buildGatewayProbe returns probe of gateway container, which requests given path of gateway admin port.
+func buildGatewayProbe(healthPath string) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: healthPath,
+				Port: intstr.FromString(gatewayAdminPortName),
+			},
+		},
+		PeriodSeconds:    defaultProbePeriodSeconds,
+		TimeoutSeconds:   defaultProbeTimeoutSeconds,
+		FailureThreshold: defaultProbeFailureThreshold,
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+func TestBuildAppHealthPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		healthPath    string
+		stripBasePath bool
+		basePath      string
+		probes        *v1alpha1.AppProbes
+		want          string
+	}{
+		{name: "runtime path under base path", healthPath: "/_stcore/health", basePath: "/apps/abc", want: "/apps/abc/_stcore/health"},
+		{name: "runtime path under base path with trailing slash", healthPath: "/_stcore/health", basePath: "/apps/abc/", want: "/apps/abc/_stcore/health"},
+		{name: "app root", basePath: "/apps/abc", want: "/apps/abc/"},
+		{name: "app root without slash", healthPath: "/api", basePath: "/abc", want: "/abc/api"},
+		{name: "trailing slash of health path is kept", healthPath: "/health/", basePath: "/abc", want: "/abc/health/"},
+		{name: "stripped base path", healthPath: "/health", stripBasePath: true, basePath: "/apps/abc", want: "/health"},
+		{name: "stripped base path app root", stripBasePath: true, basePath: "/apps/abc", want: "/"},
+		{
+			name:       "spec path",
+			healthPath: "/_stcore/health",
+			basePath:   "/apps/abc",
+			probes:     &v1alpha1.AppProbes{Path: "healthz"},
+			want:       "/apps/abc/healthz",
+		},
+		{
+			name:          "spec path of stripped base path",
+			stripBasePath: true,
+			basePath:      "/apps/abc",
+			probes:        &v1alpha1.AppProbes{Path: "/healthz"},
+			want:          "/healthz",
+		},
+		{name: "spec without path", healthPath: "/_stcore/health", basePath: "/abc", probes: &v1alpha1.AppProbes{}, want: "/abc/_stcore/health"},
+		{name: "disabled", healthPath: "/_stcore/health", basePath: "/abc", probes: &v1alpha1.AppProbes{Path: "/healthz", Disabled: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runtime, err := NewConfigRuntime(RuntimeConfig{Port: 5000, HealthPath: test.healthPath, StripBasePath: test.stripBasePath})
+			if err != nil {
+				t.Fatal(err)
+			}
+			app := newTestGitApp(v1alpha1.GitConfig{GitRef: "main"})
+			app.Spec.Probes = test.probes
+
+			if got := buildAppHealthPath(app, runtime, RuntimeParams{BasePath: test.basePath}); got != test.want {
+				t.Errorf("got health path %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildAppProbes(t *testing.T) {
+	handler := corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/abc/health", Port: intstr.FromInt(8000)}}
+
+	tests := []struct {
+		name          string
+		probes        *v1alpha1.AppProbes
+		healthPath    string
+		wantStartup   *corev1.Probe
+		wantReadiness *corev1.Probe
+		wantLiveness  *corev1.Probe
+	}{
+		{
+			name:          "defaults",
+			healthPath:    "/abc/health",
+			wantStartup:   &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, TimeoutSeconds: 5, FailureThreshold: 60},
+			wantReadiness: &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, TimeoutSeconds: 5, FailureThreshold: 3},
+			wantLiveness:  &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 20, TimeoutSeconds: 5, FailureThreshold: 3},
+		},
+		{
+			name: "overrides",
+			probes: &v1alpha1.AppProbes{
+				Startup:   &v1alpha1.ProbeSettings{InitialDelaySeconds: pointer.Int32(5), FailureThreshold: pointer.Int32(120)},
+				Readiness: &v1alpha1.ProbeSettings{PeriodSeconds: pointer.Int32(3)},
+				Liveness:  &v1alpha1.ProbeSettings{TimeoutSeconds: pointer.Int32(30), FailureThreshold: pointer.Int32(1)},
+			},
+			healthPath:    "/abc/health",
+			wantStartup:   &corev1.Probe{ProbeHandler: handler, InitialDelaySeconds: 5, PeriodSeconds: 10, TimeoutSeconds: 5, FailureThreshold: 120},
+			wantReadiness: &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 3, TimeoutSeconds: 5, FailureThreshold: 3},
+			wantLiveness:  &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 20, TimeoutSeconds: 30, FailureThreshold: 1},
+		},
+		{
+			// Health path is empty for disabled probes
+			name:   "disabled",
+			probes: &v1alpha1.AppProbes{Disabled: true, Readiness: &v1alpha1.ProbeSettings{PeriodSeconds: pointer.Int32(3)}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := newTestGitApp(v1alpha1.GitConfig{GitRef: "main"})
+			app.Spec.Probes = test.probes
+
+			startup, readiness, liveness := buildAppProbes(app, 8000, test.healthPath)
+			if !reflect.DeepEqual(startup, test.wantStartup) {
+				t.Errorf("got startup probe %+v, want %+v", startup, test.wantStartup)
+			}
+			if !reflect.DeepEqual(readiness, test.wantReadiness) {
+				t.Errorf("got readiness probe %+v, want %+v", readiness, test.wantReadiness)
+			}
+			if !reflect.DeepEqual(liveness, test.wantLiveness) {
+				t.Errorf("got liveness probe %+v, want %+v", liveness, test.wantLiveness)
+			}
+		})
+	}
+}
+
+func TestBuildGatewayProbe(t *testing.T) {
+	probe := buildGatewayProbe("/readyz")
+
+	want := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/readyz", Port: intstr.FromString(gatewayAdminPortName)},
+		},
+		PeriodSeconds:    10,
+		TimeoutSeconds:   5,
+		FailureThreshold: 3,
+	}
+	if !reflect.DeepEqual(probe, want) {
+		t.Errorf("got probe %+v, want %+v", probe, want)
+	}
+}
+
+// Probes of app container request app on its runtime port, and gateway container is probed on its admin port.
+func TestBuildDeploymentProbes(t *testing.T) {
+	app := newTestGitApp(v1alpha1.GitConfig{GitRef: "main"})
+	app.Spec.AppType = v1alpha1.AppTypeCustom
+	app.Spec.Custom = &v1alpha1.CustomApp{Command: []string{"node", "server.js"}, Port: 3000, BasePathEnvVarName: "BASE_PATH"}
+
+	deployment, err := BuildDeployment(app, newTestEnv(), &customRuntime{custom: *app.Spec.Custom})
+	if err != nil {
+		t.Fatalf("failed to build deployment: %v", err)
+	}
+
+	appContainer, gatewayContainer := deployment.Spec.Template.Spec.Containers[0], deployment.Spec.Template.Spec.Containers[1]
+	for _, probe := range []*corev1.Probe{appContainer.StartupProbe, appContainer.ReadinessProbe, appContainer.LivenessProbe} {
+		if probe == nil || probe.HTTPGet.Port != intstr.FromInt(3000) || probe.HTTPGet.Path != "/abc/" {
+			t.Errorf("got app probe %+v, want request to /abc/ on port 3000", probe)
+		}
+	}
+	if value, _ := envValue(gatewayContainer.Env, "APP_HEALTH_PATH"); value != "/abc/" {
+		t.Errorf("got gateway APP_HEALTH_PATH=%s, want /abc/", value)
+	}
+	if value, _ := envValue(gatewayContainer.Env, "APP_PORT"); value != "3000" {
+		t.Errorf("got gateway APP_PORT=%s, want 3000", value)
+	}
+
+	if gatewayContainer.ReadinessProbe == nil || gatewayContainer.LivenessProbe == nil {
+		t.Fatalf("got gateway probes %+v & %+v, want both", gatewayContainer.ReadinessProbe, gatewayContainer.LivenessProbe)
+	}
+	var adminPortDeclared bool
+	for _, port := range gatewayContainer.Ports {
+		adminPortDeclared = adminPortDeclared || port.Name == gatewayContainer.ReadinessProbe.HTTPGet.Port.StrVal
+	}
+	if !adminPortDeclared {
+		t.Errorf("gateway probe port %s is not declared by gateway container", gatewayContainer.ReadinessProbe.HTTPGet.Port.StrVal)
+	}
+}
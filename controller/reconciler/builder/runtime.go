@@ -0,0 +1,332 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/validation"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AppRuntime knows how to run apps of one app type, such as a framework.
+type AppRuntime interface {
+	// Command returns command & args of app container. Image entrypoint & cmd are used for any that is empty.
+	Command(params RuntimeParams) (command []string, args []string, err error)
+	// EnvVars returns env vars that make app listen on runtime port and serve under base path of params.
+	EnvVars(params RuntimeParams) ([]corev1.EnvVar, error)
+	// Port returns the port that app listens on.
+	Port() int32
+	// HealthPath returns url path, relative to app base path, that responds with 200 once app is up.
+	HealthPath() string
+	// StripBasePath returns true if gateway should strip base path from requests, for apps that can only be
+	// served at root path.
+	StripBasePath() bool
+}
+
+// RuntimeParams are the values of an app that app runtimes are configured with.
+type RuntimeParams struct {
+	AppName string
+	Port    int32
+	// Url path app is served under, with trailing slash if runtime asks for it
+	BasePath string
+	// Empty if app has no ingress domain
+	IngressDomain string
+}
+
+// RuntimeConfig declares an app runtime. Command, args & env var values are Go templates executed with
+// RuntimeParams (ex. {{.BasePath}}). Kubernetes expands $(VAR) references to app env vars, such as MAIN_FILE &
+// BASE_DIR, after that.
+type RuntimeConfig struct {
+	Command []string        `json:"command,omitempty"`
+	Args    []string        `json:"args,omitempty"`
+	Env     []RuntimeEnvVar `json:"env,omitempty"`
+	Port    int32           `json:"port"`
+	// Defaults to app base path
+	HealthPath string `json:"healthPath,omitempty"`
+	// Some frameworks expect base path to end with forward slash
+	BasePathTrailingSlash bool `json:"basePathTrailingSlash,omitempty"`
+	StripBasePath         bool `json:"stripBasePath,omitempty"`
+}
+
+type RuntimeEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// configRuntime is the app runtime declared by a RuntimeConfig.
+type configRuntime struct {
+	config  RuntimeConfig
+	command []*template.Template
+	args    []*template.Template
+	env     []*template.Template
+}
+
+// NewConfigRuntime returns app runtime declared by config. Returns error if any of its templates can't be parsed.
+func NewConfigRuntime(config RuntimeConfig) (AppRuntime, error) {
+	if config.Port <= 0 || config.Port > 65535 {
+		return nil, errors.Errorf("invalid port %d", config.Port)
+	}
+
+	command, err := parseTemplates("command", config.Command)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := parseTemplates("args", config.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	envValues := make([]string, 0, len(config.Env))
+	for _, envVar := range config.Env {
+		if envVar.Name == "" {
+			return nil, errors.New("env var name is empty")
+		}
+		envValues = append(envValues, envVar.Value)
+	}
+	env, err := parseTemplates("env", envValues)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime := &configRuntime{
+		config:  config,
+		command: command,
+		args:    args,
+		env:     env,
+	}
+
+	// Templates that refer to unknown params only fail when executed
+	if _, _, err := runtime.Command(RuntimeParams{}); err != nil {
+		return nil, err
+	}
+	if _, err := runtime.EnvVars(RuntimeParams{}); err != nil {
+		return nil, err
+	}
+
+	return runtime, nil
+}
+
+func (r *configRuntime) Command(params RuntimeParams) ([]string, []string, error) {
+	params = r.withBasePath(params)
+
+	command, err := executeTemplates(r.command, params)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to build command")
+	}
+
+	args, err := executeTemplates(r.args, params)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to build args")
+	}
+
+	return command, args, nil
+}
+
+func (r *configRuntime) EnvVars(params RuntimeParams) ([]corev1.EnvVar, error) {
+	values, err := executeTemplates(r.env, r.withBasePath(params))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build env vars")
+	}
+
+	envVars := make([]corev1.EnvVar, 0, len(values))
+	for i, value := range values {
+		envVars = append(envVars, corev1.EnvVar{Name: r.config.Env[i].Name, Value: value})
+	}
+	return envVars, nil
+}
+
+func (r *configRuntime) Port() int32 {
+	return r.config.Port
+}
+
+func (r *configRuntime) HealthPath() string {
+	if r.config.HealthPath == "" {
+		return "/"
+	}
+	return r.config.HealthPath
+}
+
+func (r *configRuntime) StripBasePath() bool {
+	return r.config.StripBasePath
+}
+
+// withBasePath returns params with base path the way runtime expects it.
+func (r *configRuntime) withBasePath(params RuntimeParams) RuntimeParams {
+	if r.config.BasePathTrailingSlash && !strings.HasSuffix(params.BasePath, "/") {
+		params.BasePath += "/"
+	}
+	return params
+}
+
+func parseTemplates(name string, texts []string) ([]*template.Template, error) {
+	templates := make([]*template.Template, 0, len(texts))
+	for _, text := range texts {
+		tmpl, err := template.New(name).Parse(text)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid %s template %q", name, text)
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+func executeTemplates(templates []*template.Template, params RuntimeParams) ([]string, error) {
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	values := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		var value bytes.Buffer
+		if err := tmpl.Execute(&value, params); err != nil {
+			return nil, err
+		}
+		values = append(values, value.String())
+	}
+	return values, nil
+}
+
+// RuntimeRegistry holds the app runtimes that apps can be deployed with: built-in ones, and the ones operators
+// declare in app runtimes ConfigMap. ConfigMap runtimes override built-in runtimes of the same app type.
+type RuntimeRegistry struct {
+	reader    client.Reader
+	configMap types.NamespacedName
+
+	mu       sync.RWMutex
+	runtimes map[v1alpha1.AppType]AppRuntime
+	// Resource version of the ConfigMap that runtimes were last loaded from
+	version string
+}
+
+// NewRuntimeRegistry returns registry of built-in app runtimes, and the ones in given ConfigMap once refreshed.
+// ConfigMap maps app type to YAML RuntimeConfig. Only built-in runtimes are used if ConfigMap name is empty.
+func NewRuntimeRegistry(reader client.Reader, namespace, configMapName string) *RuntimeRegistry {
+	return &RuntimeRegistry{
+		reader:    reader,
+		configMap: types.NamespacedName{Namespace: namespace, Name: configMapName},
+		runtimes:  builtInRuntimes(),
+	}
+}
+
+// RuntimeFor returns the app runtime that runs app. Custom apps are run the way their spec says.
+func (r *RuntimeRegistry) RuntimeFor(app *v1alpha1.TinyApp) (AppRuntime, error) {
+	if app.Spec.AppType == v1alpha1.AppTypeCustom {
+		if app.Spec.Custom == nil {
+			return nil, errors.New("custom app spec is empty")
+		}
+		return &customRuntime{custom: *app.Spec.Custom}, nil
+	}
+
+	runtime, ok := r.Get(app.Spec.AppType)
+	if !ok {
+		return nil, errors.Errorf("no runtime found for app type %s", app.Spec.AppType)
+	}
+	return runtime, nil
+}
+
+// Get returns the app runtime of app type.
+func (r *RuntimeRegistry) Get(appType v1alpha1.AppType) (AppRuntime, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	runtime, ok := r.runtimes[appType]
+	return runtime, ok
+}
+
+// AppTypes returns the app types that have a runtime, built-in ones first.
+func (r *RuntimeRegistry) AppTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	appTypes := append([]string{}, validation.BuiltInAppTypes...)
+	var extraAppTypes []string
+	for appType := range r.runtimes {
+		if !slices.Contains(appTypes, string(appType)) {
+			extraAppTypes = append(extraAppTypes, string(appType))
+		}
+	}
+	sort.Strings(extraAppTypes)
+
+	return append(appTypes, extraAppTypes...)
+}
+
+// Refresh loads runtimes of app runtimes ConfigMap again if it has changed. Runtimes are left as they are if any
+// of them is invalid. Only built-in runtimes are left if ConfigMap doesn't exist.
+func (r *RuntimeRegistry) Refresh(ctx context.Context) error {
+	if r.configMap.Name == "" {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.reader.Get(ctx, r.configMap, configMap); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return r.load("", nil)
+		}
+		return errors.WithMessage(err, "failed to get app runtimes ConfigMap")
+	}
+
+	return r.load(configMap.ResourceVersion, configMap.Data)
+}
+
+// load replaces ConfigMap runtimes with the ones in ConfigMap data, unless ConfigMap version was loaded already.
+func (r *RuntimeRegistry) load(version string, data map[string]string) error {
+	r.mu.RLock()
+	loaded := r.version == version
+	r.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	runtimes := builtInRuntimes()
+	for appType, value := range data {
+		if v1alpha1.AppType(appType) == v1alpha1.AppTypeCustom {
+			return errors.Errorf("runtime of app type %s is set in app spec", appType)
+		}
+
+		config := RuntimeConfig{}
+		if err := yaml.UnmarshalStrict([]byte(value), &config, yaml.DisallowUnknownFields); err != nil {
+			return errors.WithMessagef(err, "failed to parse runtime of app type %s", appType)
+		}
+
+		runtime, err := NewConfigRuntime(config)
+		if err != nil {
+			return errors.WithMessagef(err, "invalid runtime of app type %s", appType)
+		}
+		runtimes[v1alpha1.AppType(appType)] = runtime
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runtimes = runtimes
+	r.version = version
+
+	return nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// Deployments of built-in app types must stay the same as before runtimes were introduced, including their resource
+// hash, so that upgrading controller doesn't roll pods of existing apps.
+func TestBuildDeploymentGolden(t *testing.T) {
+	for _, appType := range []v1alpha1.AppType{v1alpha1.AppTypeStreamlit, v1alpha1.AppTypeDash} {
+		t.Run(string(appType), func(t *testing.T) {
+			app := newTestGitApp(v1alpha1.GitConfig{GitRef: "main"})
+			app.Labels = map[string]string{"app.kubernetes.io/name": app.Name}
+			app.Spec.AppType = appType
+			app.Spec.MainFilePath = "src/app.py"
+			app.Spec.IngressDomain, app.Spec.IngressSubPath = "apps.example.com", "/apps"
+
+			deployment, err := BuildDeployment(app, newTestEnv(), builtInRuntimes()[appType])
+			if err != nil {
+				t.Fatalf("failed to build deployment: %v", err)
+			}
+			got, err := json.MarshalIndent(deployment, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", strings.ToLower(string(appType))+"_deployment.json")
+			if *updateGolden {
+				if err := os.WriteFile(golden, append(got, '\n'), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got)+"\n" != string(want) {
+				t.Errorf("deployment differs from %s, got:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestNewConfigRuntime(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RuntimeConfig
+		wantErr bool
+	}{
+		{name: "port only", config: RuntimeConfig{Port: 3838}},
+		{name: "missing port", config: RuntimeConfig{Args: []string{"app.R"}}, wantErr: true},
+		{name: "port out of range", config: RuntimeConfig{Port: 70000}, wantErr: true},
+		{name: "unparsable command", config: RuntimeConfig{Command: []string{"{{.Port"}, Port: 3838}, wantErr: true},
+		{name: "unparsable args", config: RuntimeConfig{Args: []string{"{{end}}"}, Port: 3838}, wantErr: true},
+		{name: "unknown param", config: RuntimeConfig{Args: []string{"{{.AppPort}}"}, Port: 3838}, wantErr: true},
+		{name: "unknown env param", config: RuntimeConfig{Env: []RuntimeEnvVar{{Name: "PORT", Value: "{{.AppPort}}"}}, Port: 3838}, wantErr: true},
+		{name: "empty env name", config: RuntimeConfig{Env: []RuntimeEnvVar{{Value: "{{.Port}}"}}, Port: 3838}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewConfigRuntime(test.config)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("got error %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigRuntime(t *testing.T) {
+	runtime, err := NewConfigRuntime(RuntimeConfig{
+		Command: []string{"R", "-e"},
+		Args:    []string{"shiny::runApp('$(BASE_DIR)', port={{.Port}})"},
+		Env: []RuntimeEnvVar{
+			{Name: "SHINY_BASE_PATH", Value: "{{.BasePath}}"},
+			{Name: "SHINY_ORIGIN", Value: `{{or .IngressDomain "*"}}`},
+		},
+		Port:                  3838,
+		BasePathTrailingSlash: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	params := RuntimeParams{AppName: "abc", Port: 3838, BasePath: "/apps/abc"}
+	command, args, err := runtime.Command(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"R", "-e"}; !reflect.DeepEqual(command, want) {
+		t.Errorf("got command %v, want %v", command, want)
+	}
+	if want := []string{"shiny::runApp('$(BASE_DIR)', port=3838)"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	envVars, err := runtime.EnvVars(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantEnvVars := []corev1.EnvVar{{Name: "SHINY_BASE_PATH", Value: "/apps/abc/"}, {Name: "SHINY_ORIGIN", Value: "*"}}
+	if !reflect.DeepEqual(envVars, wantEnvVars) {
+		t.Errorf("got env vars %v, want %v", envVars, wantEnvVars)
+	}
+
+	if runtime.Port() != 3838 || runtime.HealthPath() != "/" || runtime.StripBasePath() {
+		t.Errorf("got port %d, health path %s & strip base path %v, want 3838, / & false",
+			runtime.Port(), runtime.HealthPath(), runtime.StripBasePath())
+	}
+}
+
+const testRShinyRuntime = `
+command: ["R", "-e"]
+args: ["shiny::runApp('$(BASE_DIR)', port={{.Port}})"]
+port: 3838
+stripBasePath: true
+`
+
+func newTestRuntimesConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-runtimes", Namespace: "tinyapp"},
+		Data:       data,
+	}
+}
+
+func TestRuntimeRegistryRefresh(t *testing.T) {
+	ctx := context.Background()
+	configMap := newTestRuntimesConfigMap(map[string]string{
+		"RShiny":    testRShinyRuntime,
+		"Streamlit": "args: [\"start-tinyapp.py\"]\nport: 8501\n",
+	})
+	reader := fake.NewClientBuilder().WithObjects(configMap).Build()
+	registry := NewRuntimeRegistry(reader, "tinyapp", "app-runtimes")
+
+	if _, ok := registry.Get("RShiny"); ok {
+		t.Fatal("got ConfigMap runtime before refresh")
+	}
+	if err := registry.Refresh(ctx); err != nil {
+		t.Fatalf("failed to refresh: %v", err)
+	}
+
+	runtime, ok := registry.Get("RShiny")
+	if !ok || runtime.Port() != 3838 || !runtime.StripBasePath() {
+		t.Errorf("got RShiny runtime %v, want ConfigMap runtime", runtime)
+	}
+	if runtime, _ := registry.Get(v1alpha1.AppTypeStreamlit); runtime.Port() != 8501 {
+		t.Errorf("got Streamlit port %d, want ConfigMap port 8501", runtime.Port())
+	}
+	if appTypes := registry.AppTypes(); appTypes[len(appTypes)-1] != "RShiny" {
+		t.Errorf("got app types %v, want RShiny after built-in app types", appTypes)
+	}
+
+	// Invalid runtimes leave runtimes as they are
+	for _, data := range []map[string]string{
+		{"RShiny": "port: [3838"},
+		{"RShiny": "port: 3838\nhost: 0.0.0.0\n"},
+		{"RShiny": "args: [\"{{.Port\"]\nport: 3838\n"},
+		{string(v1alpha1.AppTypeCustom): testRShinyRuntime},
+	} {
+		configMap.Data = data
+		if err := reader.Update(ctx, configMap); err != nil {
+			t.Fatal(err)
+		}
+		if err := registry.Refresh(ctx); err == nil {
+			t.Errorf("got no error refreshing runtimes %v, want error", data)
+		}
+		if _, ok := registry.Get("RShiny"); !ok {
+			t.Errorf("lost RShiny runtime after refreshing runtimes %v", data)
+		}
+	}
+
+	// Only built-in runtimes are left once ConfigMap is deleted
+	if err := reader.Delete(ctx, configMap); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Refresh(ctx); err != nil {
+		t.Fatalf("failed to refresh: %v", err)
+	}
+	if _, ok := registry.Get("RShiny"); ok {
+		t.Error("got RShiny runtime after ConfigMap was deleted")
+	}
+	if runtime, _ := registry.Get(v1alpha1.AppTypeStreamlit); runtime.Port() != 5000 {
+		t.Errorf("got Streamlit port %d, want built-in port 5000", runtime.Port())
+	}
+}
+
+func TestRuntimeRegistryRuntimeFor(t *testing.T) {
+	registry := NewRuntimeRegistry(fake.NewClientBuilder().Build(), "tinyapp", "app-runtimes")
+	if err := registry.Refresh(context.Background()); err != nil {
+		t.Fatalf("failed to refresh without ConfigMap: %v", err)
+	}
+
+	app := newTestGitApp(v1alpha1.GitConfig{GitRef: "main"})
+	if _, err := registry.RuntimeFor(app); err != nil {
+		t.Errorf("unexpected error for built-in app type: %s", err)
+	}
+
+	app.Spec.AppType = "RShiny"
+	if _, err := registry.RuntimeFor(app); err == nil {
+		t.Error("got no error for unknown app type, want error")
+	}
+
+	app.Spec.AppType = v1alpha1.AppTypeCustom
+	if _, err := registry.RuntimeFor(app); err == nil {
+		t.Error("got no error for custom app without custom spec, want error")
+	}
+	app.Spec.Custom = &v1alpha1.CustomApp{Command: []string{"node", "server.js"}, Port: 3000}
+	if runtime, err := registry.RuntimeFor(app); err != nil || runtime.Port() != 3000 {
+		t.Errorf("got runtime %v & error %v, want custom runtime on port 3000", runtime, err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/tinymultiverse/tinyapp/controller/internal"
 	"github.com/tinymultiverse/tinyapp/controller/util"
 	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,8 +42,8 @@ func BuildService(app *v1alpha1.TinyApp, env internal.EnvVars) (*corev1.Service,
 			Selector: app.Labels,
 			Ports: []corev1.ServicePort{
 				{
-					Port:       util.DefaultGatewayPort,
-					TargetPort: intstr.Parse(strconv.FormatInt(int64(util.DefaultGatewayPort), 10)),
+					Port:       globalutil.DefaultGatewayPort,
+					TargetPort: intstr.Parse(strconv.FormatInt(int64(globalutil.DefaultGatewayPort), 10)),
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
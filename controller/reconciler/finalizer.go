@@ -0,0 +1,102 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Reasons of events recorded on TinyApp deletion
+const (
+	eventReasonDeleting      = "Deleting"
+	eventReasonCleanedUp     = "CleanedUp"
+	eventReasonCleanupFailed = "CleanupFailed"
+)
+
+// ensureFinalizer adds cleanup finalizer to app, so that controller gets to clean up before app is gone.
+func (r *reconciler) ensureFinalizer(ctx context.Context, app *v1alpha1.TinyApp) error {
+	if !controllerutil.AddFinalizer(app, util.CleanupFinalizer) {
+		return nil
+	}
+
+	if err := r.tinyAppClient.Update(ctx, app); err != nil {
+		return errors.WithMessage(err, "failed to add finalizer to TinyApp")
+	}
+
+	return nil
+}
+
+// reconcileDeletion cleans up resources that aren't owned by app, so aren't garbage collected along with it.
+// Finalizer is only removed once cleanup succeeds, so that failed cleanups are retried.
+func (r *reconciler) reconcileDeletion(ctx context.Context, app *v1alpha1.TinyApp) error {
+	if !controllerutil.ContainsFinalizer(app, util.CleanupFinalizer) {
+		return nil
+	}
+
+	zap.S().Infow("Cleaning up deleted TinyApp", "name", app.Name)
+	r.recorder.Event(app, corev1.EventTypeNormal, eventReasonDeleting, "Cleaning up TinyApp resources")
+
+	if err := r.deleteAppSecrets(ctx, app); err != nil {
+		r.recorder.Eventf(app, corev1.EventTypeWarning, eventReasonCleanupFailed, "Failed to clean up TinyApp resources: %v", err)
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(app, util.CleanupFinalizer)
+	if err := r.tinyAppClient.Update(ctx, app); err != nil {
+		return errors.WithMessage(err, "failed to remove finalizer from TinyApp")
+	}
+
+	r.recorder.Event(app, corev1.EventTypeNormal, eventReasonCleanedUp, "Cleaned up TinyApp resources")
+
+	return nil
+}
+
+// deleteAppSecrets deletes git token secret created for app, along with any other secret generated for app
+// (ex. certificates). Only secrets that tinyapp labelled with app name are deleted, so that secrets created by
+// users are kept even when app refers to them or they are named after app.
+func (r *reconciler) deleteAppSecrets(ctx context.Context, app *v1alpha1.TinyApp) error {
+	secrets := r.k8sClient.CoreV1().Secrets(r.env.TinyAppNamespace)
+
+	selector := labels.SelectorFromSet(map[string]string{
+		globalutil.K8sNameLabel:   app.Name,
+		globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel,
+	})
+	secretList, err := secrets.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return errors.WithMessage(err, "failed to list TinyApp secrets")
+	}
+
+	for _, secret := range secretList.Items {
+		err := secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return errors.WithMessagef(err, "failed to delete TinyApp secret %s", secret.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestSecret(name string, labels map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: labels},
+		Data:       map[string][]byte{util.GitTokenSecretKey: []byte("token")},
+	}
+}
+
+func TestDeleteAppSecrets(t *testing.T) {
+	appLabels := map[string]string{
+		globalutil.K8sNameLabel:   "abc",
+		globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel,
+	}
+	otherAppLabels := map[string]string{
+		globalutil.K8sNameLabel:   "other",
+		globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel,
+	}
+
+	tests := []struct {
+		name        string
+		secret      *corev1.Secret
+		wantDeleted bool
+	}{
+		{name: "token secret created by server", secret: newTestSecret("abc", appLabels), wantDeleted: true},
+		{name: "other secret created for app", secret: newTestSecret("abc-tls", appLabels), wantDeleted: true},
+		{name: "user secret named after app", secret: newTestSecret("abc", nil)},
+		{name: "user secret with app name label only", secret: newTestSecret("abc", map[string]string{globalutil.K8sNameLabel: "abc"})},
+		{name: "secret of other app", secret: newTestSecret("other", otherAppLabels)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			k8sClient := fakekubernetes.NewSimpleClientset(test.secret)
+			r := &reconciler{k8sClient: k8sClient, env: internal.EnvVars{TinyAppNamespace: testNamespace}}
+
+			// App refers to secret named after it, as apps whose token was sent to server do
+			app := newTestGitApp("https://github.com/org/repo.git")
+			app.Spec.GitConfig.TokenSecretName = "abc"
+
+			if err := r.deleteAppSecrets(context.Background(), app); err != nil {
+				t.Fatalf("failed to delete secrets: %v", err)
+			}
+
+			_, err := k8sClient.CoreV1().Secrets(testNamespace).Get(context.Background(), test.secret.Name, metav1.GetOptions{})
+			if deleted := k8sErrors.IsNotFound(err); deleted != test.wantDeleted {
+				t.Errorf("got deleted %t, want %t (error %v)", deleted, test.wantDeleted, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/controller/git"
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	eventReasonGitCommitChanged = "GitCommitChanged"
+	eventReasonGitResolveFailed = "GitResolveFailed"
+	// How often to retry resolving git ref when git repo can't be reached
+	gitResolveRetryInterval = time.Minute
+)
+
+// reconcileGitCommit resolves git ref of app to a commit SHA, and records it in app status. App pods check out the
+// recorded commit, so that deploys are reproducible while branch moves, and pods are rolled when it changes.
+// Branches & tags are resolved when app spec has changed, on restart and every git sync interval of branches.
+// Returns how long to wait before git ref should be resolved again, or zero if there is no need to.
+func (r *reconciler) reconcileGitCommit(ctx context.Context, app *v1alpha1.TinyApp, specChanged bool) time.Duration {
+	logger := zap.S().With("app", app.Name)
+
+	gitConfig := app.Spec.GitConfig
+	if app.Spec.SourceType != v1alpha1.SourceTypeGit || gitConfig == nil {
+		app.Status.ResolvedGitCommit = ""
+		app.Status.GitResolvedTime = nil
+		app.Status.ResolvedGitBranch = ""
+		return 0
+	}
+
+	refType := gitConfig.GetRefType()
+	if refType == v1alpha1.GitRefTypeCommit {
+		app.Status.ResolvedGitCommit = gitConfig.GitRef
+		app.Status.GitResolvedTime = nil
+		return r.reconcileGitBranch(ctx, app, specChanged)
+	}
+	app.Status.ResolvedGitBranch = ""
+
+	var interval time.Duration
+	if refType == v1alpha1.GitRefTypeBranch && gitConfig.SyncInterval != nil {
+		interval = gitConfig.SyncInterval.Duration
+	}
+
+	now := time.Now()
+	resolvedTime := app.Status.GitResolvedTime
+	if !specChanged && app.Status.ResolvedGitCommit != "" && resolvedTime != nil && !isRestartRequestedSince(app, resolvedTime.Time) {
+		if interval == 0 {
+			return 0
+		}
+		if sinceResolved := now.Sub(resolvedTime.Time); sinceResolved < interval {
+			return interval - sinceResolved
+		}
+	}
+
+	commit, err := r.resolveGitRef(ctx, app)
+	if err != nil {
+		// Keep app on the commit it has, and try again later. App pods check out the ref itself if it was
+		// never resolved.
+		logger.Warnw("Failed to resolve git ref", "ref", gitConfig.GitRef, "error", err)
+		r.recorder.Eventf(app, corev1.EventTypeWarning, eventReasonGitResolveFailed, "Failed to resolve git %s %s: %v",
+			strings.ToLower(string(refType)), gitConfig.GitRef, err)
+		if interval != 0 && interval < gitResolveRetryInterval {
+			return interval
+		}
+		return gitResolveRetryInterval
+	}
+
+	if app.Status.ResolvedGitCommit != "" && commit != app.Status.ResolvedGitCommit {
+		logger.Infow("Git ref moved, rolling app pods", "ref", gitConfig.GitRef, "from", app.Status.ResolvedGitCommit, "to", commit)
+		r.recorder.Eventf(app, corev1.EventTypeNormal, eventReasonGitCommitChanged, "%s %s moved from %s to %s",
+			refType, gitConfig.GitRef, app.Status.ResolvedGitCommit, commit)
+	}
+
+	app.Status.ResolvedGitCommit = commit
+	app.Status.GitResolvedTime = &metav1.Time{Time: now}
+	return interval
+}
+
+// reconcileGitBranch resolves default branch of git repo for commit refs without branch, and records it in app
+// status. git-sync fetches the commit from this branch, since it can't fetch a commit by itself.
+// Returns how long to wait before branch should be resolved again, or zero if there is no need to.
+func (r *reconciler) reconcileGitBranch(ctx context.Context, app *v1alpha1.TinyApp, specChanged bool) time.Duration {
+	gitConfig := app.Spec.GitConfig
+	if gitConfig.Branch != "" {
+		app.Status.ResolvedGitBranch = ""
+		return 0
+	}
+	if !specChanged && app.Status.ResolvedGitBranch != "" {
+		return 0
+	}
+
+	token, err := r.getGitToken(ctx, app)
+	if err == nil {
+		app.Status.ResolvedGitBranch, err = git.ResolveDefaultBranch(ctx, gitConfig.GitUrl, token)
+	}
+	if err != nil {
+		zap.S().Warnw("Failed to resolve default git branch", "app", app.Name, "error", err)
+		r.recorder.Eventf(app, corev1.EventTypeWarning, eventReasonGitResolveFailed, "Failed to resolve default branch of git repo: %v", err)
+		return gitResolveRetryInterval
+	}
+
+	return 0
+}
+
+// isRestartRequestedSince returns true if app was annotated for restart after given time, in which case git ref is
+// resolved right away so that restarted pods check out its latest commit (ex. when restarted by a git push webhook).
+func isRestartRequestedSince(app *v1alpha1.TinyApp, since time.Time) bool {
+	restartedAt, err := time.Parse(time.RFC3339Nano, app.Annotations[globalutil.AnnotationRestartedAt])
+	if err != nil {
+		return false
+	}
+
+	return restartedAt.After(since)
+}
+
+// resolveGitRef returns the commit that app branch or tag currently points to.
+func (r *reconciler) resolveGitRef(ctx context.Context, app *v1alpha1.TinyApp) (string, error) {
+	gitConfig := app.Spec.GitConfig
+
+	token, err := r.getGitToken(ctx, app)
+	if err != nil {
+		return "", err
+	}
+
+	return git.ResolveRef(ctx, gitConfig.GitUrl, gitConfig.GitRef, gitConfig.GetRefType() == v1alpha1.GitRefTypeTag, token)
+}
+
+// getGitToken returns git token of app from its token secret. Returns empty token if secret doesn't exist, so that
+// public repos are read anonymously, and so are private ones until their secret is created.
+func (r *reconciler) getGitToken(ctx context.Context, app *v1alpha1.TinyApp) (string, error) {
+	secret, err := r.k8sClient.CoreV1().Secrets(r.env.TinyAppNamespace).Get(ctx, app.Spec.GitConfig.TokenSecretName, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to get git token secret")
+	}
+
+	return string(secret.Data[util.GitTokenSecretKey]), nil
+}
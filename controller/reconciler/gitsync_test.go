@@ -0,0 +1,131 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+const testCommit = "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c"
+
+func pktLine(payload string) string {
+	return fmt.Sprintf("%04x%s", len(payload)+4, payload)
+}
+
+// newTestGitServer returns git server that advertises main branch at testCommit, or fails with given status.
+// Requests with basic auth are recorded in authorized.
+func newTestGitServer(t *testing.T, failStatus int, authorized *bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			*authorized = true
+		}
+		if failStatus != 0 {
+			w.WriteHeader(failStatus)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+		fmt.Fprint(w, pktLine("# service=git-upload-pack\n")+"0000"+
+			pktLine(testCommit+" HEAD\x00symref=HEAD:refs/heads/main\n")+
+			pktLine(testCommit+" refs/heads/main\n")+"0000")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestGitApp(gitURL string) *v1alpha1.TinyApp {
+	return &v1alpha1.TinyApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: testNamespace},
+		Spec: v1alpha1.TinyAppSpec{
+			SourceType: v1alpha1.SourceTypeGit,
+			GitConfig: &v1alpha1.GitConfig{
+				GitUrl:          gitURL,
+				RefType:         v1alpha1.GitRefTypeBranch,
+				GitRef:          "main",
+				TokenSecretName: "git-token",
+			},
+		},
+	}
+}
+
+func TestReconcileGitCommit(t *testing.T) {
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: testNamespace},
+		Data:       map[string][]byte{util.GitTokenSecretKey: []byte("token")},
+	}
+
+	tests := []struct {
+		name           string
+		secret         *corev1.Secret
+		failStatus     int
+		wantCommit     string
+		wantAuthorized bool
+		wantWarning    bool
+	}{
+		{name: "token secret", secret: tokenSecret, wantCommit: testCommit, wantAuthorized: true},
+		{name: "missing token secret", wantCommit: testCommit},
+		{name: "unreachable repo", failStatus: http.StatusInternalServerError, wantWarning: true},
+		{name: "private repo without token secret", failStatus: http.StatusUnauthorized, wantWarning: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var authorized bool
+			gitServer := newTestGitServer(t, test.failStatus, &authorized)
+
+			k8sClient := fakekubernetes.NewSimpleClientset()
+			if test.secret != nil {
+				k8sClient = fakekubernetes.NewSimpleClientset(test.secret)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &reconciler{k8sClient: k8sClient, env: internal.EnvVars{TinyAppNamespace: testNamespace}, recorder: recorder}
+
+			app := newTestGitApp(gitServer.URL)
+			r.reconcileGitCommit(context.Background(), app, true)
+
+			if app.Status.ResolvedGitCommit != test.wantCommit {
+				t.Errorf("got commit %q, want %q", app.Status.ResolvedGitCommit, test.wantCommit)
+			}
+			if authorized != test.wantAuthorized {
+				t.Errorf("got authorized %t, want %t", authorized, test.wantAuthorized)
+			}
+
+			var warnings []string
+			for len(recorder.Events) > 0 {
+				if event := <-recorder.Events; strings.HasPrefix(event, corev1.EventTypeWarning) {
+					warnings = append(warnings, event)
+				}
+			}
+			if (len(warnings) > 0) != test.wantWarning {
+				t.Errorf("got warnings %v, want warning %t", warnings, test.wantWarning)
+			}
+		})
+	}
+}
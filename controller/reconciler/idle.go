@@ -0,0 +1,164 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/controller/reconciler/builder"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	gatewayActivityTimeout = 5 * time.Second
+	// How often to check on app while it's waking up
+	wakeUpRequeueInterval = 5 * time.Second
+	// How often to retry checking app activity when gateways can't be reached
+	activityRetryInterval = time.Minute
+)
+
+var activityClient = &http.Client{Timeout: gatewayActivityTimeout}
+
+// reconcileIdleState puts app to sleep once it has been idle for longer than its idle timeout, and wakes it up
+// once the activator requests it. Sleeping apps are scaled to zero when deployment is reconciled.
+// Returns how long to wait before app activity should be checked again, or zero if there is no need to.
+func (r *reconciler) reconcileIdleState(ctx context.Context, app *v1alpha1.TinyApp) time.Duration {
+	logger := zap.S().With("app", app.Name)
+
+	if !builder.ScaleToZeroEnabled(app, r.env) {
+		app.Status.SleepingSince = nil
+		app.Status.LastRequestTime = nil
+		return 0
+	}
+
+	now := time.Now()
+
+	if app.Status.IsSleeping() {
+		if !isWakeUpRequested(app) {
+			// Activator will request wake up when a request comes in
+			return 0
+		}
+
+		logger.Info("Waking up app")
+		app.Status.SleepingSince = nil
+		app.Status.LastRequestTime = &metav1.Time{Time: now}
+		return wakeUpRequeueInterval
+	}
+
+	lastRequestTime, err := r.getLastRequestTime(ctx, app)
+	if err != nil {
+		// Can't tell whether app is idle, so keep it awake
+		logger.Warnw("Failed to get app activity from gateways", "error", err)
+		return activityRetryInterval
+	}
+
+	// Idle time is counted from when app is first seen if it has never received a request
+	if app.Status.LastRequestTime == nil {
+		app.Status.LastRequestTime = &metav1.Time{Time: now}
+	}
+	if lastRequestTime.After(app.Status.LastRequestTime.Time) {
+		app.Status.LastRequestTime = &metav1.Time{Time: lastRequestTime}
+	}
+
+	idleFor := now.Sub(app.Status.LastRequestTime.Time)
+	if idleFor < app.Spec.IdleTimeout.Duration {
+		return app.Spec.IdleTimeout.Duration - idleFor
+	}
+
+	logger.Infow("App is idle, putting it to sleep", "idleFor", idleFor.String())
+	app.Status.SleepingSince = &metav1.Time{Time: now}
+	return 0
+}
+
+// isWakeUpRequested returns true if activator requested wake up after app went to sleep.
+func isWakeUpRequested(app *v1alpha1.TinyApp) bool {
+	requestedAt, err := time.Parse(time.RFC3339, app.Annotations[globalutil.AnnotationWakeRequestedAt])
+	if err != nil {
+		return false
+	}
+
+	return !requestedAt.Before(app.Status.SleepingSince.Time)
+}
+
+// getLastRequestTime asks gateways of running app pods when app last received a request.
+// Returns current time if any gateway has a request in flight, and zero time if no pod is running.
+func (r *reconciler) getLastRequestTime(ctx context.Context, app *v1alpha1.TinyApp) (time.Time, error) {
+	pods, err := r.k8sClient.CoreV1().Pods(r.env.TinyAppNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(app.Labels).String(),
+	})
+	if err != nil {
+		return time.Time{}, errors.WithMessage(err, "failed to list app pods")
+	}
+
+	var lastRequestTime time.Time
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+
+		podActivity, err := getGatewayActivity(ctx, pod.Status.PodIP)
+		if err != nil {
+			return time.Time{}, errors.WithMessagef(err, "failed to get activity of pod %s", pod.Name)
+		}
+
+		if podActivity.ActiveRequests > 0 {
+			return time.Now(), nil
+		}
+
+		if podActivity.LastRequestTime.After(lastRequestTime) {
+			lastRequestTime = podActivity.LastRequestTime
+		}
+	}
+
+	return lastRequestTime, nil
+}
+
+// getGatewayActivity gets app activity from the admin endpoint of gateway running in pod with given ip.
+func getGatewayActivity(ctx context.Context, podIP string) (*globalutil.GatewayActivity, error) {
+	activityURL := fmt.Sprintf("http://%s:%d%s", podIP, globalutil.DefaultGatewayAdminPort, globalutil.GatewayActivityPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, activityURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := activityClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	podActivity := &globalutil.GatewayActivity{}
+	if err := json.NewDecoder(res.Body).Decode(podActivity); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode activity")
+	}
+
+	return podActivity, nil
+}
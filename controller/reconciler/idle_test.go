@@ -0,0 +1,259 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "tinyapp"
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// useTestGateways makes activity of gateway in pod with given ip come from gateways, for the duration of test.
+// Gateways missing activity fail.
+func useTestGateways(t *testing.T, gateways map[string]*globalutil.GatewayActivity) {
+	original := activityClient
+	t.Cleanup(func() { activityClient = original })
+
+	activityClient = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		res := httptest.NewRecorder()
+		activity, ok := gateways[req.URL.Hostname()]
+		if !ok {
+			t.Errorf("got activity request to unexpected pod %s", req.URL.Hostname())
+		}
+		if req.URL.Port() != fmt.Sprint(globalutil.DefaultGatewayAdminPort) || req.URL.Path != globalutil.GatewayActivityPath {
+			t.Errorf("got activity request to %s", req.URL)
+		}
+		if activity == nil {
+			res.WriteHeader(http.StatusInternalServerError)
+		} else {
+			_ = json.NewEncoder(res).Encode(activity)
+		}
+		return res.Result(), nil
+	})}
+}
+
+func newTestAppPod(name, ip string, phase corev1.PodPhase) runtime.Object {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: map[string]string{globalutil.K8sNameLabel: "abc"}},
+		Status:     corev1.PodStatus{Phase: phase, PodIP: ip},
+	}
+}
+
+func newTestIdleApp(idleTimeout time.Duration) *v1alpha1.TinyApp {
+	return &v1alpha1.TinyApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: testNamespace, Labels: map[string]string{globalutil.K8sNameLabel: "abc"}},
+		Spec:       v1alpha1.TinyAppSpec{IdleTimeout: &metav1.Duration{Duration: idleTimeout}},
+	}
+}
+
+func newTestIdleReconciler(pods ...runtime.Object) *reconciler {
+	return &reconciler{
+		k8sClient: fakekubernetes.NewSimpleClientset(pods...),
+		env:       internal.EnvVars{TinyAppNamespace: testNamespace, ActivatorServiceName: "tinyapp-activator"},
+	}
+}
+
+func metaTime(t time.Time) *metav1.Time {
+	return &metav1.Time{Time: t}
+}
+
+func TestReconcileIdleState(t *testing.T) {
+	now := time.Now()
+	sleepingSince := now.Add(-time.Hour)
+
+	tests := []struct {
+		name            string
+		noIdleTimeout   bool
+		status          v1alpha1.TinyAppStatus
+		wakeRequestedAt time.Time
+		gateway         *globalutil.GatewayActivity
+		wantSleeping    bool
+		// Wanted last request time, zero if it should be unset, or now if it should be set to current time
+		wantLastRequest time.Time
+		wantRequeue     time.Duration
+	}{
+		{
+			name:          "scale to zero disabled",
+			noIdleTimeout: true,
+			status:        v1alpha1.TinyAppStatus{SleepingSince: metaTime(sleepingSince), LastRequestTime: metaTime(sleepingSince)},
+		},
+		{
+			name:            "sleeping without wake up request",
+			status:          v1alpha1.TinyAppStatus{SleepingSince: metaTime(sleepingSince), LastRequestTime: metaTime(sleepingSince)},
+			wantSleeping:    true,
+			wantLastRequest: sleepingSince,
+		},
+		{
+			name:            "wake up requested before app went to sleep",
+			status:          v1alpha1.TinyAppStatus{SleepingSince: metaTime(sleepingSince), LastRequestTime: metaTime(sleepingSince)},
+			wakeRequestedAt: sleepingSince.Add(-time.Minute),
+			wantSleeping:    true,
+			wantLastRequest: sleepingSince,
+		},
+		{
+			name:            "wake up requested",
+			status:          v1alpha1.TinyAppStatus{SleepingSince: metaTime(sleepingSince), LastRequestTime: metaTime(sleepingSince)},
+			wakeRequestedAt: now,
+			wantLastRequest: now,
+			wantRequeue:     wakeUpRequeueInterval,
+		},
+		{
+			name:            "never received a request",
+			gateway:         &globalutil.GatewayActivity{},
+			wantLastRequest: now,
+			wantRequeue:     time.Hour,
+		},
+		{
+			name:            "recent request",
+			status:          v1alpha1.TinyAppStatus{LastRequestTime: metaTime(now.Add(-2 * time.Hour))},
+			gateway:         &globalutil.GatewayActivity{LastRequestTime: now.Add(-10 * time.Minute)},
+			wantLastRequest: now.Add(-10 * time.Minute),
+			wantRequeue:     50 * time.Minute,
+		},
+		{
+			name:            "idle",
+			status:          v1alpha1.TinyAppStatus{LastRequestTime: metaTime(now.Add(-2 * time.Hour))},
+			gateway:         &globalutil.GatewayActivity{LastRequestTime: now.Add(-3 * time.Hour)},
+			wantSleeping:    true,
+			wantLastRequest: now.Add(-2 * time.Hour),
+		},
+		{
+			name:            "request in flight",
+			status:          v1alpha1.TinyAppStatus{LastRequestTime: metaTime(now.Add(-2 * time.Hour))},
+			gateway:         &globalutil.GatewayActivity{LastRequestTime: now.Add(-3 * time.Hour), ActiveRequests: 1},
+			wantLastRequest: now,
+			wantRequeue:     time.Hour,
+		},
+		{
+			name:            "gateway unreachable",
+			status:          v1alpha1.TinyAppStatus{LastRequestTime: metaTime(now.Add(-2 * time.Hour))},
+			wantLastRequest: now.Add(-2 * time.Hour),
+			wantRequeue:     activityRetryInterval,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useTestGateways(t, map[string]*globalutil.GatewayActivity{"10.0.0.1": test.gateway})
+			r := newTestIdleReconciler(newTestAppPod("abc-1", "10.0.0.1", corev1.PodRunning))
+
+			app := newTestIdleApp(time.Hour)
+			if test.noIdleTimeout {
+				app.Spec.IdleTimeout = nil
+			}
+			app.Status = test.status
+			if !test.wakeRequestedAt.IsZero() {
+				app.Annotations = map[string]string{globalutil.AnnotationWakeRequestedAt: test.wakeRequestedAt.UTC().Format(time.RFC3339)}
+			}
+
+			requeue := r.reconcileIdleState(context.Background(), app)
+
+			if app.Status.IsSleeping() != test.wantSleeping {
+				t.Errorf("got sleeping %t, want %t", app.Status.IsSleeping(), test.wantSleeping)
+			}
+			if test.wantLastRequest.IsZero() {
+				if app.Status.LastRequestTime != nil {
+					t.Errorf("got last request time %s, want none", app.Status.LastRequestTime)
+				}
+			} else if app.Status.LastRequestTime == nil {
+				t.Errorf("got no last request time, want %s", test.wantLastRequest)
+			} else if diff := app.Status.LastRequestTime.Sub(test.wantLastRequest); diff < -time.Second || diff > time.Second {
+				t.Errorf("got last request time %s, want %s", app.Status.LastRequestTime, test.wantLastRequest)
+			}
+			if diff := requeue - test.wantRequeue; diff < -time.Second || diff > time.Second {
+				t.Errorf("got requeue after %s, want %s", requeue, test.wantRequeue)
+			}
+		})
+	}
+}
+
+func TestGetLastRequestTime(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		pods     []runtime.Object
+		gateways map[string]*globalutil.GatewayActivity
+		want     time.Time
+		wantErr  bool
+	}{
+		{
+			name: "no pods",
+		},
+		{
+			name: "latest of running pods",
+			pods: []runtime.Object{
+				newTestAppPod("abc-1", "10.0.0.1", corev1.PodRunning),
+				newTestAppPod("abc-2", "10.0.0.2", corev1.PodRunning),
+				newTestAppPod("abc-3", "10.0.0.3", corev1.PodPending),
+				newTestAppPod("abc-4", "", corev1.PodRunning),
+			},
+			gateways: map[string]*globalutil.GatewayActivity{
+				"10.0.0.1": {LastRequestTime: now.Add(-time.Hour)},
+				"10.0.0.2": {LastRequestTime: now.Add(-time.Minute)},
+			},
+			want: now.Add(-time.Minute),
+		},
+		{
+			name: "failing gateway",
+			pods: []runtime.Object{
+				newTestAppPod("abc-1", "10.0.0.1", corev1.PodRunning),
+				newTestAppPod("abc-2", "10.0.0.2", corev1.PodRunning),
+			},
+			gateways: map[string]*globalutil.GatewayActivity{
+				"10.0.0.1": {LastRequestTime: now.Add(-time.Hour)},
+				"10.0.0.2": nil,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useTestGateways(t, test.gateways)
+			r := newTestIdleReconciler(test.pods...)
+
+			got, err := r.getLastRequestTime(context.Background(), newTestIdleApp(time.Hour))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %t", err, test.wantErr)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("got last request time %s, want %s", got, test.want)
+			}
+		})
+	}
+}
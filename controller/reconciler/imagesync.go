@@ -0,0 +1,122 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/controller/image"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	eventReasonImageDigestChanged = "ImageDigestChanged"
+	eventReasonImageResolveFailed = "ImageResolveFailed"
+	// How often to retry resolving app image when image registry can't be reached
+	imageResolveRetryInterval = time.Minute
+)
+
+// reconcileImageDigest resolves image of apps baked into their image to a digest, and records it in app status.
+// App pods run the recorded digest, so that deploys are reproducible while image tag moves, and pods are rolled
+// when it changes. Image tags are resolved when app spec has changed and on restart.
+// Returns how long to wait before image should be resolved again, or zero if there is no need to.
+func (r *reconciler) reconcileImageDigest(ctx context.Context, app *v1alpha1.TinyApp, specChanged bool) time.Duration {
+	logger := zap.S().With("app", app.Name)
+
+	if app.Spec.SourceType != v1alpha1.SourceTypeImage {
+		app.Status.ImageDigest = ""
+		app.Status.ImageResolvedTime = nil
+		return 0
+	}
+
+	resolvedTime := app.Status.ImageResolvedTime
+	if !specChanged && app.Status.ImageDigest != "" && resolvedTime != nil && !isRestartRequestedSince(app, resolvedTime.Time) {
+		return 0
+	}
+
+	digest, err := r.resolveImageDigest(ctx, app)
+	if err != nil {
+		// Keep app on the digest it has, and try again later. App pods run the image tag itself if it was
+		// never resolved.
+		logger.Warnw("Failed to resolve image digest", "image", app.Spec.Image, "error", err)
+		r.recorder.Eventf(app, corev1.EventTypeWarning, eventReasonImageResolveFailed, "Failed to resolve image %s: %v",
+			app.Spec.Image, err)
+		return imageResolveRetryInterval
+	}
+
+	if app.Status.ImageDigest != "" && digest != app.Status.ImageDigest {
+		logger.Infow("Image tag moved, rolling app pods", "image", app.Spec.Image, "from", app.Status.ImageDigest, "to", digest)
+		r.recorder.Eventf(app, corev1.EventTypeNormal, eventReasonImageDigestChanged, "Image %s moved from %s to %s",
+			app.Spec.Image, app.Status.ImageDigest, digest)
+	}
+
+	app.Status.ImageDigest = digest
+	app.Status.ImageResolvedTime = &metav1.Time{Time: time.Now()}
+	return 0
+}
+
+// resolveImageDigest returns the digest that app image currently points to, logging in to its registry with image
+// pull secrets of app service account.
+func (r *reconciler) resolveImageDigest(ctx context.Context, app *v1alpha1.TinyApp) (string, error) {
+	ref, err := image.ParseReference(app.Spec.Image)
+	if err != nil {
+		return "", err
+	}
+
+	credentials, err := r.getImagePullCredentials(ctx, ref.Domain)
+	if err != nil {
+		return "", err
+	}
+
+	return image.ResolveDigest(ctx, app.Spec.Image, credentials)
+}
+
+// getImagePullCredentials returns credentials of image registry domain from image pull secrets of app service
+// account. Returns nil if there are none.
+func (r *reconciler) getImagePullCredentials(ctx context.Context, domain string) (*image.Credentials, error) {
+	serviceAccount, err := r.k8sClient.CoreV1().ServiceAccounts(r.env.TinyAppNamespace).Get(ctx, r.env.AppServiceAccount, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get app service account")
+	}
+
+	for _, pullSecret := range serviceAccount.ImagePullSecrets {
+		secret, err := r.k8sClient.CoreV1().Secrets(r.env.TinyAppNamespace).Get(ctx, pullSecret.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get image pull secret %s", pullSecret.Name)
+		}
+
+		config, ok := secret.Data[corev1.DockerConfigJsonKey]
+		if !ok {
+			continue
+		}
+
+		credentials, err := image.CredentialsFromDockerConfig(config, domain)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid image pull secret %s", pullSecret.Name)
+		}
+		if credentials != nil {
+			return credentials, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -18,16 +18,22 @@ package reconciler
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/tinymultiverse/tinyapp/controller/reconciler/builder"
 	"github.com/tinymultiverse/tinyapp/controller/util"
 	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/validation"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/tinymultiverse/tinyapp/controller/internal"
 	"go.uber.org/zap"
@@ -41,15 +47,20 @@ type reconciler struct {
 	tinyAppClient client.Client
 	k8sClient     kubernetes.Interface
 	env           internal.EnvVars
+	recorder      record.EventRecorder
+	runtimes      *builder.RuntimeRegistry
 }
 
 // NewReconciler returns a reconciler
-func NewReconciler(tinyAppClient client.Client, k8sClient kubernetes.Interface, env internal.EnvVars) *reconciler {
+func NewReconciler(tinyAppClient client.Client, k8sClient kubernetes.Interface, env internal.EnvVars,
+	recorder record.EventRecorder, runtimes *builder.RuntimeRegistry) *reconciler {
 	workqueue.DefaultControllerRateLimiter()
 	return &reconciler{
 		tinyAppClient: tinyAppClient,
 		k8sClient:     k8sClient,
 		env:           env,
+		recorder:      recorder,
+		runtimes:      runtimes,
 	}
 }
 
@@ -74,50 +85,123 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	logger.Debug("Retrieved TinyApp")
 
 	if tinyApp.DeletionTimestamp != nil {
-		logger.Debugw("ForegroundDeletion is in process", "deletionTimestamp", tinyApp.DeletionTimestamp)
+		logger.Debugw("TinyApp deletion is in process", "deletionTimestamp", tinyApp.DeletionTimestamp)
+		if err := r.reconcileDeletion(ctx, tinyApp); err != nil {
+			logger.Errorw("Failed to clean up TinyApp", "error", err)
+			return reconcile.Result{}, err
+		}
 		return reconcile.Result{}, nil
 	}
 
+	if err := r.ensureFinalizer(ctx, tinyApp); err != nil {
+		logger.Errorw("Failed to add TinyApp finalizer", "error", err)
+		return reconcile.Result{}, err
+	}
+
 	// Reconcile TinyApp state
-	err = r.reconcileTinyAppState(ctx, tinyApp)
+	requeueAfter, err := r.reconcileTinyAppState(ctx, tinyApp)
 	if err != nil {
 		logger.Errorw("Failed to reconcile TinyApp state", "name", tinyApp.GetName(), "error", err)
 	}
 
 	logger.Infow("Reconcile request completed", "app status", tinyApp.Status)
 
-	return reconcile.Result{}, err
+	return reconcile.Result{RequeueAfter: requeueAfter}, err
 }
 
 // reconcileTinyAppState updates TinyApp status and all its dependents.
-func (r *reconciler) reconcileTinyAppState(ctx context.Context, app *v1alpha1.TinyApp) error {
+// Returns how long to wait before TinyApp should be reconciled again, or zero if there is no need to.
+func (r *reconciler) reconcileTinyAppState(ctx context.Context, app *v1alpha1.TinyApp) (time.Duration, error) {
 	logger := zap.S().With("app", app.Name)
 
 	defer r.updateAppStatus(ctx, app)
 	app.Status.InitConditions()
+	specChanged := app.Status.ObservedGeneration != app.Generation
+	app.Status.ObservedGeneration = app.Generation
+
+	// Runtimes that failed to load are logged only, so that apps of other app types keep being reconciled
+	if err := r.runtimes.Refresh(ctx); err != nil {
+		logger.Errorw("Failed to refresh app runtimes", "error", err)
+	}
+
+	// Apps are validated again in case admission webhook is disabled, since invalid specs can't be deployed
+	errs := validation.ValidateTinyApp(app, r.runtimes.AppTypes())
+	errs = append(errs, validation.ValidateResourceMaximums(&app.Spec, field.NewPath("spec"), r.env.MaxAppCPU, r.env.MaxAppMemory)...)
+	if len(errs) > 0 {
+		logger.Infow("TinyApp spec is invalid", "errors", errs.ToAggregate().Error())
+		app.Status.SetCondition(v1alpha1.SpecValid, v1.ConditionFalse, v1alpha1.ReasonInvalidSpec, errs.ToAggregate().Error())
+		return 0, nil
+	}
+	app.Status.SetCondition(v1alpha1.SpecValid, v1.ConditionTrue, "", "")
+
+	// Decide whether app should sleep first, since it determines how ingress & deployment are built
+	logger.Debug("Reconciling idle state")
+	requeueAfter := r.reconcileIdleState(ctx, app)
+
+	// Resolved git commit determines which commit app pods check out
+	logger.Debug("Reconciling git commit")
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileGitCommit(ctx, app, specChanged))
+
+	// Resolved image digest determines which image app pods run, for apps baked into their image
+	logger.Debug("Reconciling image digest")
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileImageDigest(ctx, app, specChanged))
 
 	logger.Debug("Reconciling service")
 	if err := r.reconcileService(ctx, app); err != nil {
 		app.Status.SetConditionFalseWithMessage(v1alpha1.ServiceCreated, err.Error())
-		return err
+		return 0, err
 	}
 	app.Status.SetConditionTrue(v1alpha1.ServiceCreated)
 
 	logger.Debug("Reconciling ingress")
 	if err := r.reconcileIngress(ctx, app); err != nil {
 		app.Status.SetConditionFalseWithMessage(v1alpha1.IngressCreated, err.Error())
-		return err
+		return 0, err
 	}
 	app.Status.SetConditionTrue(v1alpha1.IngressCreated)
 
 	logger.Debug("Reconciling deployment")
 	if err := r.reconcileDeployment(ctx, app); err != nil {
 		app.Status.SetConditionFalseWithMessage(v1alpha1.DeploymentCreated, err.Error())
-		return err
+		return 0, err
 	}
 	app.Status.SetConditionTrue(v1alpha1.DeploymentCreated)
 
-	return nil
+	logger.Debug("Reconciling horizontal pod autoscaler")
+	if err := r.reconcileHorizontalPodAutoscaler(ctx, app); err != nil {
+		app.Status.SetConditionFalseWithMessage(v1alpha1.HorizontalPodAutoscalerCreated, err.Error())
+		return 0, err
+	}
+	app.Status.SetConditionTrue(v1alpha1.HorizontalPodAutoscalerCreated)
+
+	deployment, err := r.k8sClient.AppsV1().Deployments(r.env.TinyAppNamespace).Get(ctx, app.Name, metav1.GetOptions{})
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to get TinyApp deployment")
+	}
+
+	updateScaleStatus(app, deployment)
+
+	logger.Debug("Updating rollout status")
+	rolloutRequeueAfter, err := r.updateRolloutStatus(ctx, app, deployment)
+	if err != nil {
+		return 0, err
+	}
+	requeueAfter = minRequeueAfter(requeueAfter, rolloutRequeueAfter)
+
+	// Keep checking on waking app until it's ready, so that ingress is switched from activator back to app
+	if builder.RoutesToActivator(app, r.env) && !app.Status.IsSleeping() {
+		requeueAfter = minRequeueAfter(requeueAfter, wakeUpRequeueInterval)
+	}
+
+	return requeueAfter, nil
+}
+
+// minRequeueAfter returns the sooner of two requeue delays, where zero means no requeue.
+func minRequeueAfter(a, b time.Duration) time.Duration {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
 }
 
 func (r *reconciler) updateAppStatus(ctx context.Context, app *v1alpha1.TinyApp) {
@@ -274,7 +358,7 @@ func (r *reconciler) reconcileDeployment(ctx context.Context, app *v1alpha1.Tiny
 		}
 
 		// Update deployment
-		err = r.updateDeployment(&ctx, app)
+		err = r.updateDeployment(&ctx, app, deployment)
 		if err != nil {
 			return err
 		}
@@ -286,7 +370,7 @@ func (r *reconciler) reconcileDeployment(ctx context.Context, app *v1alpha1.Tiny
 func (r *reconciler) createDeployment(ctx *context.Context, app *v1alpha1.TinyApp) error {
 	zap.S().Infow("Creating deployment for TinyApp", "name", app.Name)
 
-	tinyAppDeployment, err := builder.BuildDeployment(app, r.env)
+	tinyAppDeployment, err := r.buildDeployment(app)
 	if err != nil {
 		return errors.WithMessage(err, "failed to create TinyApp deployment object")
 	}
@@ -299,14 +383,20 @@ func (r *reconciler) createDeployment(ctx *context.Context, app *v1alpha1.TinyAp
 	return nil
 }
 
-func (r *reconciler) updateDeployment(ctx *context.Context, app *v1alpha1.TinyApp) error {
+func (r *reconciler) updateDeployment(ctx *context.Context, app *v1alpha1.TinyApp, currentDeployment *appsv1.Deployment) error {
 	zap.S().Infow("Updating deployment for TinyApp", "name", app.Name)
 
-	newDeployment, err := builder.BuildDeployment(app, r.env)
+	newDeployment, err := r.buildDeployment(app)
 	if err != nil {
 		return errors.WithMessage(err, "failed to create TinyApp deployment object")
 	}
 
+	// Keep replica count set by the horizontal pod autoscaler, unless app is going to sleep or waking up
+	if app.Spec.Autoscaling != nil && !app.Status.IsSleeping() &&
+		currentDeployment.Spec.Replicas != nil && *currentDeployment.Spec.Replicas > 0 {
+		newDeployment.Spec.Replicas = currentDeployment.Spec.Replicas
+	}
+
 	_, err = r.k8sClient.AppsV1().Deployments(r.env.TinyAppNamespace).Update(*ctx, newDeployment, metav1.UpdateOptions{})
 	if err != nil {
 		return errors.WithMessage(err, "failed to update TinyApp deployment")
@@ -315,8 +405,102 @@ func (r *reconciler) updateDeployment(ctx *context.Context, app *v1alpha1.TinyAp
 	return nil
 }
 
+func (r *reconciler) reconcileHorizontalPodAutoscaler(ctx context.Context, app *v1alpha1.TinyApp) error {
+	hpaClient := r.k8sClient.AutoscalingV2().HorizontalPodAutoscalers(r.env.TinyAppNamespace)
+
+	// Autoscaling is not enabled - make sure autoscaler does not exist
+	if app.Spec.Autoscaling == nil {
+		err := hpaClient.Delete(ctx, app.Name, metav1.DeleteOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return errors.WithMessage(err, "failed to delete TinyApp horizontal pod autoscaler")
+		}
+		return nil
+	}
+
+	hpa, err := hpaClient.Get(ctx, app.Name, metav1.GetOptions{})
+	if err != nil {
+		// Autoscaler does not exist - create it
+		if k8sErrors.IsNotFound(err) {
+			err = r.createHorizontalPodAutoscaler(&ctx, app)
+			if err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+	} else {
+		// Autoscaler exists - first check if update is needed
+		if !r.shouldPerformHorizontalPodAutoscalerUpdate(app, hpa) {
+			zap.S().Infow("Horizontal pod autoscaler update not needed", "name", app.Name)
+			return nil
+		}
+
+		// Update autoscaler
+		err = r.updateHorizontalPodAutoscaler(&ctx, app)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *reconciler) createHorizontalPodAutoscaler(ctx *context.Context, app *v1alpha1.TinyApp) error {
+	zap.S().Infow("Creating horizontal pod autoscaler for TinyApp", "name", app.Name)
+
+	hpa, err := builder.BuildHorizontalPodAutoscaler(app, r.env)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create TinyApp horizontal pod autoscaler object")
+	}
+
+	_, err = r.k8sClient.AutoscalingV2().HorizontalPodAutoscalers(r.env.TinyAppNamespace).Create(*ctx, hpa, metav1.CreateOptions{})
+	if err != nil {
+		return errors.WithMessage(err, "failed to create TinyApp horizontal pod autoscaler")
+	}
+
+	return nil
+}
+
+func (r *reconciler) updateHorizontalPodAutoscaler(ctx *context.Context, app *v1alpha1.TinyApp) error {
+	zap.S().Infow("Updating horizontal pod autoscaler for TinyApp", "name", app.Name)
+
+	newHpa, err := builder.BuildHorizontalPodAutoscaler(app, r.env)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create TinyApp horizontal pod autoscaler object")
+	}
+
+	_, err = r.k8sClient.AutoscalingV2().HorizontalPodAutoscalers(r.env.TinyAppNamespace).Update(*ctx, newHpa, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.WithMessage(err, "failed to update TinyApp horizontal pod autoscaler")
+	}
+
+	return nil
+}
+
+// updateScaleStatus sets replica count & pod selector in app status, which back the TinyApp scale subresource.
+// Ready replica count is also recorded, to decide whether requests can be routed to app.
+func updateScaleStatus(app *v1alpha1.TinyApp, deployment *appsv1.Deployment) {
+	app.Status.Replicas = deployment.Status.Replicas
+	app.Status.ReadyReplicas = deployment.Status.ReadyReplicas
+	app.Status.Selector = metav1.FormatLabelSelector(deployment.Spec.Selector)
+}
+
+// buildDeployment returns deployment of app, run by the runtime of its app type.
+func (r *reconciler) buildDeployment(app *v1alpha1.TinyApp) (*appsv1.Deployment, error) {
+	runtime, err := r.runtimes.RuntimeFor(app)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.BuildDeployment(app, r.env, runtime)
+}
+
 func (r *reconciler) shouldPerformDeploymentUpdate(app *v1alpha1.TinyApp, currentAppDeployment *appsv1.Deployment) bool {
-	desiredTinyAppDeployment, _ := builder.BuildDeployment(app, r.env)
+	desiredTinyAppDeployment, err := r.buildDeployment(app)
+	if err != nil {
+		// Let the update surface the build error
+		return true
+	}
 	if desiredTinyAppDeployment.Annotations[util.AnnotationResourceHash] != currentAppDeployment.Annotations[util.AnnotationResourceHash] {
 		zap.S().Infow("Deployment hash diff detected, so will perform deployment update", "app name", app.Name)
 		return true
@@ -325,7 +509,11 @@ func (r *reconciler) shouldPerformDeploymentUpdate(app *v1alpha1.TinyApp, curren
 }
 
 func (r *reconciler) shouldPerformServiceUpdate(app *v1alpha1.TinyApp, currentAppService *corev1.Service) bool {
-	desiredAppService, _ := builder.BuildService(app, r.env)
+	desiredAppService, err := builder.BuildService(app, r.env)
+	if err != nil {
+		// Let the update surface the build error
+		return true
+	}
 	if desiredAppService.Annotations[util.AnnotationResourceHash] != currentAppService.Annotations[util.AnnotationResourceHash] {
 		zap.S().Infow("Service hash diff detected, so will perform service update", "app name", app.Name)
 		return true
@@ -334,10 +522,27 @@ func (r *reconciler) shouldPerformServiceUpdate(app *v1alpha1.TinyApp, currentAp
 }
 
 func (r *reconciler) shouldPerformIngressUpdate(app *v1alpha1.TinyApp, currentAppIngress *networkingv1.Ingress) bool {
-	desiredAppIngress, _ := builder.BuildIngress(app, r.env)
+	desiredAppIngress, err := builder.BuildIngress(app, r.env)
+	if err != nil {
+		// Let the update surface the build error
+		return true
+	}
 	if desiredAppIngress.Annotations[util.AnnotationResourceHash] != currentAppIngress.Annotations[util.AnnotationResourceHash] {
 		zap.S().Infow("Ingress hash diff detected, so will perform ingress update", "app name", app.Name)
 		return true
 	}
 	return false
 }
+
+func (r *reconciler) shouldPerformHorizontalPodAutoscalerUpdate(app *v1alpha1.TinyApp, currentHpa *autoscalingv2.HorizontalPodAutoscaler) bool {
+	desiredHpa, err := builder.BuildHorizontalPodAutoscaler(app, r.env)
+	if err != nil {
+		// Let the update surface the build error
+		return true
+	}
+	if desiredHpa.Annotations[util.AnnotationResourceHash] != currentHpa.Annotations[util.AnnotationResourceHash] {
+		zap.S().Infow("Horizontal pod autoscaler hash diff detected, so will perform autoscaler update", "app name", app.Name)
+		return true
+	}
+	return false
+}
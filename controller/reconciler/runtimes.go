@@ -0,0 +1,48 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// EnqueueAllApps returns map function that reconciles every TinyApp in namespace, so that changes to app runtimes
+// ConfigMap roll out to apps that are run by them.
+func EnqueueAllApps(c client.Reader, namespace string) handler.MapFunc {
+	return func(_ client.Object) []reconcile.Request {
+		apps := &v1alpha1.TinyAppList{}
+		if err := c.List(context.Background(), apps, client.InNamespace(namespace)); err != nil {
+			zap.S().Errorw("Failed to list TinyApps to reconcile", "error", err)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(apps.Items))
+		for _, app := range apps.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: app.Namespace, Name: app.Name},
+			})
+		}
+		return requests
+	}
+}
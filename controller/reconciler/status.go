@@ -0,0 +1,278 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// How often to check on app while its rollout is in progress
+	rolloutRequeueInterval = 5 * time.Second
+	// How often to check on app while its pods are failing, in case they recover
+	podFailureRequeueInterval = 30 * time.Second
+	// Max length of container termination message copied into condition message
+	maxConditionMessageLength = 512
+)
+
+// Log lines that indicate app dependencies failed to install
+var dependencyInstallFailureMarkers = []string{
+	"pip install",
+	"requirements.txt",
+	"No matching distribution found",
+	"Could not find a version that satisfies the requirement",
+}
+
+// podFailure is a reason why app pods can't run.
+type podFailure struct {
+	reason  string
+	message string
+}
+
+// updateRolloutStatus sets Available, Progressing & SourceSynced conditions based on app deployment & pods.
+// Returns how long to wait before status should be checked again, or zero if rollout has settled.
+func (r *reconciler) updateRolloutStatus(ctx context.Context, app *v1alpha1.TinyApp, deployment *appsv1.Deployment) (time.Duration, error) {
+	pods, err := r.k8sClient.CoreV1().Pods(r.env.TinyAppNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	})
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to list app pods")
+	}
+
+	setSourceSyncedCondition(app, pods.Items)
+	setGitCommit(app, pods.Items)
+
+	failure := findPodFailure(pods.Items)
+	desiredReplicas := getDesiredReplicas(deployment)
+
+	switch {
+	case desiredReplicas == 0:
+		app.Status.SetCondition(v1alpha1.Available, v1.ConditionFalse, v1alpha1.ReasonScaledToZero, "")
+	case deployment.Status.AvailableReplicas >= desiredReplicas:
+		app.Status.SetCondition(v1alpha1.Available, v1.ConditionTrue, v1alpha1.ReasonMinimumReplicasAvailable, "")
+	case failure != nil:
+		app.Status.SetCondition(v1alpha1.Available, v1.ConditionFalse, failure.reason, failure.message)
+	default:
+		app.Status.SetCondition(v1alpha1.Available, v1.ConditionFalse, v1alpha1.ReasonMinimumReplicasUnavailable,
+			fmt.Sprintf("%d of %d app pods available", deployment.Status.AvailableReplicas, desiredReplicas))
+	}
+
+	switch {
+	case failure != nil:
+		app.Status.SetCondition(v1alpha1.Progressing, v1.ConditionFalse, failure.reason, failure.message)
+		return podFailureRequeueInterval, nil
+	case isProgressDeadlineExceeded(deployment):
+		app.Status.SetCondition(v1alpha1.Progressing, v1.ConditionFalse, v1alpha1.ReasonProgressDeadlineExceeded,
+			"app pods did not become available in time")
+		return podFailureRequeueInterval, nil
+	case !isRolloutComplete(deployment):
+		app.Status.SetCondition(v1alpha1.Progressing, v1.ConditionTrue, v1alpha1.ReasonRolloutInProgress, "")
+		return rolloutRequeueInterval, nil
+	default:
+		app.Status.SetCondition(v1alpha1.Progressing, v1.ConditionTrue, v1alpha1.ReasonRolloutComplete, "")
+		return 0, nil
+	}
+}
+
+// setSourceSyncedCondition sets SourceSynced condition based on git-sync init container of app pods.
+// Condition is left as is if there are no pods to tell from.
+func setSourceSyncedCondition(app *v1alpha1.TinyApp, pods []corev1.Pod) {
+	switch app.Spec.SourceType {
+	case v1alpha1.SourceTypeGit:
+	case v1alpha1.SourceTypeImage:
+		app.Status.SetCondition(v1alpha1.SourceSynced, v1.ConditionTrue, v1alpha1.ReasonSourceInImage, "")
+		return
+	default:
+		app.Status.SetCondition(v1alpha1.SourceSynced, v1.ConditionTrue, v1alpha1.ReasonSourceMounted, "")
+		return
+	}
+
+	var cloneFailure *podFailure
+	for _, pod := range pods {
+		for _, status := range pod.Status.InitContainerStatuses {
+			if status.Name != util.GitSyncContainerName {
+				continue
+			}
+
+			if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode == 0 {
+				app.Status.SetCondition(v1alpha1.SourceSynced, v1.ConditionTrue, v1alpha1.ReasonGitCloned, "")
+				return
+			}
+
+			if failure := getContainerFailure(status); failure != nil {
+				cloneFailure = &podFailure{reason: v1alpha1.ReasonGitCloneFailed, message: failure.message}
+			}
+		}
+	}
+
+	if cloneFailure != nil {
+		app.Status.SetCondition(v1alpha1.SourceSynced, v1.ConditionFalse, cloneFailure.reason, cloneFailure.message)
+	}
+}
+
+// setGitCommit sets commit checked out by the newest app pod, as recorded by its git-revision init container.
+// Commit is left as is if there are no pods to tell from.
+func setGitCommit(app *v1alpha1.TinyApp, pods []corev1.Pod) {
+	if app.Spec.SourceType != v1alpha1.SourceTypeGit {
+		app.Status.GitCommit = ""
+		return
+	}
+
+	var newestPod *corev1.Pod
+	var commit string
+	for i, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		for _, status := range pod.Status.InitContainerStatuses {
+			terminated := status.State.Terminated
+			if status.Name != util.GitRevisionContainerName || terminated == nil || terminated.ExitCode != 0 {
+				continue
+			}
+
+			if newestPod == nil || newestPod.CreationTimestamp.Before(&pod.CreationTimestamp) {
+				newestPod = &pods[i]
+				commit = strings.TrimSpace(terminated.Message)
+			}
+		}
+	}
+
+	if commit != "" {
+		app.Status.GitCommit = commit
+	}
+}
+
+// findPodFailure returns the first reason found why app pods can't run, or nil if pods are fine.
+func findPodFailure(pods []corev1.Pod) *podFailure {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		for _, status := range pod.Status.InitContainerStatuses {
+			failure := getContainerFailure(status)
+			if failure == nil {
+				continue
+			}
+
+			if status.Name == util.GitSyncContainerName && failure.reason != v1alpha1.ReasonImagePullBackOff {
+				failure.reason = v1alpha1.ReasonGitCloneFailed
+			}
+			return failure
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			if failure := getContainerFailure(status); failure != nil {
+				return failure
+			}
+		}
+	}
+
+	return nil
+}
+
+// getContainerFailure returns why container can't run, or nil if it is running, starting or completed fine.
+func getContainerFailure(status corev1.ContainerStatus) *podFailure {
+	if waiting := status.State.Waiting; waiting != nil {
+		switch waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+			return &podFailure{reason: v1alpha1.ReasonImagePullBackOff, message: waiting.Message}
+		case "CreateContainerConfigError":
+			return &podFailure{reason: v1alpha1.ReasonContainerConfigError, message: waiting.Message}
+		}
+	}
+
+	// Container that is running again is not failing, even if it was terminated before
+	terminated := status.State.Terminated
+	if terminated == nil && status.State.Waiting != nil {
+		terminated = status.LastTerminationState.Terminated
+	}
+	if terminated == nil || (terminated.ExitCode == 0 && terminated.Reason != "OOMKilled") {
+		return nil
+	}
+
+	message := trimConditionMessage(terminated.Message)
+	switch {
+	case terminated.Reason == "OOMKilled":
+		return &podFailure{reason: v1alpha1.ReasonOOMKilled, message: fmt.Sprintf("container %s ran out of memory", status.Name)}
+	case isDependencyInstallFailure(terminated.Message):
+		return &podFailure{reason: v1alpha1.ReasonDependencyInstallFailed, message: message}
+	default:
+		if message == "" {
+			message = fmt.Sprintf("container %s exited with code %d", status.Name, terminated.ExitCode)
+		}
+		return &podFailure{reason: v1alpha1.ReasonCrashLoopBackOff, message: message}
+	}
+}
+
+func isDependencyInstallFailure(message string) bool {
+	for _, marker := range dependencyInstallFailureMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// trimConditionMessage keeps the end of a container termination message, which usually holds the actual error.
+func trimConditionMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if len(message) > maxConditionMessageLength {
+		message = "..." + message[len(message)-maxConditionMessageLength:]
+	}
+	return message
+}
+
+func getDesiredReplicas(deployment *appsv1.Deployment) int32 {
+	if deployment.Spec.Replicas == nil {
+		return 1
+	}
+	return *deployment.Spec.Replicas
+}
+
+// isRolloutComplete returns true if all desired pods have been updated to latest deployment spec and are available.
+func isRolloutComplete(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+
+	desiredReplicas := getDesiredReplicas(deployment)
+	return deployment.Status.UpdatedReplicas == desiredReplicas &&
+		deployment.Status.Replicas == desiredReplicas &&
+		deployment.Status.AvailableReplicas == desiredReplicas
+}
+
+func isProgressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	for _, c := range deployment.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing {
+			return c.Status == corev1.ConditionFalse && c.Reason == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
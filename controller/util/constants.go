@@ -24,12 +24,11 @@ const (
 	AnnotationResourceHash = "resource-hash"
 )
 
+// CleanupFinalizer keeps TinyApp around until controller cleans up resources it doesn't own, such as git token secret.
+const CleanupFinalizer = "tinymultiverse.ai/cleanup"
+
 // Resource limits
 const (
-	AppCPURequest                 = "50m"
-	AppCPULimit                   = "1"
-	AppMemoryRequest              = "256Mi"
-	AppMemoryLimit                = "3Gi"
 	GatewayContainerCPURequest    = "50m"
 	GatewayContainerCPULimit      = "250m"
 	GatewayContainerMemoryRequest = "64Mi"
@@ -41,14 +40,25 @@ const (
 )
 
 const (
-	AppContainerName     = "app"
-	GitSyncContainerName = "git-sync"
-	GatewayContainerName = "reverse-proxy"
+	AppContainerName         = "app"
+	GitSyncContainerName     = "git-sync"
+	GitRevisionContainerName = "git-revision"
+	GatewayContainerName     = "reverse-proxy"
+)
+
+// Keys of secret that holds gateway OIDC client credentials
+const (
+	GatewayOIDCClientSecretKey  = "client-secret"
+	GatewayOIDCSessionSecretKey = "session-secret"
+)
+
+const (
+	DefaultAppPort int32 = 5000
 )
 
 const (
-	DefaultGatewayPort int32 = 8080
-	DefaultAppPort           = "5000"
+	DefaultAppReplicas                    int32 = 1
+	DefaultTargetCPUUtilizationPercentage int32 = 80
 )
 
 const (
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/defaulting"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DefaultPath is where TinyApp defaulting webhook is served.
+const DefaultPath = "/default-tinyapp"
+
+// defaulter fills empty TinyApp fields, so that apps created with kubectl get the same defaults as those created
+// through tinyapp-server.
+type defaulter struct {
+	decoder  *admission.Decoder
+	defaults defaulting.Defaults
+}
+
+func NewDefaulter(scheme *runtime.Scheme, env internal.EnvVars) (admission.Handler, error) {
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return &defaulter{decoder: decoder, defaults: BuildDefaults(env)}, nil
+}
+
+// BuildDefaults returns TinyApp defaults configured for controller.
+func BuildDefaults(env internal.EnvVars) defaulting.Defaults {
+	return defaulting.Defaults{
+		Image:              env.DefaultAppImage,
+		IngressDomain:      env.AppIngressDomain,
+		IngressSubPath:     env.AppIngressSubPath,
+		IngressTlsEnabled:  env.AppIngressTlsEnabled,
+		GitTokenSecretName: env.DefaultGitTokenSecret,
+		CPURequest:         env.DefaultAppCPURequest,
+		CPULimit:           env.DefaultAppCPULimit,
+		MemoryRequest:      env.DefaultAppMemoryRequest,
+		MemoryLimit:        env.DefaultAppMemoryLimit,
+	}
+}
+
+func (d *defaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	app := &v1alpha1.TinyApp{}
+	if err := d.decoder.Decode(req, app); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if app.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+
+	// Name isn't set yet for apps created with generateName
+	if app.Name == "" {
+		app.Name = req.Name
+	}
+
+	defaulting.SetDefaults(app, d.defaults)
+
+	defaulted, err := json.Marshal(app)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
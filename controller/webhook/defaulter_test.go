@@ -0,0 +1,148 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/controller/reconciler/builder"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/defaulting"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/utils/pointer"
+)
+
+func newTestDefaulterEnv() internal.EnvVars {
+	return internal.EnvVars{
+		TinyAppNamespace:        "tinyapp",
+		AppServiceAccount:       "default",
+		GitSyncImage:            "registry.k8s.io/git-sync/git-sync:v3.6.8",
+		GatewayImage:            "tinyapp-gateway:latest",
+		GatewayMetricsPort:      "9090",
+		DefaultAppImage:         "python:3.11",
+		DefaultGitTokenSecret:   "git-token",
+		DefaultAppCPURequest:    "50m",
+		DefaultAppCPULimit:      "1",
+		DefaultAppMemoryRequest: "256Mi",
+		DefaultAppMemoryLimit:   "3Gi",
+	}
+}
+
+func TestDefaulter(t *testing.T) {
+	env := newTestDefaulterEnv()
+	handler, err := NewDefaulter(newTestScheme(t), env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := newTestApp(func(app *v1alpha1.TinyApp) {
+		app.Spec.GitConfig.TokenSecretName = ""
+		app.Spec.Resources = nil
+	})
+	resp := handler.Handle(context.Background(), newTestRequest(t, admissionv1.Create, app, nil))
+	if !resp.Allowed || len(resp.Patches) == 0 {
+		t.Fatalf("got allowed %v & patches %v, want defaults patched", resp.Allowed, resp.Patches)
+	}
+
+	// Defaulting app that was defaulted before patches nothing
+	defaulted := app.DeepCopy()
+	defaulting.SetDefaults(defaulted, BuildDefaults(env))
+	resp = handler.Handle(context.Background(), newTestRequest(t, admissionv1.Update, defaulted, app))
+	if !resp.Allowed || len(resp.Patches) > 0 {
+		t.Errorf("got allowed %v & patches %v, want no patches", resp.Allowed, resp.Patches)
+	}
+}
+
+// Defaults stored by defaulting webhook must be the ones controller assumes for empty fields, so that defaulting an
+// existing app doesn't change its pods.
+func TestDefaultsMatchBuilder(t *testing.T) {
+	specs := []struct {
+		name   string
+		change func(app *v1alpha1.TinyApp)
+	}{
+		{name: "no resources & replicas", change: func(app *v1alpha1.TinyApp) { app.Spec.Resources = nil }},
+		{name: "requests", change: func(app *v1alpha1.TinyApp) {
+			app.Spec.Resources = &v1alpha1.AppResources{CPURequest: "500m", MemoryRequest: "1Gi"}
+		}},
+		{name: "requests above default limits", change: func(app *v1alpha1.TinyApp) {
+			app.Spec.Resources = &v1alpha1.AppResources{CPURequest: "2", MemoryRequest: "4Gi"}
+		}},
+		{name: "limits", change: func(app *v1alpha1.TinyApp) {
+			app.Spec.Resources = &v1alpha1.AppResources{CPULimit: "2", MemoryLimit: "1Gi"}
+		}},
+		{name: "limits below default requests", change: func(app *v1alpha1.TinyApp) {
+			app.Spec.Resources = &v1alpha1.AppResources{CPULimit: "10m", MemoryLimit: "128Mi"}
+		}},
+		{name: "replicas", change: func(app *v1alpha1.TinyApp) { app.Spec.Replicas = pointer.Int32(3) }},
+		{name: "autoscaling", change: func(app *v1alpha1.TinyApp) {
+			app.Spec.Autoscaling = &v1alpha1.AutoscalingPolicy{MaxReplicas: 3}
+		}},
+	}
+
+	env := newTestDefaulterEnv()
+	runtimes := builder.NewRuntimeRegistry(nil, env.TinyAppNamespace, "")
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			app := newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Spec.Image = "python:3.11"
+				spec.change(app)
+			})
+			defaulted := app.DeepCopy()
+			defaulting.SetDefaults(defaulted, BuildDefaults(env))
+
+			runtime, err := runtimes.RuntimeFor(app)
+			if err != nil {
+				t.Fatal(err)
+			}
+			deployment, err := builder.BuildDeployment(app, env, runtime)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defaultedDeployment, err := builder.BuildDeployment(defaulted, env, runtime)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := *defaultedDeployment.Spec.Replicas, *deployment.Spec.Replicas; got != want {
+				t.Errorf("got replicas %d, want %d", got, want)
+			}
+			got, want := defaultedDeployment.Spec.Template.Spec.Containers[0], deployment.Spec.Template.Spec.Containers[0]
+			if !reflect.DeepEqual(got.Resources, want.Resources) {
+				t.Errorf("got resources %+v, want %+v", got.Resources, want.Resources)
+			}
+
+			if app.Spec.Autoscaling == nil {
+				return
+			}
+			hpa, err := builder.BuildHorizontalPodAutoscaler(app, env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defaultedHPA, err := builder.BuildHorizontalPodAutoscaler(defaulted, env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(defaultedHPA.Spec, hpa.Spec) {
+				t.Errorf("got autoscaler %+v, want %+v", defaultedHPA.Spec, hpa.Spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/controller/reconciler/builder"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/validation"
+
+	"go.uber.org/zap"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ValidatePath is where TinyApp validating webhook is served.
+const ValidatePath = "/validate-tinyapp"
+
+// validator rejects TinyApps that controller can't deploy.
+type validator struct {
+	decoder  *admission.Decoder
+	runtimes *builder.RuntimeRegistry
+	env      internal.EnvVars
+}
+
+func NewValidator(scheme *runtime.Scheme, runtimes *builder.RuntimeRegistry, env internal.EnvVars) (admission.Handler, error) {
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validator{decoder: decoder, runtimes: runtimes, env: env}, nil
+}
+
+func (v *validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	app := &v1alpha1.TinyApp{}
+	if err := v.decoder.Decode(req, app); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Let apps that are being deleted go, so that their finalizers can be removed
+	if app.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+
+	// Apps that became invalid, e.g. because maximums were lowered or runtime was removed, can still have their
+	// metadata & status updated as long as their spec is left as it is
+	if req.Operation == admissionv1.Update {
+		oldApp := &v1alpha1.TinyApp{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldApp); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(oldApp.Spec, app.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	// Validate against runtimes loaded before, if they can't be refreshed
+	if err := v.runtimes.Refresh(ctx); err != nil {
+		zap.S().Errorw("Failed to refresh app runtimes", "error", err)
+	}
+
+	errs := validation.ValidateTinyApp(app, v.runtimes.AppTypes())
+	errs = append(errs, validation.ValidateResourceMaximums(&app.Spec, field.NewPath("spec"), v.env.MaxAppCPU, v.env.MaxAppMemory)...)
+	if len(errs) == 0 {
+		return admission.Allowed("")
+	}
+
+	zap.S().Infow("Rejected invalid TinyApp", "name", app.Name, "operation", req.Operation, "errors", errs.ToAggregate().Error())
+
+	status := k8sErrors.NewInvalid(v1alpha1.Kind("TinyApp"), app.Name, errs).ErrStatus
+	return admission.Response{
+		AdmissionResponse: admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		},
+	}
+}
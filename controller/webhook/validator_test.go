@@ -0,0 +1,216 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/controller/internal"
+	"github.com/tinymultiverse/tinyapp/controller/reconciler/builder"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+// newTestApp returns valid Streamlit app from git, changed by given function.
+func newTestApp(change func(app *v1alpha1.TinyApp)) *v1alpha1.TinyApp {
+	app := &v1alpha1.TinyApp{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "TinyApp"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "tinyapp"},
+		Spec: v1alpha1.TinyAppSpec{
+			AppType:      v1alpha1.AppTypeStreamlit,
+			SourceType:   v1alpha1.SourceTypeGit,
+			MainFilePath: "app.py",
+			GitConfig: &v1alpha1.GitConfig{
+				GitUrl:          "https://github.com/org/repo.git",
+				RefType:         v1alpha1.GitRefTypeBranch,
+				GitRef:          "main",
+				TokenSecretName: "git-token",
+			},
+			Resources: &v1alpha1.AppResources{CPULimit: "1", MemoryLimit: "1Gi"},
+		},
+	}
+	if change != nil {
+		change(app)
+	}
+	return app
+}
+
+func newTestRequest(t *testing.T, operation admissionv1.Operation, app, oldApp *v1alpha1.TinyApp) admission.Request {
+	t.Helper()
+
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       "test",
+		Operation: operation,
+		Name:      app.Name,
+		Namespace: app.Namespace,
+	}}
+
+	var err error
+	if req.Object.Raw, err = json.Marshal(app); err != nil {
+		t.Fatal(err)
+	}
+	if oldApp != nil {
+		if req.OldObject.Raw, err = json.Marshal(oldApp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return req
+}
+
+func TestValidator(t *testing.T) {
+	invalidGitURL := func(app *v1alpha1.TinyApp) { app.Spec.GitConfig.GitUrl = "git@github.com:org/repo.git" }
+	// Valid when app was created, before max CPU was lowered to 2
+	aboveMaxCPU := func(app *v1alpha1.TinyApp) { app.Spec.Resources.CPULimit = "4" }
+
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		app         *v1alpha1.TinyApp
+		oldApp      *v1alpha1.TinyApp
+		wantAllowed bool
+		wantCauses  []metav1.StatusCause
+	}{
+		{
+			name:        "create valid app",
+			operation:   admissionv1.Create,
+			app:         newTestApp(nil),
+			wantAllowed: true,
+		},
+		{
+			name:      "create invalid app",
+			operation: admissionv1.Create,
+			app: newTestApp(func(app *v1alpha1.TinyApp) {
+				invalidGitURL(app)
+				app.Spec.AppType = "Flask"
+			}),
+			wantCauses: []metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldValueNotSupported, Field: "spec.appType"},
+				{Type: metav1.CauseTypeFieldValueInvalid, Field: "spec.gitConfig.gitUrl"},
+			},
+		},
+		{
+			name:       "create app above maximums",
+			operation:  admissionv1.Create,
+			app:        newTestApp(aboveMaxCPU),
+			wantCauses: []metav1.StatusCause{{Type: metav1.CauseTypeFieldValueInvalid, Field: "spec.resources.cpuLimit"}},
+		},
+		{
+			name:      "create app being deleted",
+			operation: admissionv1.Create,
+			app: newTestApp(func(app *v1alpha1.TinyApp) {
+				invalidGitURL(app)
+				now := metav1.Now()
+				app.DeletionTimestamp = &now
+			}),
+			wantAllowed: true,
+		},
+		{
+			name:        "delete invalid app",
+			operation:   admissionv1.Delete,
+			app:         newTestApp(invalidGitURL),
+			wantAllowed: true,
+		},
+		{
+			name:        "update valid app",
+			operation:   admissionv1.Update,
+			app:         newTestApp(func(app *v1alpha1.TinyApp) { app.Spec.GitConfig.GitRef = "release" }),
+			oldApp:      newTestApp(nil),
+			wantAllowed: true,
+		},
+		{
+			name:       "update valid app to invalid",
+			operation:  admissionv1.Update,
+			app:        newTestApp(invalidGitURL),
+			oldApp:     newTestApp(nil),
+			wantCauses: []metav1.StatusCause{{Type: metav1.CauseTypeFieldValueInvalid, Field: "spec.gitConfig.gitUrl"}},
+		},
+		{
+			name:      "update metadata of invalid app",
+			operation: admissionv1.Update,
+			app: newTestApp(func(app *v1alpha1.TinyApp) {
+				aboveMaxCPU(app)
+				app.Labels = map[string]string{"team": "data"}
+			}),
+			oldApp:      newTestApp(aboveMaxCPU),
+			wantAllowed: true,
+		},
+		{
+			name:      "update spec of invalid app",
+			operation: admissionv1.Update,
+			app: newTestApp(func(app *v1alpha1.TinyApp) {
+				aboveMaxCPU(app)
+				app.Spec.GitConfig.GitRef = "release"
+			}),
+			oldApp:     newTestApp(aboveMaxCPU),
+			wantCauses: []metav1.StatusCause{{Type: metav1.CauseTypeFieldValueInvalid, Field: "spec.resources.cpuLimit"}},
+		},
+		{
+			name:        "update invalid app to valid",
+			operation:   admissionv1.Update,
+			app:         newTestApp(nil),
+			oldApp:      newTestApp(aboveMaxCPU),
+			wantAllowed: true,
+		},
+	}
+
+	env := internal.EnvVars{MaxAppCPU: "2", MaxAppMemory: "8Gi"}
+	handler, err := NewValidator(newTestScheme(t), builder.NewRuntimeRegistry(nil, "tinyapp", ""), env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := handler.Handle(context.Background(), newTestRequest(t, test.operation, test.app, test.oldApp))
+			if resp.Allowed != test.wantAllowed {
+				t.Fatalf("got allowed %v, want %v (%v)", resp.Allowed, test.wantAllowed, resp.Result)
+			}
+			if test.wantAllowed {
+				return
+			}
+
+			if resp.Result == nil || resp.Result.Reason != metav1.StatusReasonInvalid || resp.Result.Details == nil {
+				t.Fatalf("got result %v, want invalid status with details", resp.Result)
+			}
+			causes := resp.Result.Details.Causes
+			if len(causes) != len(test.wantCauses) {
+				t.Fatalf("got causes %v, want %v", causes, test.wantCauses)
+			}
+			for i, cause := range causes {
+				if cause.Type != test.wantCauses[i].Type || cause.Field != test.wantCauses[i].Field {
+					t.Errorf("got cause %v, want %v", cause, test.wantCauses[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/gateway/internal"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/client/tinyapp/clientset/versioned"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	"go.uber.org/zap"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	readyPollInterval = 500 * time.Millisecond
+	// Seconds for waking up page to wait before retrying
+	retryAfterSeconds = 3
+)
+
+var wakingUpPage = template.Must(template.New("waking-up").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.RetryAfter}}">
+<title>Waking up {{.AppName}}</title>
+</head>
+<body style="font-family: sans-serif; text-align: center; margin-top: 20vh;">
+<h2>Waking up app...</h2>
+<p>This app was asleep due to inactivity. This page will refresh once it is ready.</p>
+</body>
+</html>
+`))
+
+// activator receives requests for sleeping apps, wakes them up and proxies requests once they are ready.
+type activator struct {
+	tinyAppClient versioned.Interface
+	k8sClient     kubernetes.Interface
+	env           internal.EnvVars
+	// transport of app proxies. Default transport is used if nil.
+	transport http.RoundTripper
+}
+
+func NewActivator(env internal.EnvVars) (*activator, error) {
+	if env.TinyAppNamespace == "" {
+		return nil, errors.New("TINY_APP_NAMESPACE must be set in activator mode")
+	}
+
+	kubeConfig, err := globalutil.GetKubeConfig(env.KubeConfigPath)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get Kubernetes configuration")
+	}
+
+	tinyAppClient, err := versioned.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to make Kubernetes interface for TinyApp")
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create k8s client")
+	}
+
+	return &activator{
+		tinyAppClient: tinyAppClient,
+		k8sClient:     k8sClient,
+		env:           env,
+	}, nil
+}
+
+func (a *activator) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	appName := a.getAppName(req.URL.Path)
+	logger := zap.S().With("app", appName)
+	logger.Infow("got a request for app routed to activator", "method", req.Method, "requestURL", req.URL.String())
+
+	if appName == "" {
+		http.NotFound(res, req)
+		return
+	}
+
+	app, err := a.tinyAppClient.TinymultiverseV1alpha1().TinyApps(a.env.TinyAppNamespace).Get(req.Context(), appName, metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			http.NotFound(res, req)
+			return
+		}
+		logger.Errorw("Failed to get TinyApp", "error", err)
+		http.Error(res, "failed to get app", http.StatusInternalServerError)
+		return
+	}
+
+	if app.Status.IsSleeping() {
+		if err := a.requestWakeUp(req.Context(), app); err != nil {
+			logger.Errorw("Failed to request app wake up", "error", err)
+			http.Error(res, "failed to wake up app", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if !a.waitUntilReady(req.Context(), appName) {
+		logger.Info("App is not ready yet, serving waking up page")
+		a.serveWakingUp(res, req, appName)
+		return
+	}
+
+	a.buildAppProxy(appName).ServeHTTP(res, req)
+}
+
+// getAppName returns name of the app given request path is for.
+// App ingress paths are /<sub path>/<app name>/...
+func (a *activator) getAppName(path string) string {
+	subPath := "/" + strings.Trim(a.env.AppIngressSubPath, "/")
+	path = strings.TrimPrefix(path, subPath)
+
+	appName, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return appName
+}
+
+// requestWakeUp annotates app so that the controller scales it back up.
+// Does nothing if wake up has already been requested since app went to sleep.
+func (a *activator) requestWakeUp(ctx context.Context, app *v1alpha1.TinyApp) error {
+	if requestedAt, err := time.Parse(time.RFC3339, app.Annotations[globalutil.AnnotationWakeRequestedAt]); err == nil &&
+		!requestedAt.Before(app.Status.SleepingSince.Time) {
+		return nil
+	}
+
+	zap.S().Infow("Requesting app wake up", "app", app.Name)
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`,
+		globalutil.AnnotationWakeRequestedAt, time.Now().UTC().Format(time.RFC3339))
+	_, err := a.tinyAppClient.TinymultiverseV1alpha1().TinyApps(a.env.TinyAppNamespace).
+		Patch(ctx, app.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+
+	return err
+}
+
+// waitUntilReady waits up to hold timeout for app to have a ready pod.
+func (a *activator) waitUntilReady(ctx context.Context, appName string) bool {
+	ctx, cancel := context.WithTimeout(ctx, a.env.WakeUpHoldTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if a.isReady(ctx, appName) {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// isReady returns true if app service has at least one ready endpoint.
+func (a *activator) isReady(ctx context.Context, appName string) bool {
+	endpoints, err := a.k8sClient.CoreV1().Endpoints(a.env.TinyAppNamespace).Get(ctx, appName, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildAppProxy returns reverse proxy to app service.
+func (a *activator) buildAppProxy(appName string) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("%s.%s.svc:%d", appName, a.env.TinyAppNamespace, globalutil.DefaultGatewayPort),
+	})
+	proxy.Transport = a.transport
+	return proxy
+}
+
+// serveWakingUp responds with a page that refreshes until app is ready, or just asks to retry for non-browser clients.
+func (a *activator) serveWakingUp(res http.ResponseWriter, req *http.Request, appName string) {
+	res.Header().Set("Retry-After", fmt.Sprint(retryAfterSeconds))
+
+	if !strings.Contains(req.Header.Get("Accept"), "text/html") {
+		http.Error(res, "app is waking up", http.StatusServiceUnavailable)
+		return
+	}
+
+	res.Header().Set("Content-Type", "text/html; charset=utf-8")
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(http.StatusServiceUnavailable)
+
+	err := wakingUpPage.Execute(res, struct {
+		AppName    string
+		RetryAfter int
+	}{appName, retryAfterSeconds})
+	if err != nil {
+		zap.S().Errorw("Failed to write waking up page", "error", err)
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/gateway/internal"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/client/tinyapp/clientset/versioned/fake"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "tinyapp"
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestActivator returns activator holding requests for up to holdTimeout, whose app proxies send requests to
+// backend. Hosts that requests were proxied to are sent to proxiedHosts.
+func newTestActivator(t *testing.T, holdTimeout time.Duration, proxiedHosts chan<- string, apps ...*v1alpha1.TinyApp) *activator {
+	backend := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(res, "app response to %s", req.URL.Path)
+	}))
+	t.Cleanup(backend.Close)
+	backendURL, err := url.Parse(backend.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tinyAppClient := fake.NewSimpleClientset()
+	for _, app := range apps {
+		if _, err := tinyAppClient.TinymultiverseV1alpha1().TinyApps(testNamespace).Create(context.Background(), app, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &activator{
+		tinyAppClient: tinyAppClient,
+		k8sClient:     fakekubernetes.NewSimpleClientset(),
+		env: internal.EnvVars{
+			TinyAppNamespace:  testNamespace,
+			AppIngressSubPath: "apps",
+			WakeUpHoldTimeout: holdTimeout,
+		},
+		transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			proxiedHosts <- req.URL.Host
+			req.URL.Host = backendURL.Host
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+}
+
+func newTestSleepingApp(name string, sleepingSince time.Time) *v1alpha1.TinyApp {
+	return &v1alpha1.TinyApp{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Status:     v1alpha1.TinyAppStatus{SleepingSince: &metav1.Time{Time: sleepingSince}},
+	}
+}
+
+// createReadyEndpoints marks app service as having a ready pod.
+func createReadyEndpoints(t *testing.T, a *activator, appName string) {
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: appName, Namespace: testNamespace},
+		Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+	}
+	if _, err := a.k8sClient.CoreV1().Endpoints(testNamespace).Create(context.Background(), endpoints, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getWakeRequestedAt(t *testing.T, a *activator, appName string) string {
+	app, err := a.tinyAppClient.TinymultiverseV1alpha1().TinyApps(testNamespace).Get(context.Background(), appName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return app.Annotations[globalutil.AnnotationWakeRequestedAt]
+}
+
+// Request for a sleeping app requests wake up, is held until app is ready and then proxied to app.
+func TestActivatorWakesUpAndProxies(t *testing.T) {
+	proxiedHosts := make(chan string, 1)
+	a := newTestActivator(t, 10*time.Second, proxiedHosts, newTestSleepingApp("abc", time.Now().Add(-time.Minute)))
+
+	res := httptest.NewRecorder()
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		a.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/apps/abc/page", nil))
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for getWakeRequestedAt(t, a, "abc") == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("wake up was not requested")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case <-served:
+		t.Fatalf("request served with status %d before app was ready", res.Code)
+	case <-time.After(2 * readyPollInterval):
+	}
+
+	createReadyEndpoints(t, a, "abc")
+	select {
+	case <-served:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request not served after app became ready")
+	}
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", res.Code, http.StatusOK)
+	}
+	if got, want := res.Body.String(), "app response to /apps/abc/page"; got != want {
+		t.Errorf("got body %q, want %q", got, want)
+	}
+	if got, want := <-proxiedHosts, fmt.Sprintf("abc.%s.svc:%d", testNamespace, globalutil.DefaultGatewayPort); got != want {
+		t.Errorf("got request proxied to %s, want %s", got, want)
+	}
+}
+
+// Requests are served a waking up page if app does not get ready within hold timeout.
+func TestActivatorHoldTimeout(t *testing.T) {
+	a := newTestActivator(t, 100*time.Millisecond, make(chan string, 1), newTestSleepingApp("abc", time.Now().Add(-time.Minute)))
+
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "browser", accept: "text/html,application/xhtml+xml", wantContentType: "text/html", wantBody: "Waking up app"},
+		{name: "api client", accept: "application/json", wantContentType: "text/plain", wantBody: "app is waking up"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/apps/abc/", nil)
+			req.Header.Set("Accept", test.accept)
+			res := httptest.NewRecorder()
+			a.ServeHTTP(res, req)
+
+			if res.Code != http.StatusServiceUnavailable {
+				t.Errorf("got status %d, want %d", res.Code, http.StatusServiceUnavailable)
+			}
+			if got := res.Header().Get("Retry-After"); got != fmt.Sprint(retryAfterSeconds) {
+				t.Errorf("got Retry-After %q, want %d", got, retryAfterSeconds)
+			}
+			if got := res.Header().Get("Content-Type"); !strings.HasPrefix(got, test.wantContentType) {
+				t.Errorf("got content type %q, want %s", got, test.wantContentType)
+			}
+			if body, _ := io.ReadAll(res.Body); !strings.Contains(string(body), test.wantBody) {
+				t.Errorf("got body %q, want it to contain %q", body, test.wantBody)
+			}
+		})
+	}
+
+	if getWakeRequestedAt(t, a, "abc") == "" {
+		t.Error("wake up was not requested")
+	}
+}
+
+// Requests for apps that are already awake are proxied without requesting wake up.
+func TestActivatorProxiesReadyApp(t *testing.T) {
+	app := newTestSleepingApp("abc", time.Time{})
+	app.Status.SleepingSince = nil
+	proxiedHosts := make(chan string, 1)
+	a := newTestActivator(t, time.Second, proxiedHosts, app)
+	createReadyEndpoints(t, a, "abc")
+
+	res := httptest.NewRecorder()
+	a.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/apps/abc/", nil))
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", res.Code, http.StatusOK)
+	}
+	<-proxiedHosts
+	if got := getWakeRequestedAt(t, a, "abc"); got != "" {
+		t.Errorf("got wake up requested at %s for awake app", got)
+	}
+}
+
+func TestActivatorNotFound(t *testing.T) {
+	a := newTestActivator(t, time.Second, make(chan string, 1))
+
+	for _, path := range []string{"/apps/abc/", "/apps/", "/"} {
+		res := httptest.NewRecorder()
+		a.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		if res.Code != http.StatusNotFound {
+			t.Errorf("got status %d for %s, want %d", res.Code, path, http.StatusNotFound)
+		}
+	}
+}
+
+// Wake up is requested once per sleep.
+func TestRequestWakeUp(t *testing.T) {
+	sleepingSince := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name             string
+		wakeRequestedAt  time.Time
+		wantPatchedAgain bool
+	}{
+		{name: "never requested", wantPatchedAgain: true},
+		{name: "requested before app went to sleep", wakeRequestedAt: sleepingSince.Add(-time.Minute), wantPatchedAgain: true},
+		{name: "requested since app went to sleep", wakeRequestedAt: sleepingSince.Add(time.Minute)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := newTestSleepingApp("abc", sleepingSince)
+			wantRequestedAt := ""
+			if !test.wakeRequestedAt.IsZero() {
+				wantRequestedAt = test.wakeRequestedAt.Format(time.RFC3339)
+				app.Annotations = map[string]string{globalutil.AnnotationWakeRequestedAt: wantRequestedAt}
+			}
+			a := newTestActivator(t, time.Second, make(chan string, 1), app)
+
+			if err := a.requestWakeUp(context.Background(), app); err != nil {
+				t.Fatal(err)
+			}
+
+			got := getWakeRequestedAt(t, a, "abc")
+			if patched := got != wantRequestedAt; patched != test.wantPatchedAgain {
+				t.Fatalf("got wake up requested at %q, want patched %t", got, test.wantPatchedAgain)
+			}
+			if requestedAt, err := time.Parse(time.RFC3339, got); err != nil || requestedAt.Before(sleepingSince) {
+				t.Errorf("got wake up requested at %q, want time after app went to sleep", got)
+			}
+		})
+	}
+}
+
+func TestGetAppName(t *testing.T) {
+	tests := []struct {
+		subPath string
+		path    string
+		want    string
+	}{
+		{subPath: "apps", path: "/apps/abc/page", want: "abc"},
+		{subPath: "/apps/", path: "/apps/abc", want: "abc"},
+		{subPath: "", path: "/abc/", want: "abc"},
+		{subPath: "apps", path: "/apps/", want: ""},
+	}
+
+	for _, test := range tests {
+		a := &activator{env: internal.EnvVars{AppIngressSubPath: test.subPath}}
+		if got := a.getAppName(test.path); got != test.want {
+			t.Errorf("got app name %q for path %s with sub path %q, want %q", got, test.path, test.subPath, test.want)
+		}
+	}
+}
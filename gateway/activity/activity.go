@@ -0,0 +1,68 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activity
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/util"
+)
+
+// Tracker records app traffic so the controller can tell when app is idle.
+type Tracker struct {
+	mu       sync.Mutex
+	activity util.GatewayActivity
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// RequestStarted records start of a request. Must be followed by RequestDone once request is served.
+func (t *Tracker) RequestStarted() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.activity.LastRequestTime = time.Now()
+	t.activity.ActiveRequests++
+}
+
+// RequestDone records end of a request.
+func (t *Tracker) RequestDone() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.activity.LastRequestTime = time.Now()
+	t.activity.ActiveRequests--
+}
+
+// Get returns current activity.
+func (t *Tracker) Get() util.GatewayActivity {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.activity
+}
+
+// ServeHTTP serves current activity as json.
+func (t *Tracker) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(res).Encode(t.Get())
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activity
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/util"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	if got := tracker.Get(); !got.LastRequestTime.IsZero() || got.ActiveRequests != 0 {
+		t.Fatalf("got activity %+v before any request, want none", got)
+	}
+
+	start := time.Now()
+	tracker.RequestStarted()
+	tracker.RequestStarted()
+	if got := tracker.Get(); got.ActiveRequests != 2 || got.LastRequestTime.Before(start) {
+		t.Errorf("got activity %+v, want 2 active requests since %s", got, start)
+	}
+
+	tracker.RequestDone()
+	tracker.RequestDone()
+	got := tracker.Get()
+	if got.ActiveRequests != 0 || got.LastRequestTime.Before(start) {
+		t.Errorf("got activity %+v, want no active requests and last request since %s", got, start)
+	}
+
+	res := httptest.NewRecorder()
+	tracker.ServeHTTP(res, httptest.NewRequest(http.MethodGet, util.GatewayActivityPath, nil))
+	if ct := res.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("got content type %q, want application/json", ct)
+	}
+	served := util.GatewayActivity{}
+	if err := json.NewDecoder(res.Body).Decode(&served); err != nil {
+		t.Fatal(err)
+	}
+	if !served.LastRequestTime.Equal(got.LastRequestTime) || served.ActiveRequests != got.ActiveRequests {
+		t.Errorf("got served activity %+v, want %+v", served, got)
+	}
+}
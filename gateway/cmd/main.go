@@ -17,9 +17,15 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/tinymultiverse/tinyapp/gateway/activator"
+	"github.com/tinymultiverse/tinyapp/gateway/activity"
+	"github.com/tinymultiverse/tinyapp/gateway/health"
+	"github.com/tinymultiverse/tinyapp/gateway/identity"
 	"github.com/tinymultiverse/tinyapp/gateway/internal"
+	"github.com/tinymultiverse/tinyapp/gateway/login"
 	"github.com/tinymultiverse/tinyapp/gateway/proxy"
 	"github.com/tinymultiverse/tinyapp/gateway/util/metrics"
 	"github.com/tinymultiverse/tinyapp/util"
@@ -42,15 +48,51 @@ func main() {
 		zap.S().Fatalw("could not process environment variables", "error", err)
 	}
 
-	prometheus.MustRegister(metrics.UsernameCounter)
+	if envVars.Mode == internal.ModeActivator {
+		runActivator()
+		return
+	}
+
+	if envVars.TinyAppName == "" {
+		zap.S().Fatal("TINY_APP_NAME must be set in proxy mode")
+	}
+
+	prometheus.MustRegister(metrics.UsernameCounter, metrics.SessionCounter)
+	prometheus.MustRegister(metrics.HTTPCollectors()...)
+
+	resolver, err := identity.NewResolver(context.Background(), envVars)
+	if err != nil {
+		zap.S().Fatalw("failed to set up identity resolver", "error", err)
+	}
 
-	proxyConfig, err := proxy.NewProxyServerConfig(envVars)
+	tracker := activity.NewTracker()
+	proxyConfig, err := proxy.NewProxyServerConfig(envVars, tracker, resolver)
 	if err != nil {
 		zap.S().Fatalw("failed to set up proxy", "error", err)
 	}
 
+	var appHandler http.Handler = proxyConfig
+	if envVars.AuthEnabled {
+		appHandler, err = login.NewHandler(context.Background(), login.Config{
+			IssuerURL:     envVars.OIDCIssuerURL,
+			ClientID:      envVars.OIDCClientID,
+			ClientSecret:  envVars.OIDCClientSecret,
+			BasePath:      envVars.AppBasePath,
+			RedirectURL:   envVars.OIDCRedirectURL,
+			Scopes:        envVars.OIDCScopes,
+			UsernameClaim: envVars.OIDCUsernameClaim,
+			GroupsClaim:   envVars.OIDCGroupsClaim,
+			SessionSecret: envVars.SessionSecret,
+			AllowedUsers:  envVars.AllowedUsers,
+			AllowedGroups: envVars.AllowedGroups,
+		}, proxyConfig)
+		if err != nil {
+			zap.S().Fatalw("failed to set up login", "error", err)
+		}
+	}
+
 	mux := http.NewServeMux()
-	mux.Handle("/", proxyConfig)
+	mux.Handle("/", metrics.InstrumentHandler(envVars.TinyAppName, appHandler))
 	addr := ":" + envVars.HttpPort
 	go func() {
 		zap.S().Info("starting proxy gateway")
@@ -59,6 +101,20 @@ func main() {
 		}
 	}()
 
+	// Admin endpoints are served on a separate port so that they don't shadow any app path
+	adminMux := http.NewServeMux()
+	adminMux.Handle(util.GatewayActivityPath, tracker)
+	checker := health.NewChecker(buildAppHealthURL())
+	adminMux.HandleFunc(util.GatewayLivenessPath, checker.ServeLiveness)
+	adminMux.HandleFunc(util.GatewayReadinessPath, checker.ServeReadiness)
+	adminAddr := ":" + envVars.AdminPort
+	go func() {
+		zap.S().Info("starting admin server")
+		if err := http.ListenAndServe(adminAddr, adminMux); err != nil {
+			zap.S().Fatalw("could not start admin server", "error", err)
+		}
+	}()
+
 	if envVars.MetricsEnabled {
 		if envVars.MetricsPath == "" || envVars.MetricsPort == "" {
 			zap.S().Fatal("METRICS_PATH and METRICS_PORT must be set if METRICS_ENABLED is true")
@@ -80,4 +136,29 @@ func main() {
 			}
 		}
 	}
+
+	select {}
+}
+
+// buildAppHealthURL returns url that app responds at while healthy, or empty string if app has none.
+func buildAppHealthURL() string {
+	if envVars.AppHealthPath == "" {
+		return ""
+	}
+	return "http://localhost:" + envVars.AppPort + envVars.AppHealthPath
+}
+
+// runActivator serves requests for sleeping apps until they are woken up.
+func runActivator() {
+	activatorHandler, err := activator.NewActivator(envVars)
+	if err != nil {
+		zap.S().Fatalw("failed to set up activator", "error", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", activatorHandler)
+	zap.S().Info("starting activator")
+	if err := http.ListenAndServe(":"+envVars.HttpPort, mux); err != nil {
+		zap.S().Fatalw("could not start activator", "error", err)
+	}
 }
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// How long readiness check waits for app to respond
+const appCheckTimeout = 3 * time.Second
+
+// Checker serves gateway health. Gateway is only ready while the app it proxies to is healthy.
+type Checker struct {
+	appHealthURL string
+	client       *http.Client
+}
+
+// NewChecker returns checker of app that responds at given health url. Gateway is ready regardless of app if url
+// is empty.
+func NewChecker(appHealthURL string) *Checker {
+	return &Checker{
+		appHealthURL: appHealthURL,
+		client: &http.Client{
+			Timeout: appCheckTimeout,
+			// Redirects count as healthy, the same as for kubelet probes
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// ServeLiveness responds with 200 as long as gateway is serving.
+func (c *Checker) ServeLiveness(res http.ResponseWriter, _ *http.Request) {
+	_, _ = res.Write([]byte("ok"))
+}
+
+// ServeReadiness responds with 200 if app is healthy, 503 otherwise.
+func (c *Checker) ServeReadiness(res http.ResponseWriter, req *http.Request) {
+	if err := c.checkApp(req.Context()); err != nil {
+		http.Error(res, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = res.Write([]byte("ok"))
+}
+
+// checkApp returns error if app doesn't respond at its health url with 2xx or 3xx status.
+func (c *Checker) checkApp(ctx context.Context) error {
+	if c.appHealthURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.appHealthURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return errors.WithMessage(err, "app is not responding")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("app health check returned status %d", res.StatusCode)
+	}
+	return nil
+}
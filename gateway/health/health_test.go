@@ -0,0 +1,74 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChecker(t *testing.T) {
+	appStatus := http.StatusOK
+	app := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/apps/abc/_stcore/health" {
+			res.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if appStatus == http.StatusFound {
+			http.Redirect(res, req, "/apps/abc/login", appStatus)
+			return
+		}
+		res.WriteHeader(appStatus)
+	}))
+	defer app.Close()
+
+	tests := []struct {
+		name          string
+		appHealthURL  string
+		appStatus     int
+		wantReadiness int
+	}{
+		{name: "healthy app", appHealthURL: app.URL + "/apps/abc/_stcore/health", appStatus: http.StatusOK, wantReadiness: http.StatusOK},
+		{name: "redirecting app", appHealthURL: app.URL + "/apps/abc/_stcore/health", appStatus: http.StatusFound, wantReadiness: http.StatusOK},
+		{name: "failing app", appHealthURL: app.URL + "/apps/abc/_stcore/health", appStatus: http.StatusInternalServerError, wantReadiness: http.StatusServiceUnavailable},
+		{name: "wrong health path", appHealthURL: app.URL + "/_stcore/health", appStatus: http.StatusOK, wantReadiness: http.StatusServiceUnavailable},
+		// Nothing listens on port 1
+		{name: "app down", appHealthURL: "http://127.0.0.1:1/apps/abc/_stcore/health", wantReadiness: http.StatusServiceUnavailable},
+		{name: "probes disabled", appHealthURL: "", wantReadiness: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			appStatus = test.appStatus
+			checker := NewChecker(test.appHealthURL)
+
+			res := httptest.NewRecorder()
+			checker.ServeReadiness(res, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if res.Code != test.wantReadiness {
+				t.Errorf("got readiness status %d, want %d (%s)", res.Code, test.wantReadiness, res.Body.String())
+			}
+
+			// Gateway stays alive while app is down, so that it isn't restarted along with app
+			res = httptest.NewRecorder()
+			checker.ServeLiveness(res, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			if res.Code != http.StatusOK {
+				t.Errorf("got liveness status %d, want 200", res.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package identity
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/tinymultiverse/tinyapp/gateway/internal"
+	"github.com/tinymultiverse/tinyapp/util/auth"
+	"go.uber.org/zap"
+)
+
+// Headers an auth proxy in front of gateway may set with the user it authenticated
+var (
+	trustedUserHeaders   = []string{"X-Forwarded-User", "X-Auth-Request-Email", "X-Auth-Request-User"}
+	trustedGroupsHeaders = []string{"X-Forwarded-Groups", "X-Auth-Request-Groups"}
+)
+
+// Headers gateway sets on requests forwarded to app
+const (
+	ForwardedUserHeader   = "X-Forwarded-User"
+	ForwardedEmailHeader  = "X-Forwarded-Email"
+	ForwardedGroupsHeader = "X-Forwarded-Groups"
+)
+
+// Resolver finds out which user made a request.
+type Resolver struct {
+	trustHeaders bool
+	// Verifies bearer tokens. Nil if bearer tokens are not accepted.
+	authenticator auth.Authenticator
+}
+
+func NewResolver(ctx context.Context, envVars internal.EnvVars) (*Resolver, error) {
+	resolver := &Resolver{trustHeaders: envVars.TrustIdentityHeaders}
+
+	if envVars.JWTIssuerURL != "" {
+		authenticator, err := auth.NewOIDCAuthenticator(ctx, auth.OIDCConfig{
+			IssuerURL:     envVars.JWTIssuerURL,
+			Audience:      envVars.JWTAudience,
+			UsernameClaim: envVars.JWTUsernameClaim,
+			GroupsClaim:   envVars.JWTGroupsClaim,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resolver.authenticator = authenticator
+	}
+
+	return resolver, nil
+}
+
+// Resolve returns user who made request, or nil if user is unknown.
+// Users logged in by gateway come first, then identity headers if trusted, then bearer token.
+func (r *Resolver) Resolve(req *http.Request) *auth.Identity {
+	if identity := auth.IdentityFromContext(req.Context()); identity != nil {
+		return identity
+	}
+
+	if r.trustHeaders {
+		if username := getFirstHeader(req, trustedUserHeaders); username != "" {
+			return &auth.Identity{
+				Subject:  username,
+				Username: username,
+				Groups:   splitList(getFirstHeader(req, trustedGroupsHeaders)),
+			}
+		}
+	}
+
+	if r.authenticator != nil {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			return nil
+		}
+
+		identity, err := r.authenticator.Authenticate(req.Context(), token)
+		if err != nil {
+			zap.S().Debugw("Ignoring invalid bearer token", "error", err)
+			return nil
+		}
+		return identity
+	}
+
+	return nil
+}
+
+// SetForwardedHeaders passes identity on to app in request headers.
+// Identity headers sent by client are always removed, so that app can trust them.
+func SetForwardedHeaders(req *http.Request, identity *auth.Identity) {
+	for _, header := range append(append(trustedUserHeaders, trustedGroupsHeaders...), ForwardedEmailHeader) {
+		req.Header.Del(header)
+	}
+
+	if identity == nil {
+		return
+	}
+
+	req.Header.Set(ForwardedUserHeader, identity.Username)
+	if strings.Contains(identity.Username, "@") {
+		req.Header.Set(ForwardedEmailHeader, identity.Username)
+	}
+	if len(identity.Groups) > 0 {
+		req.Header.Set(ForwardedGroupsHeader, strings.Join(identity.Groups, ","))
+	}
+}
+
+func getFirstHeader(req *http.Request, headers []string) string {
+	for _, header := range headers {
+		if value := strings.TrimSpace(req.Header.Get(header)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
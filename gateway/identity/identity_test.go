@@ -0,0 +1,173 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/util/auth"
+)
+
+// tokenAuthenticator accepts fixed tokens.
+type tokenAuthenticator map[string]*auth.Identity
+
+func (a tokenAuthenticator) Authenticate(_ context.Context, token string) (*auth.Identity, error) {
+	if identity, ok := a[token]; ok {
+		return identity, nil
+	}
+	return nil, errors.New("invalid token")
+}
+
+// spoofedHeaders are identity headers a client may send to pass as someone else.
+var spoofedHeaders = map[string]string{
+	"X-Forwarded-User":      "admin",
+	"X-Auth-Request-Email":  "admin@example.com",
+	"X-Auth-Request-User":   "admin",
+	"X-Forwarded-Groups":    "admins",
+	"X-Auth-Request-Groups": "admins",
+	"X-Forwarded-Email":     "admin@example.com",
+}
+
+func newTestRequest(headers map[string]string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/app/", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return req
+}
+
+func TestResolve(t *testing.T) {
+	jane := &auth.Identity{Subject: "jane", Username: "jane@example.com", Groups: []string{"research"}}
+	authenticator := tokenAuthenticator{"jane-token": jane}
+
+	withHeaders := func(headers map[string]string, extra map[string]string) map[string]string {
+		all := make(map[string]string)
+		for name, value := range headers {
+			all[name] = value
+		}
+		for name, value := range extra {
+			all[name] = value
+		}
+		return all
+	}
+
+	tests := []struct {
+		name     string
+		resolver *Resolver
+		headers  map[string]string
+		loggedIn *auth.Identity
+		want     *auth.Identity
+	}{
+		{
+			name:     "headers not trusted",
+			resolver: &Resolver{},
+			headers:  spoofedHeaders,
+		},
+		{
+			name:     "headers not trusted with bearer token",
+			resolver: &Resolver{authenticator: authenticator},
+			headers:  withHeaders(spoofedHeaders, map[string]string{"Authorization": "Bearer jane-token"}),
+			want:     jane,
+		},
+		{
+			name:     "headers not trusted with invalid bearer token",
+			resolver: &Resolver{authenticator: authenticator},
+			headers:  withHeaders(spoofedHeaders, map[string]string{"Authorization": "Bearer forged"}),
+		},
+		{
+			name:     "trusted headers",
+			resolver: &Resolver{trustHeaders: true},
+			headers:  map[string]string{"X-Auth-Request-Email": "bob@example.com", "X-Auth-Request-Groups": "a, b,,"},
+			want:     &auth.Identity{Subject: "bob@example.com", Username: "bob@example.com", Groups: []string{"a", "b"}},
+		},
+		{
+			name:     "trusted headers without user",
+			resolver: &Resolver{trustHeaders: true, authenticator: authenticator},
+			headers:  map[string]string{"X-Forwarded-Groups": "admins", "Authorization": "Bearer jane-token"},
+			want:     jane,
+		},
+		{
+			name:     "logged in by gateway",
+			resolver: &Resolver{trustHeaders: true, authenticator: authenticator},
+			headers:  withHeaders(spoofedHeaders, map[string]string{"Authorization": "Bearer jane-token"}),
+			loggedIn: &auth.Identity{Subject: "carol", Username: "carol"},
+			want:     &auth.Identity{Subject: "carol", Username: "carol"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := newTestRequest(test.headers)
+			if test.loggedIn != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), test.loggedIn))
+			}
+
+			if got := test.resolver.Resolve(req); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got identity %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSetForwardedHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		want     http.Header
+	}{
+		{
+			name: "unknown user",
+			want: http.Header{},
+		},
+		{
+			name:     "user with email & groups",
+			identity: &auth.Identity{Username: "jane@example.com", Groups: []string{"research", "ml"}},
+			want: http.Header{
+				"X-Forwarded-User":   {"jane@example.com"},
+				"X-Forwarded-Email":  {"jane@example.com"},
+				"X-Forwarded-Groups": {"research,ml"},
+			},
+		},
+		{
+			name:     "user without email & groups",
+			identity: &auth.Identity{Username: "jane"},
+			want:     http.Header{"X-Forwarded-User": {"jane"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := newTestRequest(spoofedHeaders)
+			SetForwardedHeaders(req, test.identity)
+
+			got := http.Header{}
+			for name := range spoofedHeaders {
+				if values := req.Header.Values(name); len(values) > 0 {
+					got[name] = values
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got identity headers %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package identity
+
+import (
+	"sync"
+	"time"
+)
+
+// How often sessions that ended are forgotten
+const sessionPruneInterval = time.Minute
+
+// SessionTracker counts sessions of app users. A session ends once its user makes no requests for idle timeout.
+type SessionTracker struct {
+	mu          sync.Mutex
+	idleTimeout time.Duration
+	lastSeen    map[string]time.Time
+	lastPruned  time.Time
+}
+
+func NewSessionTracker(idleTimeout time.Duration) *SessionTracker {
+	return &SessionTracker{
+		idleTimeout: idleTimeout,
+		lastSeen:    make(map[string]time.Time),
+		lastPruned:  time.Now(),
+	}
+}
+
+// Touch records a request of given user or visitor, and returns true if it starts a new session.
+func (t *SessionTracker) Touch(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(t.lastPruned) >= sessionPruneInterval {
+		t.prune(now)
+	}
+
+	lastSeen, ok := t.lastSeen[key]
+	t.lastSeen[key] = now
+
+	return !ok || now.Sub(lastSeen) >= t.idleTimeout
+}
+
+func (t *SessionTracker) prune(now time.Time) {
+	for key, lastSeen := range t.lastSeen {
+		if now.Sub(lastSeen) >= t.idleTimeout {
+			delete(t.lastSeen, key)
+		}
+	}
+	t.lastPruned = now
+}
@@ -16,11 +16,56 @@ limitations under the License.
 
 package internal
 
+import "time"
+
+// Gateway modes
+const (
+	// ModeProxy runs gateway as reverse proxy sidecar of an app.
+	ModeProxy = "proxy"
+	// ModeActivator runs gateway as the shared activator that wakes up sleeping apps.
+	ModeActivator = "activator"
+)
+
 type EnvVars struct {
+	Mode              string `env:"MODE" envDefault:"proxy"`
 	HttpPort          string `env:"HTTP_PORT" envDefault:"8889"`
+	AdminPort         string `env:"ADMIN_PORT" envDefault:"8081"`
 	MetricsEnabled    bool   `env:"METRICS_ENABLED" envDefault:"true"`
 	MetricsTlsEnabled bool   `env:"METRICS_TLS_ENABLED" envDefault:"false"`
-	MetricsPort       string `env:"METRICS_PORT"` // Required if METRICS_ENABLED is true
-	MetricsPath       string `env:"METRICS_PATH"` // Required if METRICS_ENABLED is true
-	TinyAppName       string `env:"TINY_APP_NAME,notEmpty"`
+	MetricsPort       string `env:"METRICS_PORT"`  // Required if METRICS_ENABLED is true
+	MetricsPath       string `env:"METRICS_PATH"`  // Required if METRICS_ENABLED is true
+	TinyAppName       string `env:"TINY_APP_NAME"` // Required in proxy mode
+	AppPort           string `env:"APP_PORT" envDefault:"5000"`
+	// Users counted under their own username in per-user metrics. Further users are counted together.
+	MetricsMaxUsers int `env:"METRICS_MAX_USERS" envDefault:"1000"`
+	// Url path that app responds at while healthy. Gateway readiness doesn't check app if empty.
+	AppHealthPath string `env:"APP_HEALTH_PATH"`
+	// Stripped from request paths before they are proxied, for apps that can only be served at root path
+	StripPathPrefix string `env:"STRIP_PATH_PREFIX"`
+	// Require users to log in with OpenID Connect provider before using app
+	AuthEnabled       bool     `env:"AUTH_ENABLED" envDefault:"false"`
+	OIDCIssuerURL     string   `env:"OIDC_ISSUER_URL"`
+	OIDCClientID      string   `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret  string   `env:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL   string   `env:"OIDC_REDIRECT_URL"` // App url followed by login callback path
+	AppBasePath       string   `env:"APP_BASE_PATH"`     // Url path app is served at
+	OIDCScopes        []string `env:"OIDC_SCOPES" envDefault:"openid,email,profile"`
+	OIDCUsernameClaim string   `env:"OIDC_USERNAME_CLAIM" envDefault:"email"`
+	OIDCGroupsClaim   string   `env:"OIDC_GROUPS_CLAIM" envDefault:"groups"`
+	SessionSecret     string   `env:"SESSION_SECRET"`
+	AllowedUsers      []string `env:"ALLOWED_USERS"`
+	AllowedGroups     []string `env:"ALLOWED_GROUPS"`
+	// Trust identity headers (X-Forwarded-User, X-Auth-Request-Email) set by an auth proxy in front of gateway
+	TrustIdentityHeaders bool `env:"TRUST_IDENTITY_HEADERS" envDefault:"false"`
+	// Identify users by bearer tokens issued by this OpenID Connect provider
+	JWTIssuerURL       string        `env:"JWT_ISSUER_URL"`
+	JWTAudience        string        `env:"JWT_AUDIENCE"`
+	JWTUsernameClaim   string        `env:"JWT_USERNAME_CLAIM" envDefault:"email"`
+	JWTGroupsClaim     string        `env:"JWT_GROUPS_CLAIM" envDefault:"groups"`
+	SessionIdleTimeout time.Duration `env:"SESSION_IDLE_TIMEOUT" envDefault:"30m"` // User session ends after no requests for this long
+	// Used in activator mode
+	KubeConfigPath    string        `env:"KUBE_CONFIG_PATH"`
+	TinyAppNamespace  string        `env:"TINY_APP_NAMESPACE"`
+	AppIngressSubPath string        `env:"APP_INGRESS_SUB_PATH"`
+	WakeUpHoldTimeout time.Duration `env:"WAKE_UP_HOLD_TIMEOUT" envDefault:"10s"` // How long to hold a request while app wakes up
 }
@@ -0,0 +1,451 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package login
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/util"
+	"github.com/tinymultiverse/tinyapp/util/auth"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+const (
+	sessionCookieName = "tinyapp_session"
+	stateCookieName   = "tinyapp_login_state"
+	stateMaxAge       = 10 * time.Minute
+	sessionMaxAge     = 7 * 24 * time.Hour
+	// Session lifetime if identity provider doesn't say when tokens expire
+	defaultTokenLifetime = time.Hour
+)
+
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// BasePath is the url path app is served at. Login routes & cookies are scoped to it.
+	BasePath string
+	// RedirectURL must be app url followed by util.LoginCallbackPath.
+	RedirectURL   string
+	Scopes        []string
+	UsernameClaim string
+	GroupsClaim   string
+	// SessionSecret is used to encrypt session cookies.
+	SessionSecret string
+	// Users & groups allowed to use app. Any logged-in user is allowed if both are empty.
+	AllowedUsers  []string
+	AllowedGroups []string
+}
+
+// Handler requires users to log in with an OpenID Connect provider before their requests reach next handler.
+// Logged-in identity is passed on in request context.
+type Handler struct {
+	config        Config
+	oauth2Config  *oauth2.Config
+	verifier      *auth.OIDCAuthenticator
+	endSessionURL string
+	// App base path, which cookies are scoped to so that apps on the same domain don't share sessions
+	basePath     string
+	secureCookie bool
+	aead         cipher.AEAD
+	next         http.Handler
+}
+
+// session is stored encrypted in session cookie.
+type session struct {
+	Username     string   `json:"u"`
+	Groups       []string `json:"g,omitempty"`
+	Expiry       int64    `json:"e"` // Unix time when session must be refreshed
+	RefreshToken string   `json:"r,omitempty"`
+}
+
+// loginState is stored encrypted in state cookie while user logs in with identity provider.
+type loginState struct {
+	State    string `json:"s"`
+	ReturnTo string `json:"r"`
+}
+
+func NewHandler(ctx context.Context, config Config, next http.Handler) (*Handler, error) {
+	if config.IssuerURL == "" || config.ClientID == "" || config.RedirectURL == "" || config.SessionSecret == "" {
+		return nil, errors.New("OIDC issuer url, client id, redirect url and session secret are required")
+	}
+
+	redirectURL, err := url.Parse(config.RedirectURL)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid redirect url")
+	}
+	basePath := strings.TrimSuffix(config.BasePath, "/")
+	if redirectURL.Path != basePath+util.LoginCallbackPath {
+		return nil, errors.Errorf("redirect url path must be %s", basePath+util.LoginCallbackPath)
+	}
+
+	metadata, err := auth.DiscoverProvider(ctx, config.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := auth.NewOIDCAuthenticator(ctx, auth.OIDCConfig{
+		IssuerURL:     config.IssuerURL,
+		Audience:      config.ClientID,
+		JWKSURL:       metadata.JWKSURI,
+		UsernameClaim: config.UsernameClaim,
+		GroupsClaim:   config.GroupsClaim,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key := sha256.Sum256([]byte(config.SessionSecret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		config: config,
+		oauth2Config: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Scopes:       config.Scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  metadata.AuthorizationEndpoint,
+				TokenURL: metadata.TokenEndpoint,
+			},
+		},
+		verifier:      verifier,
+		endSessionURL: metadata.EndSessionEndpoint,
+		basePath:      basePath,
+		secureCookie:  redirectURL.Scheme == "https",
+		aead:          aead,
+		next:          next,
+	}, nil
+}
+
+func (h *Handler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	// Other paths are app's own, even if they end the same
+	switch req.URL.Path {
+	case h.basePath + util.LoginCallbackPath:
+		h.handleCallback(res, req)
+		return
+	case h.basePath + util.LogoutPath:
+		h.handleLogout(res, req)
+		return
+	}
+
+	s := h.getSession(req)
+	if s != nil && time.Now().Unix() >= s.Expiry {
+		s = h.refreshSession(req.Context(), res, s)
+	}
+	if s == nil {
+		h.startLogin(res, req)
+		return
+	}
+
+	if !h.isAllowed(s) {
+		zap.S().Infow("User is not allowed to use app", "user", s.Username)
+		http.Error(res, "You are not allowed to use this app", http.StatusForbidden)
+		return
+	}
+
+	identity := &auth.Identity{Subject: s.Username, Username: s.Username, Groups: s.Groups}
+	h.next.ServeHTTP(res, req.WithContext(auth.WithIdentity(req.Context(), identity)))
+}
+
+// startLogin redirects browser to identity provider login page. Other clients are told to authenticate.
+func (h *Handler) startLogin(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet || !strings.Contains(req.Header.Get("Accept"), "text/html") {
+		http.Error(res, "Login required", http.StatusUnauthorized)
+		return
+	}
+
+	state, err := randomString()
+	if err != nil {
+		zap.S().Errorw("Failed to generate login state", "error", err)
+		http.Error(res, "Failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.setCookie(res, stateCookieName, loginState{State: state, ReturnTo: req.URL.RequestURI()}, stateMaxAge); err != nil {
+		zap.S().Errorw("Failed to set login state cookie", "error", err)
+		http.Error(res, "Failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(res, req, h.oauth2Config.AuthCodeURL(state), http.StatusFound)
+}
+
+// handleCallback completes login once identity provider redirects user back with an authorization code.
+func (h *Handler) handleCallback(res http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	state := loginState{}
+	if err := h.getCookie(req, stateCookieName, &state); err != nil ||
+		subtle.ConstantTimeCompare([]byte(state.State), []byte(query.Get("state"))) != 1 {
+		http.Error(res, "Invalid login state, please try again", http.StatusBadRequest)
+		return
+	}
+	h.clearCookie(res, stateCookieName)
+
+	if errorCode := query.Get("error"); errorCode != "" {
+		zap.S().Infow("Login failed at identity provider", "error", errorCode, "description", query.Get("error_description"))
+		http.Error(res, "Login failed: "+errorCode, http.StatusUnauthorized)
+		return
+	}
+
+	token, err := h.oauth2Config.Exchange(req.Context(), query.Get("code"))
+	if err != nil {
+		zap.S().Errorw("Failed to exchange authorization code", "error", err)
+		http.Error(res, "Login failed", http.StatusUnauthorized)
+		return
+	}
+
+	s, err := h.newSession(req.Context(), token, nil)
+	if err != nil {
+		zap.S().Errorw("Failed to create session", "error", err)
+		http.Error(res, "Login failed", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.setCookie(res, sessionCookieName, s, sessionMaxAge); err != nil {
+		zap.S().Errorw("Failed to set session cookie", "error", err)
+		http.Error(res, "Login failed", http.StatusInternalServerError)
+		return
+	}
+
+	zap.S().Infow("User logged in", "user", s.Username)
+
+	http.Redirect(res, req, h.getReturnTo(state.ReturnTo), http.StatusFound)
+}
+
+func (h *Handler) handleLogout(res http.ResponseWriter, req *http.Request) {
+	h.clearCookie(res, sessionCookieName)
+
+	if h.endSessionURL == "" {
+		http.Redirect(res, req, h.basePath+"/", http.StatusFound)
+		return
+	}
+
+	// Also end session with identity provider, otherwise user would be logged right back in
+	endSessionURL, err := url.Parse(h.endSessionURL)
+	if err != nil {
+		http.Redirect(res, req, h.basePath+"/", http.StatusFound)
+		return
+	}
+	appURL := strings.TrimSuffix(h.config.RedirectURL, util.LoginCallbackPath) + "/"
+	query := endSessionURL.Query()
+	query.Set("client_id", h.config.ClientID)
+	query.Set("post_logout_redirect_uri", appURL)
+	endSessionURL.RawQuery = query.Encode()
+
+	http.Redirect(res, req, endSessionURL.String(), http.StatusFound)
+}
+
+// refreshSession gets new tokens with session refresh token.
+// Returns nil if session can't be refreshed and user has to log in again.
+func (h *Handler) refreshSession(ctx context.Context, res http.ResponseWriter, s *session) *session {
+	if s.RefreshToken == "" {
+		return nil
+	}
+
+	token, err := h.oauth2Config.TokenSource(ctx, &oauth2.Token{RefreshToken: s.RefreshToken}).Token()
+	if err != nil {
+		zap.S().Infow("Failed to refresh session", "user", s.Username, "error", err)
+		return nil
+	}
+
+	refreshed, err := h.newSession(ctx, token, s)
+	if err != nil {
+		zap.S().Infow("Failed to refresh session", "user", s.Username, "error", err)
+		return nil
+	}
+
+	if err := h.setCookie(res, sessionCookieName, refreshed, sessionMaxAge); err != nil {
+		zap.S().Errorw("Failed to set session cookie", "error", err)
+		return nil
+	}
+
+	return refreshed
+}
+
+// newSession creates session from tokens issued by identity provider.
+// Refresh responses may leave out id token & refresh token, in which case those of previous session are kept.
+func (h *Handler) newSession(ctx context.Context, token *oauth2.Token, previous *session) (*session, error) {
+	s := &session{RefreshToken: token.RefreshToken}
+
+	if rawIDToken, ok := token.Extra("id_token").(string); ok && rawIDToken != "" {
+		identity, err := h.verifier.Authenticate(ctx, rawIDToken)
+		if err != nil {
+			return nil, err
+		}
+		s.Username = identity.Username
+		s.Groups = identity.Groups
+	} else if previous != nil {
+		s.Username = previous.Username
+		s.Groups = previous.Groups
+	} else {
+		return nil, errors.New("identity provider did not return an id token")
+	}
+
+	if s.RefreshToken == "" && previous != nil {
+		s.RefreshToken = previous.RefreshToken
+	}
+
+	expiry := token.Expiry
+	if expiry.IsZero() {
+		expiry = time.Now().Add(defaultTokenLifetime)
+	}
+	s.Expiry = expiry.Unix()
+
+	return s, nil
+}
+
+func (h *Handler) isAllowed(s *session) bool {
+	if len(h.config.AllowedUsers) == 0 && len(h.config.AllowedGroups) == 0 {
+		return true
+	}
+
+	if slices.Contains(h.config.AllowedUsers, s.Username) {
+		return true
+	}
+
+	for _, group := range s.Groups {
+		if slices.Contains(h.config.AllowedGroups, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getReturnTo returns where to send user after login, making sure it stays within app.
+// Browsers treat backslashes as slashes, so they are not allowed either.
+func (h *Handler) getReturnTo(returnTo string) string {
+	returnURL, err := url.Parse(returnTo)
+	if err != nil || returnURL.Scheme != "" || returnURL.Host != "" || strings.HasPrefix(returnTo, "//") ||
+		strings.Contains(returnTo, "\\") || !strings.HasPrefix(path.Clean(returnURL.Path)+"/", h.basePath+"/") {
+		return h.basePath + "/"
+	}
+	return returnTo
+}
+
+func (h *Handler) getSession(req *http.Request) *session {
+	s := &session{}
+	if err := h.getCookie(req, sessionCookieName, s); err != nil {
+		return nil
+	}
+	return s
+}
+
+// setCookie stores value encrypted in a cookie scoped to app.
+func (h *Handler) setCookie(res http.ResponseWriter, name string, value interface{}, maxAge time.Duration) error {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, h.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	ciphertext := h.aead.Seal(nonce, nonce, plaintext, h.getCookieAdditionalData(name))
+
+	http.SetCookie(res, &http.Cookie{
+		Name:     name,
+		Value:    base64.RawURLEncoding.EncodeToString(ciphertext),
+		Path:     h.getCookiePath(),
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   h.secureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return nil
+}
+
+func (h *Handler) getCookie(req *http.Request, name string, value interface{}) error {
+	cookie, err := req.Cookie(name)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return err
+	}
+
+	nonceSize := h.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return errors.New("cookie too short")
+	}
+
+	plaintext, err := h.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], h.getCookieAdditionalData(name))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(plaintext, value)
+}
+
+func (h *Handler) clearCookie(res http.ResponseWriter, name string) {
+	http.SetCookie(res, &http.Cookie{
+		Name:     name,
+		Path:     h.getCookiePath(),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   h.secureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// getCookieAdditionalData returns data that cookie is authenticated with besides its value: cookie name & app url.
+// Cookies of all apps are encrypted with the same session secret, so this keeps one cookie from being passed off as
+// another, or as a cookie of another app.
+func (h *Handler) getCookieAdditionalData(name string) []byte {
+	return []byte(name + " " + h.config.RedirectURL)
+}
+
+func (h *Handler) getCookiePath() string {
+	return h.basePath + "/"
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
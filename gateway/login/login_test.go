@@ -0,0 +1,530 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package login
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/util/auth"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	testClientID = "tinyapp-gateway"
+	testBasePath = "/apps/abc"
+	testAppURL   = "https://apps.example.com" + testBasePath
+	testCode     = "auth-code"
+)
+
+// testProvider is an OpenID Connect provider that logs in a fixed user.
+type testProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	username string
+	groups   []string
+	// Refresh token that token endpoint accepts. Refresh fails if empty.
+	refreshToken string
+	refreshed    int
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	provider := &testProvider{key: key, username: "jane@example.com", groups: []string{"research"}, refreshToken: "refresh-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(res http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(res).Encode(auth.ProviderMetadata{
+			Issuer:                provider.server.URL,
+			AuthorizationEndpoint: provider.server.URL + "/authorize",
+			TokenEndpoint:         provider.server.URL + "/token",
+			JWKSURI:               provider.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(res http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(res).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "key-1", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(res http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		switch req.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if req.PostForm.Get("code") != testCode {
+				http.Error(res, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+		case "refresh_token":
+			if provider.refreshToken == "" || req.PostForm.Get("refresh_token") != provider.refreshToken {
+				http.Error(res, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			provider.refreshed++
+		default:
+			http.Error(res, `{"error":"unsupported_grant_type"}`, http.StatusBadRequest)
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(res).Encode(map[string]interface{}{
+			"access_token":  "access",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+			"refresh_token": provider.refreshToken,
+			"id_token":      provider.signIDToken(t),
+		})
+	})
+
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+
+	return provider
+}
+
+func (p *testProvider) signIDToken(t *testing.T) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: p.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "key-1"))
+	if err != nil {
+		t.Errorf("failed to create signer: %v", err)
+		return ""
+	}
+
+	now := time.Now()
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   p.server.URL,
+		Subject:  p.username,
+		Audience: jwt.Audience{testClientID},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}).Claims(map[string]interface{}{"email": p.username, "groups": p.groups}).CompactSerialize()
+	if err != nil {
+		t.Errorf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// newTestHandler returns login handler of app at given url, in front of an app that responds with user's identity.
+func newTestHandler(t *testing.T, provider *testProvider, appURL string, change func(config *Config)) *Handler {
+	t.Helper()
+
+	appPath, err := url.Parse(appURL)
+	if err != nil {
+		t.Fatalf("invalid app url: %v", err)
+	}
+
+	config := Config{
+		IssuerURL:     provider.server.URL,
+		ClientID:      testClientID,
+		ClientSecret:  "client-secret",
+		BasePath:      appPath.Path,
+		RedirectURL:   appURL + "/_tinyapp/oauth2/callback",
+		Scopes:        []string{"openid", "email"},
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+		SessionSecret: "session-secret",
+	}
+	if change != nil {
+		change(&config)
+	}
+
+	app := http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		identity := auth.IdentityFromContext(req.Context())
+		if identity == nil {
+			http.Error(res, "no identity", http.StatusInternalServerError)
+			return
+		}
+		_, _ = res.Write([]byte(identity.Username))
+	})
+
+	handler, err := NewHandler(context.Background(), config, app)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	return handler
+}
+
+// browserRequest returns request a browser makes for a page, with given cookies.
+func browserRequest(target string, cookies ...*http.Cookie) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	return req
+}
+
+func serve(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	return res
+}
+
+// getCookie returns cookie with given name set by response.
+func getCookie(t *testing.T, res *httptest.ResponseRecorder, name string) *http.Cookie {
+	t.Helper()
+
+	for _, cookie := range res.Result().Cookies() {
+		if cookie.Name == name && cookie.MaxAge >= 0 {
+			return cookie
+		}
+	}
+	t.Fatalf("response sets no %s cookie", name)
+	return nil
+}
+
+// newSessionCookie returns session cookie that handler would have set for given session.
+func newSessionCookie(t *testing.T, handler *Handler, s session) *http.Cookie {
+	t.Helper()
+
+	res := httptest.NewRecorder()
+	if err := handler.setCookie(res, sessionCookieName, s, sessionMaxAge); err != nil {
+		t.Fatalf("failed to set session cookie: %v", err)
+	}
+	return getCookie(t, res, sessionCookieName)
+}
+
+// login goes through login flow, and returns session cookie.
+func login(t *testing.T, handler *Handler, target string) *http.Cookie {
+	t.Helper()
+
+	res := serve(handler, browserRequest(target))
+	if res.Code != http.StatusFound {
+		t.Fatalf("got status %d, want redirect to login", res.Code)
+	}
+	authURL, err := url.Parse(res.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid login redirect: %v", err)
+	}
+	state := authURL.Query().Get("state")
+	stateCookie := getCookie(t, res, stateCookieName)
+
+	res = serve(handler, browserRequest(testBasePath+"/_tinyapp/oauth2/callback?code="+testCode+"&state="+state, stateCookie))
+	if res.Code != http.StatusFound {
+		t.Fatalf("got callback status %d (%s), want redirect back to app", res.Code, res.Body.String())
+	}
+	if location := res.Header().Get("Location"); location != target {
+		t.Errorf("got redirect to %s after login, want %s", location, target)
+	}
+	return getCookie(t, res, sessionCookieName)
+}
+
+func TestLogin(t *testing.T) {
+	provider := newTestProvider(t)
+	handler := newTestHandler(t, provider, testAppURL, nil)
+
+	// Clients other than browsers are not redirected to log in
+	res := serve(handler, httptest.NewRequest(http.MethodGet, testBasePath+"/api/data", nil))
+	if res.Code != http.StatusUnauthorized {
+		t.Errorf("got status %d for API request, want %d", res.Code, http.StatusUnauthorized)
+	}
+
+	sessionCookie := login(t, handler, testBasePath+"/page?tab=1")
+	if sessionCookie.Path != testBasePath+"/" || !sessionCookie.HttpOnly || !sessionCookie.Secure {
+		t.Errorf("session cookie is not scoped to app: %+v", sessionCookie)
+	}
+
+	res = serve(handler, browserRequest(testBasePath+"/page", sessionCookie))
+	if res.Code != http.StatusOK || res.Body.String() != provider.username {
+		t.Errorf("got status %d with body %q, want app response for %s", res.Code, res.Body.String(), provider.username)
+	}
+}
+
+func TestLoginRoutes(t *testing.T) {
+	provider := newTestProvider(t)
+	handler := newTestHandler(t, provider, testAppURL, nil)
+	sessionCookie := login(t, handler, testBasePath+"/")
+
+	// Paths of app that only end like login routes reach app
+	for _, target := range []string{
+		testBasePath + "/files/_tinyapp/oauth2/callback",
+		testBasePath + "/files/_tinyapp/oauth2/logout",
+		"/apps/other/_tinyapp/oauth2/logout",
+	} {
+		res := serve(handler, browserRequest(target, sessionCookie))
+		if res.Code != http.StatusOK {
+			t.Errorf("got status %d for %s, want app response", res.Code, target)
+		}
+	}
+
+	res := serve(handler, browserRequest(testBasePath+"/_tinyapp/oauth2/logout", sessionCookie))
+	if res.Code != http.StatusFound || res.Header().Get("Location") != testBasePath+"/" {
+		t.Errorf("got status %d redirecting to %q, want logout", res.Code, res.Header().Get("Location"))
+	}
+	for _, cookie := range res.Result().Cookies() {
+		if cookie.Name == sessionCookieName && cookie.MaxAge >= 0 {
+			t.Errorf("logout did not clear session cookie")
+		}
+	}
+}
+
+func TestNewHandlerRedirectURL(t *testing.T) {
+	provider := newTestProvider(t)
+
+	for _, redirectURL := range []string{
+		testAppURL + "/other/_tinyapp/oauth2/callback",
+		"https://apps.example.com/apps/other/_tinyapp/oauth2/callback",
+		testAppURL + "/",
+	} {
+		_, err := NewHandler(context.Background(), Config{
+			IssuerURL:     provider.server.URL,
+			ClientID:      testClientID,
+			BasePath:      testBasePath,
+			RedirectURL:   redirectURL,
+			SessionSecret: "session-secret",
+		}, http.NotFoundHandler())
+		if err == nil {
+			t.Errorf("redirect url %s outside of app base path was accepted", redirectURL)
+		}
+	}
+}
+
+func TestCallbackInvalidState(t *testing.T) {
+	provider := newTestProvider(t)
+	handler := newTestHandler(t, provider, testAppURL, nil)
+	otherHandler := newTestHandler(t, provider, "https://apps.example.com/apps/other", nil)
+
+	res := serve(handler, browserRequest(testBasePath+"/"))
+	authURL, err := url.Parse(res.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid login redirect: %v", err)
+	}
+	state := authURL.Query().Get("state")
+	stateCookie := getCookie(t, res, stateCookieName)
+
+	otherRes := serve(otherHandler, browserRequest("/apps/other/"))
+	otherAuthURL, err := url.Parse(otherRes.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid login redirect: %v", err)
+	}
+	otherState := otherAuthURL.Query().Get("state")
+	otherStateCookie := getCookie(t, otherRes, stateCookieName)
+	otherStateCookie.Path = stateCookie.Path
+
+	tests := []struct {
+		name    string
+		state   string
+		cookies []*http.Cookie
+	}{
+		{name: "missing state cookie", state: state},
+		{name: "missing state", cookies: []*http.Cookie{stateCookie}},
+		{name: "wrong state", state: "other-state", cookies: []*http.Cookie{stateCookie}},
+		{name: "state of another app", state: otherState, cookies: []*http.Cookie{otherStateCookie}},
+		{name: "tampered state cookie", state: state, cookies: []*http.Cookie{tamper(stateCookie)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			callbackURL := testBasePath + "/_tinyapp/oauth2/callback?code=" + testCode + "&state=" + url.QueryEscape(test.state)
+			res := serve(handler, browserRequest(callbackURL, test.cookies...))
+			if res.Code != http.StatusBadRequest {
+				t.Errorf("got status %d, want %d", res.Code, http.StatusBadRequest)
+			}
+			for _, cookie := range res.Result().Cookies() {
+				if cookie.Name == sessionCookieName {
+					t.Errorf("session cookie was set")
+				}
+			}
+		})
+	}
+}
+
+// tamper returns copy of cookie with a bit of its value flipped.
+func tamper(cookie *http.Cookie) *http.Cookie {
+	value, _ := base64.RawURLEncoding.DecodeString(cookie.Value)
+	value[len(value)-1] ^= 1
+
+	tampered := *cookie
+	tampered.Value = base64.RawURLEncoding.EncodeToString(value)
+	return &tampered
+}
+
+func TestSessionCookieRejected(t *testing.T) {
+	provider := newTestProvider(t)
+	handler := newTestHandler(t, provider, testAppURL, nil)
+	// Apps share session secret, but not sessions
+	otherHandler := newTestHandler(t, provider, "https://apps.example.com/apps/other", nil)
+
+	validSession := session{Username: "jane@example.com", Expiry: time.Now().Add(time.Hour).Unix()}
+	sessionCookie := newSessionCookie(t, handler, validSession)
+
+	stateRes := serve(handler, browserRequest(testBasePath+"/"))
+	renamedCookie := getCookie(t, stateRes, stateCookieName)
+	renamedCookie.Name = sessionCookieName
+
+	tests := []struct {
+		name   string
+		cookie *http.Cookie
+	}{
+		{name: "tampered", cookie: tamper(sessionCookie)},
+		{name: "state cookie renamed to session cookie", cookie: renamedCookie},
+		{name: "session cookie of another app", cookie: newSessionCookie(t, otherHandler, validSession)},
+		{name: "not encrypted", cookie: &http.Cookie{Name: sessionCookieName, Value: base64.RawURLEncoding.EncodeToString([]byte(`{"u":"jane@example.com","e":9999999999}`))}},
+		{name: "garbage", cookie: &http.Cookie{Name: sessionCookieName, Value: "%%%"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, testBasePath+"/api", nil)
+			req.AddCookie(test.cookie)
+			if res := serve(handler, req); res.Code != http.StatusUnauthorized {
+				t.Errorf("got status %d, want %d", res.Code, http.StatusUnauthorized)
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, testBasePath+"/api", nil)
+	req.AddCookie(sessionCookie)
+	if res := serve(handler, req); res.Code != http.StatusOK {
+		t.Errorf("got status %d for valid session, want %d", res.Code, http.StatusOK)
+	}
+}
+
+func TestExpiredSession(t *testing.T) {
+	expired := time.Now().Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name         string
+		session      session
+		refreshToken string // Accepted by identity provider
+		wantRefresh  bool
+	}{
+		{
+			name:         "refreshed",
+			session:      session{Username: "jane@example.com", Expiry: expired, RefreshToken: "refresh-1"},
+			refreshToken: "refresh-1",
+			wantRefresh:  true,
+		},
+		{
+			name:         "refresh rejected",
+			session:      session{Username: "jane@example.com", Expiry: expired, RefreshToken: "revoked"},
+			refreshToken: "refresh-1",
+		},
+		{
+			name:    "no refresh token",
+			session: session{Username: "jane@example.com", Expiry: expired},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := newTestProvider(t)
+			provider.refreshToken = test.refreshToken
+			handler := newTestHandler(t, provider, testAppURL, nil)
+
+			res := serve(handler, browserRequest(testBasePath+"/page", newSessionCookie(t, handler, test.session)))
+
+			if !test.wantRefresh {
+				// User has to log in again
+				if res.Code != http.StatusFound || !strings.HasPrefix(res.Header().Get("Location"), provider.server.URL+"/authorize") {
+					t.Errorf("got status %d redirecting to %q, want redirect to login", res.Code, res.Header().Get("Location"))
+				}
+				return
+			}
+
+			if res.Code != http.StatusOK || provider.refreshed != 1 {
+				t.Fatalf("got status %d after %d refreshes, want app response after refresh", res.Code, provider.refreshed)
+			}
+
+			refreshed := session{}
+			req := browserRequest(testBasePath+"/", getCookie(t, res, sessionCookieName))
+			if err := handler.getCookie(req, sessionCookieName, &refreshed); err != nil {
+				t.Fatalf("failed to read refreshed session: %v", err)
+			}
+			if refreshed.Expiry <= time.Now().Unix() || refreshed.RefreshToken != "refresh-1" {
+				t.Errorf("got refreshed session %+v, want new expiry and refresh token", refreshed)
+			}
+		})
+	}
+}
+
+func TestAllowedUsers(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowedUsers  []string
+		allowedGroups []string
+		want          int
+	}{
+		{name: "anyone", want: http.StatusOK},
+		{name: "allowed user", allowedUsers: []string{"bob@example.com", "jane@example.com"}, want: http.StatusOK},
+		{name: "allowed group", allowedGroups: []string{"research"}, want: http.StatusOK},
+		{name: "denied user", allowedUsers: []string{"bob@example.com"}, want: http.StatusForbidden},
+		{name: "denied group", allowedGroups: []string{"admins"}, want: http.StatusForbidden},
+		{name: "denied user & group", allowedUsers: []string{"bob@example.com"}, allowedGroups: []string{"admins"}, want: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := newTestProvider(t)
+			handler := newTestHandler(t, provider, testAppURL, func(config *Config) {
+				config.AllowedUsers = test.allowedUsers
+				config.AllowedGroups = test.allowedGroups
+			})
+
+			res := serve(handler, browserRequest(testBasePath+"/", login(t, handler, testBasePath+"/")))
+			if res.Code != test.want {
+				t.Errorf("got status %d, want %d", res.Code, test.want)
+			}
+		})
+	}
+}
+
+func TestGetReturnTo(t *testing.T) {
+	handler := &Handler{basePath: testBasePath}
+
+	tests := []struct {
+		returnTo string
+		want     string
+	}{
+		{returnTo: testBasePath + "/page?tab=1", want: testBasePath + "/page?tab=1"},
+		{returnTo: testBasePath + "/", want: testBasePath + "/"},
+		{returnTo: "", want: testBasePath + "/"},
+		{returnTo: "https://evil.com" + testBasePath + "/", want: testBasePath + "/"},
+		{returnTo: "//evil.com" + testBasePath + "/", want: testBasePath + "/"},
+		{returnTo: "//evil", want: testBasePath + "/"},
+		{returnTo: "/\\evil.com", want: testBasePath + "/"},
+		{returnTo: testBasePath + "/\\\\evil.com", want: testBasePath + "/"},
+		{returnTo: "/apps/other/", want: testBasePath + "/"},
+		{returnTo: testBasePath + "other/", want: testBasePath + "/"},
+		{returnTo: testBasePath + "/../other/", want: testBasePath + "/"},
+	}
+
+	for _, test := range tests {
+		if got := handler.getReturnTo(test.returnTo); got != test.want {
+			t.Errorf("getReturnTo(%q) = %q, want %q", test.returnTo, got, test.want)
+		}
+	}
+}
@@ -17,46 +17,123 @@ limitations under the License.
 package proxy
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"strings"
 
-	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/gateway/activity"
+	"github.com/tinymultiverse/tinyapp/gateway/identity"
 	"github.com/tinymultiverse/tinyapp/gateway/internal"
 	"github.com/tinymultiverse/tinyapp/gateway/util/metrics"
 	globalutil "github.com/tinymultiverse/tinyapp/util"
 	"go.uber.org/zap"
 )
 
+// Identifies anonymous visitors, so that their sessions can be counted
+const visitorCookieName = "tinyapp_visitor"
+
 type proxyServerConfig struct {
-	Proxy   *httputil.ReverseProxy
-	AppName string
+	Proxy    *httputil.ReverseProxy
+	AppName  string
+	Activity *activity.Tracker
+	Identity *identity.Resolver
+	Sessions *identity.SessionTracker
+	// Username label values of per-user counters
+	UserLabels *metrics.UserLabels
 }
 
-func NewProxyServerConfig(envVars internal.EnvVars) (*proxyServerConfig, error) {
-	targetURL, err := url.Parse("http://localhost:" + util.DefaultAppPort)
+func NewProxyServerConfig(envVars internal.EnvVars, tracker *activity.Tracker, resolver *identity.Resolver) (*proxyServerConfig, error) {
+	targetURL, err := url.Parse("http://localhost:" + envVars.AppPort)
 	if err != nil {
 		return nil, err
 	}
 
 	proxy := httputil.NewSingleHostReverseProxy(targetURL)
+	if envVars.StripPathPrefix != "" {
+		proxy.Director = stripPathPrefix(proxy.Director, strings.TrimSuffix(envVars.StripPathPrefix, "/"))
+	}
 
 	return &proxyServerConfig{
-		Proxy:   proxy,
-		AppName: envVars.TinyAppName,
+		Proxy:      proxy,
+		AppName:    envVars.TinyAppName,
+		Activity:   tracker,
+		Identity:   resolver,
+		Sessions:   identity.NewSessionTracker(envVars.SessionIdleTimeout),
+		UserLabels: metrics.NewUserLabels(envVars.MetricsMaxUsers),
 	}, nil
 }
 
 func (p *proxyServerConfig) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	zap.S().Infow("got a request", "host", req.Host, "method", req.Method, "requestURL", req.URL.String())
 
+	p.Activity.RequestStarted()
+	defer p.Activity.RequestDone()
+
+	user := p.Identity.Resolve(req)
+	identity.SetForwardedHeaders(req, user)
+
+	username := globalutil.AnyUserName
+	sessionKey := ""
+	if user != nil && user.Username != "" {
+		username = user.Username
+		sessionKey = "user:" + username
+	} else if visitorId := getVisitorId(res, req); visitorId != "" {
+		sessionKey = "visitor:" + visitorId
+	}
+
+	if sessionKey != "" && p.Sessions.Touch(sessionKey) {
+		metrics.SessionCounter.WithLabelValues(p.UserLabels.Get(username)).Inc()
+	}
+
 	// Only increment user count if the request URL is app homepage, i.e. request url ends with app name (id).
 	if strings.HasSuffix(req.URL.Path, p.AppName+"/") {
 		zap.S().Debug("Incrementing user count")
-		// TODO Once integrated with OAuth, get actual username from auth server
-		metrics.UsernameCounter.WithLabelValues(globalutil.AnyUserName).Inc()
+		metrics.UsernameCounter.WithLabelValues(p.UserLabels.Get(username)).Inc()
 	}
 
 	p.Proxy.ServeHTTP(res, req)
 }
+
+// stripPathPrefix returns director that strips prefix from request path before directing request to app.
+// Stripped prefix is passed on in X-Forwarded-Prefix header, so that app can still build its external urls.
+func stripPathPrefix(director func(*http.Request), prefix string) func(*http.Request) {
+	return func(req *http.Request) {
+		director(req)
+
+		if req.URL.Path != prefix && !strings.HasPrefix(req.URL.Path, prefix+"/") {
+			return
+		}
+
+		req.URL.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, prefix), "/")
+		if req.URL.RawPath != "" {
+			req.URL.RawPath = "/" + strings.TrimPrefix(strings.TrimPrefix(req.URL.RawPath, prefix), "/")
+		}
+		req.Header.Set("X-Forwarded-Prefix", prefix)
+	}
+}
+
+// getVisitorId returns id of anonymous visitor from cookie, setting a new one if visitor has none.
+func getVisitorId(res http.ResponseWriter, req *http.Request) string {
+	if cookie, err := req.Cookie(visitorCookieName); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	visitorId := hex.EncodeToString(b)
+
+	http.SetCookie(res, &http.Cookie{
+		Name:     visitorCookieName,
+		Value:    visitorId,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return visitorId
+}
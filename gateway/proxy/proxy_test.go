@@ -0,0 +1,138 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/gateway/activity"
+	"github.com/tinymultiverse/tinyapp/gateway/identity"
+	"github.com/tinymultiverse/tinyapp/gateway/internal"
+	"github.com/tinymultiverse/tinyapp/util/auth"
+)
+
+// identityHeaders are headers that carry user identity to app.
+var identityHeaders = []string{
+	"X-Forwarded-User", "X-Forwarded-Email", "X-Forwarded-Groups",
+	"X-Auth-Request-User", "X-Auth-Request-Email", "X-Auth-Request-Groups",
+}
+
+// newTestProxy returns gateway proxy in front of an app that records identity headers of requests it gets.
+func newTestProxy(t *testing.T, envVars internal.EnvVars) (http.Handler, *http.Header) {
+	t.Helper()
+
+	received := &http.Header{}
+	app := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		*received = http.Header{}
+		for _, name := range identityHeaders {
+			if values := req.Header.Values(name); len(values) > 0 {
+				(*received)[name] = values
+			}
+		}
+	}))
+	t.Cleanup(app.Close)
+
+	appURL, err := url.Parse(app.URL)
+	if err != nil {
+		t.Fatalf("invalid app url: %v", err)
+	}
+	envVars.AppPort = appURL.Port()
+	envVars.TinyAppName = "abc"
+	envVars.SessionIdleTimeout = time.Minute
+	envVars.MetricsMaxUsers = 10
+
+	resolver, err := identity.NewResolver(context.Background(), envVars)
+	if err != nil {
+		t.Fatalf("failed to create resolver: %v", err)
+	}
+
+	proxy, err := NewProxyServerConfig(envVars, activity.NewTracker(), resolver)
+	if err != nil {
+		t.Fatalf("failed to create proxy: %v", err)
+	}
+	return proxy, received
+}
+
+func TestProxyIdentityHeaders(t *testing.T) {
+	spoofed := map[string]string{
+		"X-Forwarded-User":      "admin",
+		"X-Forwarded-Email":     "admin@example.com",
+		"X-Forwarded-Groups":    "admins",
+		"X-Auth-Request-Email":  "admin@example.com",
+		"X-Auth-Request-Groups": "admins",
+	}
+
+	tests := []struct {
+		name         string
+		trustHeaders bool
+		headers      map[string]string
+		loggedIn     *auth.Identity
+		want         http.Header
+	}{
+		{
+			name:    "spoofed headers not trusted",
+			headers: spoofed,
+			want:    http.Header{},
+		},
+		{
+			name:     "spoofed headers of logged in user",
+			headers:  spoofed,
+			loggedIn: &auth.Identity{Username: "jane@example.com"},
+			want:     http.Header{"X-Forwarded-User": {"jane@example.com"}, "X-Forwarded-Email": {"jane@example.com"}},
+		},
+		{
+			name:         "trusted headers are replaced",
+			trustHeaders: true,
+			headers:      map[string]string{"X-Auth-Request-Email": "bob@example.com", "X-Auth-Request-Groups": "ml", "X-Forwarded-Email": "admin@example.com"},
+			want: http.Header{
+				"X-Forwarded-User":   {"bob@example.com"},
+				"X-Forwarded-Email":  {"bob@example.com"},
+				"X-Forwarded-Groups": {"ml"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proxy, received := newTestProxy(t, internal.EnvVars{TrustIdentityHeaders: test.trustHeaders})
+
+			req := httptest.NewRequest(http.MethodGet, "/abc/", nil)
+			for name, value := range test.headers {
+				req.Header.Set(name, value)
+			}
+			if test.loggedIn != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), test.loggedIn))
+			}
+
+			res := httptest.NewRecorder()
+			proxy.ServeHTTP(res, req)
+			if res.Code != http.StatusOK {
+				t.Fatalf("got status %d, want %d", res.Code, http.StatusOK)
+			}
+
+			if !reflect.DeepEqual(*received, test.want) {
+				t.Errorf("app got identity headers %v, want %v", *received, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const appNameLabel = "app_name"
+
+var (
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gateway_request_duration_seconds",
+		Help:    "Duration of app requests, excluding websocket connections",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{appNameLabel, "code_class"})
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_requests_total",
+		Help: "Number of app requests by response status class",
+	}, []string{appNameLabel, "code_class"})
+	RequestBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_request_bytes_total",
+		Help: "Bytes received in app request bodies",
+	}, []string{appNameLabel})
+	ResponseBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_response_bytes_total",
+		Help: "Bytes sent in app response bodies",
+	}, []string{appNameLabel})
+	RequestsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gateway_requests_in_flight",
+		Help: "Number of app requests being served",
+	}, []string{appNameLabel})
+	WebsocketConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gateway_websocket_connections_active",
+		Help: "Number of open websocket connections to app",
+	}, []string{appNameLabel})
+)
+
+// HTTPCollectors returns collectors updated by InstrumentHandler.
+func HTTPCollectors() []prometheus.Collector {
+	return []prometheus.Collector{RequestDuration, RequestsTotal, RequestBytes, ResponseBytes, RequestsInFlight, WebsocketConnections}
+}
+
+// InstrumentHandler records request metrics of app with given name.
+func InstrumentHandler(appName string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		inFlight := RequestsInFlight.WithLabelValues(appName)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		// Websocket connections stay open as long as app is in use, so they are counted instead of timed
+		isWebsocket := strings.EqualFold(req.Header.Get("Upgrade"), "websocket")
+		if isWebsocket {
+			connections := WebsocketConnections.WithLabelValues(appName)
+			connections.Inc()
+			defer connections.Dec()
+		}
+
+		if req.Body != nil && req.Body != http.NoBody {
+			body := &countingReadCloser{ReadCloser: req.Body}
+			req.Body = body
+			defer func() { RequestBytes.WithLabelValues(appName).Add(float64(body.bytes)) }()
+		}
+
+		writer := &statusRecorder{ResponseWriter: res, status: http.StatusOK}
+		start := time.Now()
+
+		next.ServeHTTP(writer, req)
+
+		// Proxy writes 101 response straight to hijacked connection
+		if isWebsocket && !writer.wroteHeader {
+			writer.status = http.StatusSwitchingProtocols
+		}
+
+		codeClass := strconv.Itoa(writer.status/100) + "xx"
+		RequestsTotal.WithLabelValues(appName, codeClass).Inc()
+		ResponseBytes.WithLabelValues(appName).Add(float64(writer.bytes))
+		if !isWebsocket {
+			RequestDuration.WithLabelValues(appName, codeClass).Observe(time.Since(start).Seconds())
+		}
+	})
+}
+
+// statusRecorder records response status & size.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach underlying writer, which proxy needs to flush & hijack websockets.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	bytes int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.bytes += int64(n)
+	return n, err
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestInstrumentHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       func(t *testing.T, appName string, res http.ResponseWriter, req *http.Request)
+		body          string
+		websocket     bool
+		wantCodeClass string
+		wantResBytes  float64
+		wantTimed     bool
+	}{
+		{
+			name: "ok",
+			handler: func(t *testing.T, appName string, res http.ResponseWriter, req *http.Request) {
+				if _, err := io.ReadAll(req.Body); err != nil {
+					t.Error(err)
+				}
+				res.WriteHeader(http.StatusCreated)
+				fmt.Fprint(res, "hello")
+			},
+			body:          "abcd",
+			wantCodeClass: "2xx",
+			wantResBytes:  5,
+			wantTimed:     true,
+		},
+		{
+			name: "status defaults to ok",
+			handler: func(t *testing.T, appName string, res http.ResponseWriter, req *http.Request) {
+				fmt.Fprint(res, "hi")
+			},
+			wantCodeClass: "2xx",
+			wantResBytes:  2,
+			wantTimed:     true,
+		},
+		{
+			name: "error",
+			handler: func(t *testing.T, appName string, res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusBadGateway)
+				res.WriteHeader(http.StatusOK)
+			},
+			wantCodeClass: "5xx",
+			wantTimed:     true,
+		},
+		{
+			name: "in flight",
+			handler: func(t *testing.T, appName string, res http.ResponseWriter, req *http.Request) {
+				if got := testutil.ToFloat64(RequestsInFlight.WithLabelValues(appName)); got != 1 {
+					t.Errorf("got %v requests in flight while serving, want 1", got)
+				}
+				if err := http.NewResponseController(res).Flush(); err != nil {
+					t.Errorf("failed to flush: %s", err)
+				}
+			},
+			wantCodeClass: "2xx",
+			wantTimed:     true,
+		},
+		{
+			name: "websocket",
+			handler: func(t *testing.T, appName string, res http.ResponseWriter, req *http.Request) {
+				if got := testutil.ToFloat64(WebsocketConnections.WithLabelValues(appName)); got != 1 {
+					t.Errorf("got %v websocket connections while connected, want 1", got)
+				}
+			},
+			websocket:     true,
+			wantCodeClass: "1xx",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Metrics are global, so each test counts requests of its own app
+			appName := strings.ReplaceAll(test.name, " ", "-")
+			handler := InstrumentHandler(appName, http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				test.handler(t, appName, res, req)
+			}))
+
+			var body io.Reader
+			if test.body != "" {
+				body = strings.NewReader(test.body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			if test.websocket {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", "websocket")
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got := testutil.ToFloat64(RequestsTotal.WithLabelValues(appName, test.wantCodeClass)); got != 1 {
+				t.Errorf("got %v %s requests, want 1", got, test.wantCodeClass)
+			}
+			if got := testutil.ToFloat64(RequestBytes.WithLabelValues(appName)); got != float64(len(test.body)) {
+				t.Errorf("got %v request bytes, want %d", got, len(test.body))
+			}
+			if got := testutil.ToFloat64(ResponseBytes.WithLabelValues(appName)); got != test.wantResBytes {
+				t.Errorf("got %v response bytes, want %v", got, test.wantResBytes)
+			}
+			if got := testutil.ToFloat64(RequestsInFlight.WithLabelValues(appName)); got != 0 {
+				t.Errorf("got %v requests in flight after request, want 0", got)
+			}
+			if got := testutil.ToFloat64(WebsocketConnections.WithLabelValues(appName)); got != 0 {
+				t.Errorf("got %v websocket connections after request, want 0", got)
+			}
+			// Duration series only exists once a request is timed
+			if timed := RequestDuration.DeleteLabelValues(appName, test.wantCodeClass); timed != test.wantTimed {
+				t.Errorf("got request timed %t, want %t", timed, test.wantTimed)
+			}
+		})
+	}
+}
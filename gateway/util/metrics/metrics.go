@@ -17,11 +17,50 @@ limitations under the License.
 package metrics
 
 import (
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tinymultiverse/tinyapp/util"
 )
 
 var (
 	UsernameCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "username_counter",
 	}, []string{"username"})
+	SessionCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "session_counter",
+		Help: "Number of user sessions started",
+	}, []string{"username"})
 )
+
+// UserLabels bounds the number of username label values of per-user counters, so that their series don't grow without
+// limit as users arrive. Users beyond the limit are counted together under util.OtherUsersName.
+type UserLabels struct {
+	mu       sync.Mutex
+	maxUsers int
+	users    map[string]struct{}
+}
+
+func NewUserLabels(maxUsers int) *UserLabels {
+	return &UserLabels{maxUsers: maxUsers, users: make(map[string]struct{})}
+}
+
+// Get returns username label value of given user.
+func (l *UserLabels) Get(username string) string {
+	if username == util.AnyUserName {
+		return username
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.users[username]; ok {
+		return username
+	}
+	if len(l.users) >= l.maxUsers {
+		return util.OtherUsersName
+	}
+
+	l.users[username] = struct{}{}
+	return username
+}
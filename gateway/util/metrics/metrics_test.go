@@ -0,0 +1,48 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/util"
+)
+
+func TestUserLabels(t *testing.T) {
+	labels := NewUserLabels(2)
+
+	tests := []struct {
+		username string
+		want     string
+	}{
+		{username: "alice", want: "alice"},
+		{username: util.AnyUserName, want: util.AnyUserName},
+		{username: "bob", want: "bob"},
+		// Limit is reached, new users are counted together
+		{username: "carol", want: util.OtherUsersName},
+		{username: "dave", want: util.OtherUsersName},
+		// Users seen before keep their label, as does anonymous user
+		{username: "alice", want: "alice"},
+		{username: util.AnyUserName, want: util.AnyUserName},
+	}
+
+	for _, test := range tests {
+		if got := labels.Get(test.username); got != test.want {
+			t.Errorf("Get(%q) = %q, want %q", test.username, got, test.want)
+		}
+	}
+}
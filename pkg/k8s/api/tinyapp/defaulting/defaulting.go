@@ -0,0 +1,147 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"strings"
+
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+)
+
+// Defaults holds values set on TinyApps that leave them empty. Empty defaults are not applied.
+type Defaults struct {
+	Image              string
+	IngressDomain      string
+	IngressSubPath     string
+	IngressTlsEnabled  bool
+	GitTokenSecretName string
+	CPURequest         string
+	CPULimit           string
+	MemoryRequest      string
+	MemoryLimit        string
+}
+
+// SetDefaults fills empty fields of app with given defaults & controller constants. Fields that are already set are
+// left as is, so calling it on an app that was defaulted before is a no-op.
+func SetDefaults(app *v1alpha1.TinyApp, defaults Defaults) {
+	setDefaultLabels(app)
+
+	spec := &app.Spec
+	// Apps baked into their image must bring it, since default image has no app code
+	if spec.Image == "" && spec.SourceType != v1alpha1.SourceTypeImage {
+		spec.Image = defaults.Image
+	}
+
+	// Ingress settings go together, so sub path & TLS are only defaulted along with domain
+	if spec.IngressDomain == "" && defaults.IngressDomain != "" {
+		spec.IngressDomain = defaults.IngressDomain
+		spec.IngressTlsEnabled = defaults.IngressTlsEnabled
+		if spec.IngressSubPath == "" {
+			spec.IngressSubPath = defaults.IngressSubPath
+		}
+	}
+
+	if spec.SourceType == v1alpha1.SourceTypeGit && spec.GitConfig != nil {
+		if spec.GitConfig.TokenSecretName == "" {
+			spec.GitConfig.TokenSecretName = defaults.GitTokenSecretName
+		}
+		if spec.GitConfig.RefType == "" {
+			spec.GitConfig.RefType = spec.GitConfig.GetRefType()
+		}
+	}
+
+	setDefaultResources(spec, defaults)
+
+	if spec.Autoscaling != nil {
+		if spec.Autoscaling.MinReplicas == nil {
+			spec.Autoscaling.MinReplicas = pointer.Int32(util.DefaultAppReplicas)
+		}
+		if spec.Autoscaling.TargetCPUUtilizationPercentage == nil {
+			spec.Autoscaling.TargetCPUUtilizationPercentage = pointer.Int32(util.DefaultTargetCPUUtilizationPercentage)
+		}
+	} else if spec.Replicas == nil {
+		spec.Replicas = pointer.Int32(util.DefaultAppReplicas)
+	}
+}
+
+// setDefaultLabels adds labels that controller selects app pods with.
+func setDefaultLabels(app *v1alpha1.TinyApp) {
+	if app.Labels == nil {
+		app.Labels = make(map[string]string)
+	}
+
+	if app.Labels[globalutil.K8sNameLabel] == "" && app.Name != "" {
+		app.Labels[globalutil.K8sNameLabel] = app.Name
+	}
+	if app.Labels[globalutil.K8sPartOfLabel] == "" {
+		app.Labels[globalutil.K8sPartOfLabel] = globalutil.TinyAppPartOfLabel
+	}
+}
+
+func setDefaultResources(spec *v1alpha1.TinyAppSpec, defaults Defaults) {
+	if defaults.CPURequest == "" && defaults.CPULimit == "" && defaults.MemoryRequest == "" && defaults.MemoryLimit == "" {
+		return
+	}
+
+	if spec.Resources == nil {
+		spec.Resources = &v1alpha1.AppResources{}
+	}
+
+	resources := spec.Resources
+	resources.CPURequest, resources.CPULimit = defaultQuantities(resources.CPURequest, resources.CPULimit,
+		defaults.CPURequest, defaults.CPULimit)
+	resources.MemoryRequest, resources.MemoryLimit = defaultQuantities(resources.MemoryRequest, resources.MemoryLimit,
+		defaults.MemoryRequest, defaults.MemoryLimit)
+}
+
+// defaultQuantities fills empty request & limit of a resource the same way controller does when building app pods:
+// if only request is set and it is above the default limit, limit is raised to match the request, and if only limit is
+// set and it is below the default request, request is lowered to match the limit.
+func defaultQuantities(request, limit, defaultRequest, defaultLimit string) (string, string) {
+	requestSet, limitSet := strings.TrimSpace(request) != "", strings.TrimSpace(limit) != ""
+	if requestSet && limitSet {
+		return request, limit
+	}
+	if !requestSet {
+		request = defaultRequest
+	}
+	if !limitSet {
+		limit = defaultLimit
+	}
+
+	requestQuantity, err := resource.ParseQuantity(request)
+	if err != nil {
+		// Left for validation to reject
+		return request, limit
+	}
+
+	limitQuantity, err := resource.ParseQuantity(limit)
+	if err == nil && requestQuantity.Cmp(limitQuantity) > 0 {
+		if limitSet {
+			request = limit
+		} else {
+			limit = request
+		}
+	}
+
+	return request, limit
+}
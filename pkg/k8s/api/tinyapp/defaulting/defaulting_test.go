@@ -0,0 +1,212 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var testDefaults = Defaults{
+	Image:              "python:3.11",
+	IngressDomain:      "apps.example.com",
+	IngressSubPath:     "/tinyapp",
+	IngressTlsEnabled:  true,
+	GitTokenSecretName: "git-token",
+	CPURequest:         "50m",
+	CPULimit:           "1",
+	MemoryRequest:      "256Mi",
+	MemoryLimit:        "3Gi",
+}
+
+// newTestApp returns Streamlit app from git that sets no optional fields, changed by given function.
+func newTestApp(change func(app *v1alpha1.TinyApp)) *v1alpha1.TinyApp {
+	app := &v1alpha1.TinyApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "tinyapp"},
+		Spec: v1alpha1.TinyAppSpec{
+			AppType:      v1alpha1.AppTypeStreamlit,
+			SourceType:   v1alpha1.SourceTypeGit,
+			MainFilePath: "app.py",
+			GitConfig:    &v1alpha1.GitConfig{GitUrl: "https://github.com/org/repo.git", GitRef: "main"},
+		},
+	}
+	if change != nil {
+		change(app)
+	}
+	return app
+}
+
+func TestSetDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		app      *v1alpha1.TinyApp
+		defaults Defaults
+		want     *v1alpha1.TinyApp
+	}{
+		{
+			name:     "empty app",
+			app:      newTestApp(nil),
+			defaults: testDefaults,
+			want: newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Labels = map[string]string{globalutil.K8sNameLabel: "my-app", globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel}
+				app.Spec.Image = "python:3.11"
+				app.Spec.IngressDomain, app.Spec.IngressSubPath, app.Spec.IngressTlsEnabled = "apps.example.com", "/tinyapp", true
+				app.Spec.GitConfig.TokenSecretName = "git-token"
+				app.Spec.GitConfig.RefType = v1alpha1.GitRefTypeBranch
+				app.Spec.Resources = &v1alpha1.AppResources{CPURequest: "50m", CPULimit: "1", MemoryRequest: "256Mi", MemoryLimit: "3Gi"}
+				app.Spec.Replicas = pointer.Int32(util.DefaultAppReplicas)
+			}),
+		},
+		{
+			name: "empty defaults",
+			app:  newTestApp(nil),
+			want: newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Labels = map[string]string{globalutil.K8sNameLabel: "my-app", globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel}
+				app.Spec.GitConfig.RefType = v1alpha1.GitRefTypeBranch
+				app.Spec.Replicas = pointer.Int32(util.DefaultAppReplicas)
+			}),
+		},
+		{
+			name: "legacy tag",
+			app: newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Spec.GitConfig.IsTag = true
+			}),
+			want: newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Labels = map[string]string{globalutil.K8sNameLabel: "my-app", globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel}
+				app.Spec.GitConfig.IsTag = true
+				app.Spec.GitConfig.RefType = v1alpha1.GitRefTypeTag
+				app.Spec.Replicas = pointer.Int32(util.DefaultAppReplicas)
+			}),
+		},
+		{
+			name: "image app",
+			app: newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Spec.SourceType, app.Spec.GitConfig = v1alpha1.SourceTypeImage, nil
+			}),
+			defaults: Defaults{Image: "python:3.11", GitTokenSecretName: "git-token"},
+			want: newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Labels = map[string]string{globalutil.K8sNameLabel: "my-app", globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel}
+				app.Spec.SourceType, app.Spec.GitConfig = v1alpha1.SourceTypeImage, nil
+				app.Spec.Replicas = pointer.Int32(util.DefaultAppReplicas)
+			}),
+		},
+		{
+			name: "autoscaling",
+			app: newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Spec.Autoscaling = &v1alpha1.AutoscalingPolicy{MaxReplicas: 3}
+			}),
+			want: newTestApp(func(app *v1alpha1.TinyApp) {
+				app.Labels = map[string]string{globalutil.K8sNameLabel: "my-app", globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel}
+				app.Spec.GitConfig.RefType = v1alpha1.GitRefTypeBranch
+				app.Spec.Autoscaling = &v1alpha1.AutoscalingPolicy{
+					MinReplicas:                    pointer.Int32(util.DefaultAppReplicas),
+					MaxReplicas:                    3,
+					TargetCPUUtilizationPercentage: pointer.Int32(util.DefaultTargetCPUUtilizationPercentage),
+				}
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetDefaults(test.app, test.defaults)
+			if !reflect.DeepEqual(test.app, test.want) {
+				t.Errorf("got app %+v, want %+v", test.app, test.want)
+			}
+		})
+	}
+}
+
+func TestSetDefaultsKeepsSetFields(t *testing.T) {
+	newSetApp := func() *v1alpha1.TinyApp {
+		return newTestApp(func(app *v1alpha1.TinyApp) {
+			app.Labels = map[string]string{globalutil.K8sNameLabel: "other", globalutil.K8sPartOfLabel: "other", "team": "data"}
+			app.Spec.Image = "python:3.12"
+			app.Spec.IngressDomain, app.Spec.IngressSubPath = "internal.example.com", "/apps"
+			app.Spec.GitConfig.TokenSecretName = "my-token"
+			app.Spec.GitConfig.RefType = v1alpha1.GitRefTypeTag
+			app.Spec.Resources = &v1alpha1.AppResources{CPURequest: "2", CPULimit: "4", MemoryRequest: "1Gi", MemoryLimit: "2Gi"}
+			app.Spec.Replicas = pointer.Int32(0)
+			app.Spec.Autoscaling = &v1alpha1.AutoscalingPolicy{
+				MinReplicas:                    pointer.Int32(0),
+				MaxReplicas:                    3,
+				TargetCPUUtilizationPercentage: pointer.Int32(50),
+			}
+		})
+	}
+
+	app := newSetApp()
+	SetDefaults(app, testDefaults)
+	if want := newSetApp(); !reflect.DeepEqual(app, want) {
+		t.Errorf("got app %+v, want %+v", app, want)
+	}
+}
+
+func TestSetDefaultsIdempotent(t *testing.T) {
+	apps := []*v1alpha1.TinyApp{
+		newTestApp(nil),
+		newTestApp(func(app *v1alpha1.TinyApp) { app.Spec.Resources = &v1alpha1.AppResources{CPURequest: "2"} }),
+		newTestApp(func(app *v1alpha1.TinyApp) { app.Spec.Resources = &v1alpha1.AppResources{MemoryLimit: "128Mi"} }),
+		newTestApp(func(app *v1alpha1.TinyApp) { app.Spec.Autoscaling = &v1alpha1.AutoscalingPolicy{MaxReplicas: 3} }),
+		newTestApp(func(app *v1alpha1.TinyApp) { app.Spec.SourceType, app.Spec.GitConfig = v1alpha1.SourceTypeImage, nil }),
+	}
+
+	for _, app := range apps {
+		SetDefaults(app, testDefaults)
+		once := app.DeepCopy()
+		SetDefaults(app, testDefaults)
+		if !reflect.DeepEqual(app, once) {
+			t.Errorf("defaulting again changed app %+v to %+v", once, app)
+		}
+	}
+}
+
+func TestDefaultQuantities(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     string
+		limit       string
+		wantRequest string
+		wantLimit   string
+	}{
+		{name: "defaults", wantRequest: "256Mi", wantLimit: "3Gi"},
+		{name: "request & limit", request: "1Gi", limit: "2Gi", wantRequest: "1Gi", wantLimit: "2Gi"},
+		{name: "request below default limit", request: "1Gi", wantRequest: "1Gi", wantLimit: "3Gi"},
+		{name: "request above default limit", request: "4Gi", wantRequest: "4Gi", wantLimit: "4Gi"},
+		{name: "limit above default request", limit: "1Gi", wantRequest: "256Mi", wantLimit: "1Gi"},
+		{name: "limit below default request", limit: "128Mi", wantRequest: "128Mi", wantLimit: "128Mi"},
+		// Left for validation to reject
+		{name: "request above limit", request: "2Gi", limit: "1Gi", wantRequest: "2Gi", wantLimit: "1Gi"},
+		{name: "invalid request", request: "lots", wantRequest: "lots", wantLimit: "3Gi"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, limit := defaultQuantities(test.request, test.limit, "256Mi", "3Gi")
+			if request != test.wantRequest || limit != test.wantLimit {
+				t.Errorf("got request %s & limit %s, want %s & %s", request, limit, test.wantRequest, test.wantLimit)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 )
 
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -45,10 +46,12 @@ type TinyAppSpec struct {
 	Description string `json:"description" required:"false"`
 	// Documentation holds url to TinyApp documentation.
 	Documentation string `json:"documentation"`
-	// Image is Docker image used to run the app.
+	// Image is Docker image used to run the app. When SourceType is Image, it also holds app code & dependencies.
 	Image string `json:"image"`
 	// AppType is type of app.
 	AppType `json:"appType"`
+	// Custom is how to run the app container. Used & required only when AppType is Custom.
+	Custom *CustomApp `json:"custom,omitempty"`
 	// SourceType determines where app code comes from (ex. git).
 	SourceType `json:"sourceType"`
 	// GitConfig contains git repository information.
@@ -57,6 +60,7 @@ type TinyAppSpec struct {
 	// MainFilePath is the path to app main file, relative to base directory.
 	// Base directory will be /app when SourceType is Git and main volumeClaim's mount path when SourceType is FileSystem.
 	// Parent directory of main file will be the working directory (cwd) for the app process.
+	// Optional when AppType is Custom.
 	MainFilePath string `json:"mainFile"`
 	// EnvVars is environment variables to set in app.
 	EnvVars []*corev1.EnvVar `json:"envVars"`
@@ -71,6 +75,23 @@ type TinyAppSpec struct {
 	IngressSubPath string `json:"ingressPath"`
 	// IngressTlsEnabled specified whether Tls is enabled for ingress.
 	IngressTlsEnabled bool `json:"ingressTlsEnabled"`
+	// Resources is compute resources for the app container.
+	// Controller defaults are used for any value that is not set.
+	Resources *AppResources `json:"resources,omitempty"`
+	// Replicas is the number of app pods. Defaults to 1. Ignored when Autoscaling is set.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Autoscaling enables a HorizontalPodAutoscaler for the app.
+	Autoscaling *AutoscalingPolicy `json:"autoscaling,omitempty"`
+	// IdleTimeout is how long app can go without requests before it is scaled to zero.
+	// App is woken up by the next request. Scale to zero is disabled if not set.
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+	// Access records who can manage app through tinyapp-server. Not used to build app resources, so changing it does
+	// not roll app pods.
+	Access *AppAccess `json:"access,omitempty"`
+	// Auth requires users to log in before using app. App is open to anyone if not set.
+	Auth *AppAuth `json:"auth,omitempty"`
+	// Probes overrides health checks of app container. Defaults of app type are used for any value not set.
+	Probes *AppProbes `json:"probes,omitempty"`
 }
 
 type AppType string
@@ -79,9 +100,35 @@ type AppType string
 const (
 	AppTypeStreamlit AppType = "Streamlit"
 	AppTypeDash      AppType = "Dash"
-	AppTypeUnknown   AppType = "Unknown"
+	AppTypeGradio    AppType = "Gradio"
+	AppTypePanel     AppType = "Panel"
+	// AppTypeShiny is Shiny for Python
+	AppTypeShiny AppType = "Shiny"
+	// AppTypeVoila serves a Jupyter notebook as an app
+	AppTypeVoila AppType = "Voila"
+	AppTypeBokeh AppType = "Bokeh"
+	// AppTypeCustom runs app with the command & port set in app spec, such as FastAPI, Flask, Node or R apps
+	AppTypeCustom  AppType = "Custom"
+	AppTypeUnknown AppType = "Unknown"
 )
 
+// CustomApp is how to run the app container of an app whose type is Custom.
+type CustomApp struct {
+	// Command is entrypoint of app container. Image entrypoint is used if empty.
+	// $(VAR) references to app env vars, such as BASE_DIR & MAIN_FILE, are expanded.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args is arguments of app container. Image cmd is used if empty.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Port is the port app listens on, which gateway proxies requests to.
+	Port int32 `json:"port"`
+	// BasePathEnvVarName is the env var that app reads the url path it is served under from. If empty, gateway
+	// strips the path from requests, so that app is served at root path.
+	// +optional
+	BasePathEnvVarName string `json:"basePathEnvVarName,omitempty"`
+}
+
 type SourceType string
 
 const (
@@ -89,19 +136,150 @@ const (
 	SourceTypeGit SourceType = "Git"
 	// SourceTypeFileSystem means the app source code is coming from mounted file system
 	SourceTypeFileSystem SourceType = "FileSystem"
+	// SourceTypeImage means the app source code and dependencies are baked into the app image
+	SourceTypeImage SourceType = "Image"
 	// SourceTypeUnknown means the app source type is unknown
 	SourceTypeUnknown = "Unknown"
 )
 
+type GitRefType string
+
+const (
+	GitRefTypeBranch GitRefType = "Branch"
+	GitRefTypeTag    GitRefType = "Tag"
+	// GitRefTypeCommit means GitRef is a full commit SHA
+	GitRefTypeCommit GitRefType = "Commit"
+)
+
 type GitConfig struct {
 	// Url to clone git repo when app source type is git
 	GitUrl string `json:"gitUrl"`
-	// If true, GitRef refers to tag instead of branch name
+	// If true, GitRef refers to tag instead of branch name. Only used if RefType is not set.
 	IsTag bool `json:"isTag"`
-	// Branch or tag name depending on isTag
+	// RefType tells whether GitRef is a branch, tag or commit SHA. Defaults to Tag if IsTag is true, Branch otherwise.
+	// +optional
+	RefType GitRefType `json:"refType,omitempty"`
+	// Branch, tag or commit SHA depending on refType
 	GitRef string `json:"gitRef"`
+	// Branch is a branch that contains the commit, which git-sync fetches to check the commit out.
+	// Only used for commit refs. Defaults to the default branch of git repo.
+	// +optional
+	Branch string `json:"branch,omitempty"`
 	// Secret name containing git token. Must be in the same namespace as TinyApp object.
 	TokenSecretName string `json:"tokenSecretName"`
+	// SyncInterval is how often controller checks the branch for new commits. App pods are rolled onto the new
+	// commit when branch moves. Branch is only checked on deploy & restart if empty. Only used for branches.
+	// +optional
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+}
+
+// GetRefType returns the type of GitRef, falling back to IsTag for specs without ref type.
+func (g *GitConfig) GetRefType() GitRefType {
+	if g.RefType != "" {
+		return g.RefType
+	}
+	if g.IsTag {
+		return GitRefTypeTag
+	}
+	return GitRefTypeBranch
+}
+
+// AppResources holds compute resources for the app container.
+// Values are Kubernetes quantities (ex. 500m, 2Gi).
+type AppResources struct {
+	CPURequest    string `json:"cpuRequest,omitempty"`
+	CPULimit      string `json:"cpuLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
+}
+
+// AutoscalingPolicy configures horizontal scaling of app pods based on CPU utilization.
+type AutoscalingPolicy struct {
+	// MinReplicas is the lower bound for number of app pods. Defaults to 1.
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper bound for number of app pods.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilizationPercentage is the average CPU utilization, relative to CPU request, to scale at.
+	// Defaults to 80.
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+}
+
+// AppAuth holds users & groups allowed to use app.
+// Any logged-in user is allowed if both are empty.
+type AppAuth struct {
+	AllowedUsers  []string `json:"allowedUsers,omitempty"`
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
+}
+
+// AppProbes overrides startup, readiness & liveness probes of app container.
+type AppProbes struct {
+	// Disabled turns off app container probes, for apps without an HTTP endpoint that responds while app is healthy.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// Path is url path, relative to app base path, that responds with 2xx or 3xx status while app is healthy.
+	// Defaults to health path of app type.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Startup probe holds off readiness & liveness probes until app is up, e.g. while requirements are installed.
+	// +optional
+	Startup *ProbeSettings `json:"startup,omitempty"`
+	// Readiness probe decides whether requests are routed to app pod.
+	// +optional
+	Readiness *ProbeSettings `json:"readiness,omitempty"`
+	// Liveness probe restarts app container if app stops responding.
+	// +optional
+	Liveness *ProbeSettings `json:"liveness,omitempty"`
+}
+
+// ProbeSettings holds timing & thresholds of a probe. Controller defaults are used for any value that is not set.
+type ProbeSettings struct {
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// FailureThreshold is how many probes in a row must fail for probe to fail.
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// AppAccess holds users & groups allowed to manage app, in addition to admins.
+type AppAccess struct {
+	// Creator is the user who created app. Creator is always an owner.
+	Creator string `json:"creator,omitempty"`
+	// Owners are users who can manage app.
+	Owners []string `json:"owners,omitempty"`
+	// Groups whose members can manage app.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// IsOwner returns true if user, or any of given groups, is allowed to manage app.
+func (a *AppAccess) IsOwner(username string, groups []string) bool {
+	if a == nil {
+		return false
+	}
+
+	if username != "" {
+		if a.Creator == username {
+			return true
+		}
+		for _, owner := range a.Owners {
+			if owner == username {
+				return true
+			}
+		}
+	}
+
+	for _, group := range a.Groups {
+		for _, userGroup := range groups {
+			if group == userGroup {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 type Volume struct {
@@ -128,6 +306,10 @@ const (
 	TinyAppDeployed TinyAppPhase = "Deployed"
 	// TinyAppFailed means that some or all of the underlying resources failed to be set up successfully.
 	TinyAppFailed TinyAppPhase = "Failed"
+	// TinyAppSleeping means the app has been scaled to zero due to inactivity.
+	TinyAppSleeping TinyAppPhase = "Sleeping"
+	// TinyAppProgressing means the app is being rolled out and is not fully available yet.
+	TinyAppProgressing TinyAppPhase = "Progressing"
 )
 
 type TinyAppConditionType string
@@ -136,18 +318,89 @@ const (
 	DeploymentCreated TinyAppConditionType = "DeploymentCreated"
 	ServiceCreated    TinyAppConditionType = "ServiceCreated"
 	IngressCreated    TinyAppConditionType = "IngressCreated"
+	// HorizontalPodAutoscalerCreated is also true when autoscaling is not enabled and there is nothing to create.
+	HorizontalPodAutoscalerCreated TinyAppConditionType = "HorizontalPodAutoscalerCreated"
+	// Available is true when all desired app pods are ready to serve requests.
+	Available TinyAppConditionType = "Available"
+	// Progressing is true while app rollout is in progress or has completed, and false when it has failed.
+	Progressing TinyAppConditionType = "Progressing"
+	// SourceSynced is true when app source code has been fetched into app pods.
+	SourceSynced TinyAppConditionType = "SourceSynced"
+	// SpecValid is false when app spec is invalid, in which case nothing is deployed for it.
+	SpecValid TinyAppConditionType = "SpecValid"
+)
+
+// Condition reasons
+const (
+	ReasonMinimumReplicasAvailable   = "MinimumReplicasAvailable"
+	ReasonMinimumReplicasUnavailable = "MinimumReplicasUnavailable"
+	ReasonScaledToZero               = "ScaledToZero"
+	ReasonRolloutInProgress          = "RolloutInProgress"
+	ReasonRolloutComplete            = "RolloutComplete"
+	ReasonProgressDeadlineExceeded   = "ProgressDeadlineExceeded"
+	ReasonGitCloned                  = "GitCloned"
+	ReasonSourceMounted              = "SourceMounted"
+	ReasonSourceInImage              = "SourceInImage"
+	ReasonInvalidSpec                = "InvalidSpec"
+	// Pod failures
+	ReasonImagePullBackOff        = "ImagePullBackOff"
+	ReasonGitCloneFailed          = "GitCloneFailed"
+	ReasonOOMKilled               = "OOMKilled"
+	ReasonDependencyInstallFailed = "DependencyInstallFailed"
+	ReasonCrashLoopBackOff        = "CrashLoopBackOff"
+	ReasonContainerConfigError    = "CreateContainerConfigError"
 )
 
 // TinyAppStatus defines the observed state of TinyApp
 type TinyAppStatus struct {
 	// +optional
 	Phase TinyAppPhase `json:"phase"`
+	// ObservedGeneration is the most recent generation of TinyApp spec reconciled by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Important: Run "make" to regenerate code after modifying this file
 	// Represents the latest available observations of a deployment's current state.
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions []*Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// Replicas is the number of app pods observed on the deployment. Used by the scale subresource.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// Selector is the label selector for app pods. Used by the scale subresource.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+	// ReadyReplicas is the number of ready app pods observed on the deployment.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// LastRequestTime is the last time app received a request, as reported by app gateways.
+	// Only tracked when idle timeout is set.
+	// +optional
+	LastRequestTime *metav1.Time `json:"lastRequestTime,omitempty"`
+	// SleepingSince is set while app is scaled to zero due to inactivity.
+	// +optional
+	SleepingSince *metav1.Time `json:"sleepingSince,omitempty"`
+	// GitCommit is the commit of app source checked out by the newest app pod. Only set for git sources.
+	// +optional
+	GitCommit string `json:"gitCommit,omitempty"`
+	// ResolvedGitCommit is the commit SHA that git ref was resolved to, which app pods check out.
+	// Branches & tags are resolved when app spec changes, on restart and every git sync interval.
+	// +optional
+	ResolvedGitCommit string `json:"resolvedGitCommit,omitempty"`
+	// GitResolvedTime is when controller last resolved git ref.
+	// +optional
+	GitResolvedTime *metav1.Time `json:"gitResolvedTime,omitempty"`
+	// ResolvedGitBranch is the default branch of git repo, which git-sync fetches commit refs from when
+	// gitConfig.branch is empty.
+	// +optional
+	ResolvedGitBranch string `json:"resolvedGitBranch,omitempty"`
+	// ImageDigest is the digest that app image was resolved to, which app pods run. Only set for image sources.
+	// Image tags are resolved when app spec changes and on restart.
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// ImageResolvedTime is when controller last resolved app image.
+	// +optional
+	ImageResolvedTime *metav1.Time `json:"imageResolvedTime,omitempty"`
 }
 
 type Condition struct {
@@ -164,23 +417,21 @@ type Condition struct {
 }
 
 func (s *TinyAppStatus) InitConditions() {
-	if len(s.Conditions) > 0 {
-		return // Already initialized
-	}
-
 	conditionTypes := []TinyAppConditionType{
-		DeploymentCreated, ServiceCreated, IngressCreated,
+		SpecValid, DeploymentCreated, ServiceCreated, IngressCreated, HorizontalPodAutoscalerCreated,
+		Available, Progressing, SourceSynced,
 	}
 
-	var conditions []*Condition
 	for _, ct := range conditionTypes {
-		conditions = append(conditions, &Condition{
+		if s.GetCondition(ct) != nil {
+			continue // Already initialized
+		}
+
+		s.Conditions = append(s.Conditions, &Condition{
 			Type:   ct,
 			Status: v1.ConditionUnknown,
 		})
 	}
-
-	s.Conditions = conditions
 }
 
 func (s *TinyAppStatus) SetConditionTrue(conditionType TinyAppConditionType) {
@@ -205,6 +456,23 @@ func (s *TinyAppStatus) SetConditionFalseWithMessage(conditionType TinyAppCondit
 	}
 }
 
+// SetCondition sets status, reason & message of a condition.
+// Transition time is only updated when condition status changes.
+func (s *TinyAppStatus) SetCondition(conditionType TinyAppConditionType, status v1.ConditionStatus, reason, message string) {
+	c := s.GetCondition(conditionType)
+	if c == nil {
+		c = &Condition{Type: conditionType}
+		s.Conditions = append(s.Conditions, c)
+	}
+
+	if c.Status != status {
+		c.LastTransitionTime = metav1.NewTime(time.Now())
+	}
+	c.Status = status
+	c.Reason = reason
+	c.Message = message
+}
+
 func (s *TinyAppStatus) GetCondition(conditionType TinyAppConditionType) *Condition {
 	for _, c := range s.Conditions {
 		if c.Type == conditionType {
@@ -223,12 +491,48 @@ func (s *TinyAppStatus) IsConditionTrue(conditionType TinyAppConditionType) bool
 	return false
 }
 
+// GetPhase sums up conditions into a phase. App is progressing until its conditions are known.
 func (s *TinyAppStatus) GetPhase() TinyAppPhase {
 	for _, c := range s.Conditions {
-		if c.Status != v1.ConditionTrue {
+		if c.Type == Available || c.Type == SourceSynced {
+			// Judged below, since these are expected to be false while app is starting up or asleep
+			continue
+		}
+		if c.Status == v1.ConditionFalse {
 			return TinyAppFailed
 		}
 	}
 
+	if s.IsSleeping() {
+		return TinyAppSleeping
+	}
+
+	if s.IsConditionFalse(SourceSynced) {
+		return TinyAppFailed
+	}
+
+	if progressing := s.GetCondition(Progressing); progressing != nil && progressing.Reason == ReasonRolloutInProgress {
+		return TinyAppProgressing
+	}
+
+	if s.IsConditionFalse(Available) {
+		return TinyAppFailed
+	}
+
+	if !s.IsConditionTrue(Available) {
+		// Not known yet whether app is available
+		return TinyAppProgressing
+	}
+
 	return TinyAppDeployed
 }
+
+func (s *TinyAppStatus) IsConditionFalse(conditionType TinyAppConditionType) bool {
+	c := s.GetCondition(conditionType)
+	return c != nil && c.Status == v1.ConditionFalse
+}
+
+// IsSleeping returns true if app is scaled to zero due to inactivity.
+func (s *TinyAppStatus) IsSleeping() bool {
+	return s.SleepingSince != nil
+}
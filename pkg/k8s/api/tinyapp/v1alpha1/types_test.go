@@ -0,0 +1,150 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPhase(t *testing.T) {
+	condition := func(conditionType TinyAppConditionType, status v1.ConditionStatus, reason string) *Condition {
+		return &Condition{Type: conditionType, Status: status, Reason: reason}
+	}
+	created := []*Condition{
+		condition(SpecValid, v1.ConditionTrue, ""),
+		condition(DeploymentCreated, v1.ConditionTrue, ""),
+		condition(ServiceCreated, v1.ConditionTrue, ""),
+		condition(IngressCreated, v1.ConditionTrue, ""),
+	}
+	withCreated := func(conditions ...*Condition) []*Condition {
+		return append(append([]*Condition{}, created...), conditions...)
+	}
+
+	tests := []struct {
+		name       string
+		conditions []*Condition
+		sleeping   bool
+		want       TinyAppPhase
+	}{
+		{
+			name: "no conditions",
+			want: TinyAppProgressing,
+		},
+		{
+			name:       "resources created, rollout not checked yet",
+			conditions: created,
+			want:       TinyAppProgressing,
+		},
+		{
+			name:       "available unknown",
+			conditions: withCreated(condition(Available, v1.ConditionUnknown, ""), condition(Progressing, v1.ConditionTrue, ReasonRolloutComplete)),
+			want:       TinyAppProgressing,
+		},
+		{
+			name:       "progressing unknown",
+			conditions: withCreated(condition(Progressing, v1.ConditionUnknown, "")),
+			want:       TinyAppProgressing,
+		},
+		{
+			name:       "resource not created yet",
+			conditions: []*Condition{condition(DeploymentCreated, v1.ConditionUnknown, "")},
+			want:       TinyAppProgressing,
+		},
+		{
+			name: "rollout in progress",
+			conditions: withCreated(
+				condition(Available, v1.ConditionFalse, ReasonMinimumReplicasUnavailable),
+				condition(Progressing, v1.ConditionTrue, ReasonRolloutInProgress),
+			),
+			want: TinyAppProgressing,
+		},
+		{
+			name: "deployed",
+			conditions: withCreated(
+				condition(Available, v1.ConditionTrue, ReasonMinimumReplicasAvailable),
+				condition(Progressing, v1.ConditionTrue, ReasonRolloutComplete),
+				condition(SourceSynced, v1.ConditionTrue, ReasonGitCloned),
+			),
+			want: TinyAppDeployed,
+		},
+		{
+			name:       "available before rollout checked",
+			conditions: withCreated(condition(Available, v1.ConditionTrue, ReasonMinimumReplicasAvailable)),
+			want:       TinyAppDeployed,
+		},
+		{
+			name: "unavailable after rollout",
+			conditions: withCreated(
+				condition(Available, v1.ConditionFalse, ReasonMinimumReplicasUnavailable),
+				condition(Progressing, v1.ConditionTrue, ReasonRolloutComplete),
+			),
+			want: TinyAppFailed,
+		},
+		{
+			name: "pod failure",
+			conditions: withCreated(
+				condition(Available, v1.ConditionFalse, ReasonCrashLoopBackOff),
+				condition(Progressing, v1.ConditionFalse, ReasonCrashLoopBackOff),
+			),
+			want: TinyAppFailed,
+		},
+		{
+			name:       "resource creation failed",
+			conditions: []*Condition{condition(DeploymentCreated, v1.ConditionFalse, "")},
+			want:       TinyAppFailed,
+		},
+		{
+			name:       "invalid spec",
+			conditions: []*Condition{condition(SpecValid, v1.ConditionFalse, ReasonInvalidSpec)},
+			want:       TinyAppFailed,
+		},
+		{
+			name: "source sync failed",
+			conditions: withCreated(
+				condition(Available, v1.ConditionTrue, ReasonMinimumReplicasAvailable),
+				condition(Progressing, v1.ConditionTrue, ReasonRolloutInProgress),
+				condition(SourceSynced, v1.ConditionFalse, ReasonGitCloneFailed),
+			),
+			want: TinyAppFailed,
+		},
+		{
+			name: "sleeping",
+			conditions: withCreated(
+				condition(Available, v1.ConditionFalse, ReasonScaledToZero),
+				condition(Progressing, v1.ConditionTrue, ReasonRolloutComplete),
+				condition(SourceSynced, v1.ConditionFalse, ReasonGitCloneFailed),
+			),
+			sleeping: true,
+			want:     TinyAppSleeping,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := &TinyAppStatus{Conditions: test.conditions}
+			if test.sleeping {
+				status.SleepingSince = &metav1.Time{}
+			}
+			if got := status.GetPhase(); got != test.want {
+				t.Errorf("got phase %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -6,10 +6,136 @@
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppAccess) DeepCopyInto(out *AppAccess) {
+	*out = *in
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppAccess.
+func (in *AppAccess) DeepCopy() *AppAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(AppAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppAuth) DeepCopyInto(out *AppAuth) {
+	*out = *in
+	if in.AllowedUsers != nil {
+		in, out := &in.AllowedUsers, &out.AllowedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedGroups != nil {
+		in, out := &in.AllowedGroups, &out.AllowedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppAuth.
+func (in *AppAuth) DeepCopy() *AppAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(AppAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppProbes) DeepCopyInto(out *AppProbes) {
+	*out = *in
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(ProbeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ProbeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(ProbeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppProbes.
+func (in *AppProbes) DeepCopy() *AppProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(AppProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppResources) DeepCopyInto(out *AppResources) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppResources.
+func (in *AppResources) DeepCopy() *AppResources {
+	if in == nil {
+		return nil
+	}
+	out := new(AppResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicy) DeepCopyInto(out *AutoscalingPolicy) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicy.
+func (in *AutoscalingPolicy) DeepCopy() *AutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -27,9 +153,40 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomApp) DeepCopyInto(out *CustomApp) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomApp.
+func (in *CustomApp) DeepCopy() *CustomApp {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomApp)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitConfig) DeepCopyInto(out *GitConfig) {
 	*out = *in
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -43,6 +200,42 @@ func (in *GitConfig) DeepCopy() *GitConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSettings) DeepCopyInto(out *ProbeSettings) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSettings.
+func (in *ProbeSettings) DeepCopy() *ProbeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TinyApp) DeepCopyInto(out *TinyApp) {
 	*out = *in
@@ -107,18 +300,23 @@ func (in *TinyAppList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TinyAppSpec) DeepCopyInto(out *TinyAppSpec) {
 	*out = *in
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomApp)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GitConfig != nil {
 		in, out := &in.GitConfig, &out.GitConfig
 		*out = new(GitConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.EnvVars != nil {
 		in, out := &in.EnvVars, &out.EnvVars
-		*out = make([]*v1.EnvVar, len(*in))
+		*out = make([]*corev1.EnvVar, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(v1.EnvVar)
+				*out = new(corev1.EnvVar)
 				(*in).DeepCopyInto(*out)
 			}
 		}
@@ -134,6 +332,41 @@ func (in *TinyAppSpec) DeepCopyInto(out *TinyAppSpec) {
 			}
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(AppResources)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = new(AppAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(AppAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(AppProbes)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -161,6 +394,22 @@ func (in *TinyAppStatus) DeepCopyInto(out *TinyAppStatus) {
 			}
 		}
 	}
+	if in.LastRequestTime != nil {
+		in, out := &in.LastRequestTime, &out.LastRequestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SleepingSince != nil {
+		in, out := &in.SleepingSince, &out.SleepingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.GitResolvedTime != nil {
+		in, out := &in.GitResolvedTime, &out.GitResolvedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ImageResolvedTime != nil {
+		in, out := &in.ImageResolvedTime, &out.ImageResolvedTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -0,0 +1,486 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package validation checks TinyApp objects before they are stored or reconciled.
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/controller/image"
+	"github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// BuiltInAppTypes are app types that controller has runtimes for without app runtimes ConfigMap.
+var BuiltInAppTypes = []string{string(v1alpha1.AppTypeStreamlit), string(v1alpha1.AppTypeDash),
+	string(v1alpha1.AppTypeGradio), string(v1alpha1.AppTypePanel), string(v1alpha1.AppTypeShiny),
+	string(v1alpha1.AppTypeVoila), string(v1alpha1.AppTypeBokeh), string(v1alpha1.AppTypeCustom)}
+
+// Supported values, in the order they are listed in error messages
+var (
+	supportedSourceTypes = []string{string(v1alpha1.SourceTypeGit), string(v1alpha1.SourceTypeFileSystem), string(v1alpha1.SourceTypeImage)}
+	supportedGitRefTypes = []string{string(v1alpha1.GitRefTypeBranch), string(v1alpha1.GitRefTypeTag), string(v1alpha1.GitRefTypeCommit)}
+)
+
+// Full SHA-1 or SHA-256 commit hash
+var commitSHARegexp = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// Shortest git sync interval, so that git hosts aren't polled too often
+const minGitSyncInterval = 10 * time.Second
+
+// Pod volumes added by controller, which volume claims can't be named after
+var reservedVolumeNames = map[string]bool{
+	util.GitCloneVolumeName:        true,
+	util.GitTokenVolumeName:        true,
+	globalutil.TLSSecretVolumeName: true,
+}
+
+// ValidateTinyApp returns everything that is wrong with TinyApp spec.
+// App type must be one of supported app types, which are the ones controller has app runtimes for.
+func ValidateTinyApp(app *v1alpha1.TinyApp, supportedAppTypes []string) field.ErrorList {
+	return ValidateTinyAppSpec(&app.Spec, field.NewPath("spec"), supportedAppTypes)
+}
+
+// ValidateTinyAppSpec returns everything that is wrong with TinyApp spec at given path.
+func ValidateTinyAppSpec(spec *v1alpha1.TinyAppSpec, specPath *field.Path, supportedAppTypes []string) field.ErrorList {
+	var errs field.ErrorList
+
+	if !contains(supportedAppTypes, string(spec.AppType)) {
+		errs = append(errs, field.NotSupported(specPath.Child("appType"), spec.AppType, supportedAppTypes))
+	}
+
+	if spec.AppType == v1alpha1.AppTypeCustom {
+		errs = append(errs, validateCustomApp(spec.Custom, specPath.Child("custom"))...)
+	} else if spec.Custom != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("custom"), "only allowed for Custom app type"))
+	}
+
+	// Custom apps are started by their own command, which may not need a main file
+	if spec.MainFilePath == "" {
+		if spec.AppType != v1alpha1.AppTypeCustom {
+			errs = append(errs, field.Required(specPath.Child("mainFile"), ""))
+		}
+	} else if path.IsAbs(spec.MainFilePath) {
+		errs = append(errs, field.Invalid(specPath.Child("mainFile"), spec.MainFilePath, "must be relative to app base directory"))
+	}
+
+	errs = append(errs, validateVolumeClaims(spec, specPath.Child("volumeClaims"))...)
+
+	switch spec.SourceType {
+	case v1alpha1.SourceTypeGit:
+		errs = append(errs, validateGitConfig(spec.GitConfig, specPath.Child("gitConfig"))...)
+	case v1alpha1.SourceTypeFileSystem:
+		errs = append(errs, validateMainVolumeClaim(spec, specPath.Child("mainVolumeClaimName"))...)
+	case v1alpha1.SourceTypeImage:
+		errs = append(errs, validateSourceImage(spec.Image, specPath.Child("image"))...)
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("sourceType"), spec.SourceType, supportedSourceTypes))
+	}
+
+	errs = append(errs, validateEnvVars(spec, specPath.Child("envVars"))...)
+	errs = append(errs, validateResources(spec.Resources, specPath.Child("resources"))...)
+
+	if spec.Replicas != nil && *spec.Replicas < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("replicas"), *spec.Replicas, "must not be negative"))
+	}
+
+	errs = append(errs, validateAutoscaling(spec.Autoscaling, specPath.Child("autoscaling"))...)
+
+	if spec.IdleTimeout != nil && spec.IdleTimeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(specPath.Child("idleTimeout"), spec.IdleTimeout.Duration.String(), "must be positive"))
+	}
+
+	if spec.Auth != nil {
+		errs = append(errs, validateNames(spec.Auth.AllowedUsers, specPath.Child("auth", "allowedUsers"))...)
+		errs = append(errs, validateNames(spec.Auth.AllowedGroups, specPath.Child("auth", "allowedGroups"))...)
+	}
+
+	errs = append(errs, validateProbes(spec.Probes, specPath.Child("probes"))...)
+
+	return errs
+}
+
+func validateGitConfig(gitConfig *v1alpha1.GitConfig, gitPath *field.Path) field.ErrorList {
+	if gitConfig == nil {
+		return field.ErrorList{field.Required(gitPath, "required when sourceType is Git")}
+	}
+
+	var errs field.ErrorList
+
+	if gitConfig.GitUrl == "" {
+		errs = append(errs, field.Required(gitPath.Child("gitUrl"), ""))
+	} else if gitUrl, err := url.Parse(gitConfig.GitUrl); err != nil || (gitUrl.Scheme != "http" && gitUrl.Scheme != "https") || gitUrl.Host == "" {
+		errs = append(errs, field.Invalid(gitPath.Child("gitUrl"), gitConfig.GitUrl, "must be an http or https url"))
+	}
+
+	if gitConfig.RefType != "" && !contains(supportedGitRefTypes, string(gitConfig.RefType)) {
+		errs = append(errs, field.NotSupported(gitPath.Child("refType"), gitConfig.RefType, supportedGitRefTypes))
+	}
+
+	if gitConfig.GitRef == "" {
+		errs = append(errs, field.Required(gitPath.Child("gitRef"), "branch, tag or commit is required"))
+	} else if gitConfig.RefType == v1alpha1.GitRefTypeCommit && !commitSHARegexp.MatchString(gitConfig.GitRef) {
+		errs = append(errs, field.Invalid(gitPath.Child("gitRef"), gitConfig.GitRef, "must be a full commit SHA in lower case"))
+	}
+
+	if gitConfig.Branch != "" && gitConfig.GetRefType() != v1alpha1.GitRefTypeCommit {
+		errs = append(errs, field.Forbidden(gitPath.Child("branch"), "only used when refType is Commit"))
+	}
+
+	// Git token is always mounted into git-sync
+	if gitConfig.TokenSecretName == "" {
+		errs = append(errs, field.Required(gitPath.Child("tokenSecretName"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(gitConfig.TokenSecretName) {
+			errs = append(errs, field.Invalid(gitPath.Child("tokenSecretName"), gitConfig.TokenSecretName, msg))
+		}
+	}
+
+	if gitConfig.SyncInterval != nil && gitConfig.SyncInterval.Duration < minGitSyncInterval {
+		errs = append(errs, field.Invalid(gitPath.Child("syncInterval"), gitConfig.SyncInterval.Duration.String(),
+			fmt.Sprintf("must be at least %s", minGitSyncInterval)))
+	}
+
+	return errs
+}
+
+func validateMainVolumeClaim(spec *v1alpha1.TinyAppSpec, claimPath *field.Path) field.ErrorList {
+	if spec.MainVolumeClaimName == "" {
+		return field.ErrorList{field.Required(claimPath, "required when sourceType is FileSystem")}
+	}
+
+	for _, volumeClaim := range spec.VolumeClaims {
+		if volumeClaim != nil && volumeClaim.Name == spec.MainVolumeClaimName {
+			return nil
+		}
+	}
+
+	return field.ErrorList{field.Invalid(claimPath, spec.MainVolumeClaimName, "must be one of volumeClaims")}
+}
+
+// validateSourceImage validates image that app is baked into. It isn't defaulted, since default image has no app code.
+func validateSourceImage(sourceImage string, imagePath *field.Path) field.ErrorList {
+	if sourceImage == "" {
+		return field.ErrorList{field.Required(imagePath, "required when sourceType is Image")}
+	}
+
+	if _, err := image.ParseReference(sourceImage); err != nil {
+		return field.ErrorList{field.Invalid(imagePath, sourceImage, err.Error())}
+	}
+
+	return nil
+}
+
+func validateVolumeClaims(spec *v1alpha1.TinyAppSpec, claimsPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	names := make(map[string]bool)
+	mountPaths := make(map[string]bool)
+	// Git source is cloned here
+	if spec.SourceType == v1alpha1.SourceTypeGit {
+		mountPaths[util.GitRootDir] = true
+	}
+
+	for i, volumeClaim := range spec.VolumeClaims {
+		claimPath := claimsPath.Index(i)
+		if volumeClaim == nil {
+			errs = append(errs, field.Required(claimPath, ""))
+			continue
+		}
+
+		// Claim name is also used as pod volume name
+		if volumeClaim.Name == "" {
+			errs = append(errs, field.Required(claimPath.Child("name"), ""))
+		} else if names[volumeClaim.Name] {
+			errs = append(errs, field.Duplicate(claimPath.Child("name"), volumeClaim.Name))
+		} else if reservedVolumeNames[volumeClaim.Name] {
+			errs = append(errs, field.Invalid(claimPath.Child("name"), volumeClaim.Name, "name is reserved"))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(volumeClaim.Name) {
+				errs = append(errs, field.Invalid(claimPath.Child("name"), volumeClaim.Name, msg))
+			}
+		}
+		names[volumeClaim.Name] = true
+
+		if volumeClaim.MountPath == "" {
+			errs = append(errs, field.Required(claimPath.Child("mountPath"), ""))
+			continue
+		}
+
+		if !path.IsAbs(volumeClaim.MountPath) {
+			errs = append(errs, field.Invalid(claimPath.Child("mountPath"), volumeClaim.MountPath, "must be an absolute path"))
+		}
+
+		mountPath := path.Clean(volumeClaim.MountPath)
+		if mountPaths[mountPath] {
+			errs = append(errs, field.Duplicate(claimPath.Child("mountPath"), volumeClaim.MountPath))
+		}
+		mountPaths[mountPath] = true
+
+		if path.IsAbs(volumeClaim.SubPath) {
+			errs = append(errs, field.Invalid(claimPath.Child("subPath"), volumeClaim.SubPath, "must be a relative path"))
+		}
+	}
+
+	return errs
+}
+
+func validateCustomApp(custom *v1alpha1.CustomApp, customPath *field.Path) field.ErrorList {
+	if custom == nil {
+		return field.ErrorList{field.Required(customPath, "")}
+	}
+
+	var errs field.ErrorList
+
+	portPath := customPath.Child("port")
+	for _, msg := range validation.IsValidPortNum(int(custom.Port)) {
+		errs = append(errs, field.Invalid(portPath, custom.Port, msg))
+	}
+	if custom.Port == globalutil.DefaultGatewayPort || custom.Port == globalutil.DefaultGatewayAdminPort {
+		errs = append(errs, field.Invalid(portPath, custom.Port, "port is used by gateway"))
+	}
+
+	if custom.BasePathEnvVarName != "" {
+		for _, msg := range validation.IsEnvVarName(custom.BasePathEnvVarName) {
+			errs = append(errs, field.Invalid(customPath.Child("basePathEnvVarName"), custom.BasePathEnvVarName, msg))
+		}
+	}
+
+	return errs
+}
+
+func validateEnvVars(spec *v1alpha1.TinyAppSpec, envPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	names := make(map[string]bool)
+	for i, envVar := range spec.EnvVars {
+		if envVar == nil {
+			errs = append(errs, field.Required(envPath.Index(i), ""))
+			continue
+		}
+
+		namePath := envPath.Index(i).Child("name")
+		if envVar.Name == "" {
+			errs = append(errs, field.Required(namePath, ""))
+			continue
+		}
+
+		for _, msg := range validation.IsEnvVarName(envVar.Name) {
+			errs = append(errs, field.Invalid(namePath, envVar.Name, msg))
+		}
+
+		if names[envVar.Name] {
+			errs = append(errs, field.Duplicate(namePath, envVar.Name))
+		}
+		names[envVar.Name] = true
+	}
+
+	return errs
+}
+
+func validateResources(resources *v1alpha1.AppResources, resourcesPath *field.Path) field.ErrorList {
+	if resources == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	pairs := []struct {
+		name, request, limit string
+	}{
+		{name: "cpu", request: resources.CPURequest, limit: resources.CPULimit},
+		{name: "memory", request: resources.MemoryRequest, limit: resources.MemoryLimit},
+	}
+
+	for _, pair := range pairs {
+		requestPath := resourcesPath.Child(pair.name + "Request")
+		limitPath := resourcesPath.Child(pair.name + "Limit")
+
+		request, requestErrs := parseQuantity(pair.request, requestPath)
+		limit, limitErrs := parseQuantity(pair.limit, limitPath)
+		errs = append(errs, requestErrs...)
+		errs = append(errs, limitErrs...)
+
+		if request != nil && limit != nil && request.Cmp(*limit) > 0 {
+			errs = append(errs, field.Invalid(requestPath, pair.request, fmt.Sprintf("must not exceed %sLimit %s", pair.name, pair.limit)))
+		}
+	}
+
+	return errs
+}
+
+// ValidateResourceMaximums returns resources of TinyApp spec at given path that exceed maximums operators allow apps to
+// request. Empty maximum is unbounded.
+func ValidateResourceMaximums(spec *v1alpha1.TinyAppSpec, specPath *field.Path, maxCPU, maxMemory string) field.ErrorList {
+	if spec.Resources == nil {
+		return nil
+	}
+
+	resourcesPath := specPath.Child("resources")
+
+	var errs field.ErrorList
+
+	maximums := []struct {
+		name, max string
+		values    []string
+	}{
+		{name: "cpu", max: maxCPU, values: []string{spec.Resources.CPURequest, spec.Resources.CPULimit}},
+		{name: "memory", max: maxMemory, values: []string{spec.Resources.MemoryRequest, spec.Resources.MemoryLimit}},
+	}
+
+	for _, maximum := range maximums {
+		if maximum.max == "" {
+			continue
+		}
+
+		maxQuantity, err := resource.ParseQuantity(maximum.max)
+		if err != nil {
+			errs = append(errs, field.InternalError(resourcesPath, fmt.Errorf("invalid maximum %s %q: %s", maximum.name, maximum.max, err)))
+			continue
+		}
+
+		for i, suffix := range []string{"Request", "Limit"} {
+			valuePath := resourcesPath.Child(maximum.name + suffix)
+			// Invalid values are reported by spec validation
+			quantity, _ := parseQuantity(maximum.values[i], valuePath)
+			if quantity != nil && quantity.Cmp(maxQuantity) > 0 {
+				errs = append(errs, field.Invalid(valuePath, maximum.values[i], fmt.Sprintf("must not exceed maximum allowed %s", maximum.max)))
+			}
+		}
+	}
+
+	return errs
+}
+
+// parseQuantity parses quantity if set, returning nil if it is not set or invalid.
+func parseQuantity(value string, quantityPath *field.Path) (*resource.Quantity, field.ErrorList) {
+	if value == "" {
+		return nil, nil
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, field.ErrorList{field.Invalid(quantityPath, value, err.Error())}
+	}
+
+	if quantity.Sign() <= 0 {
+		return nil, field.ErrorList{field.Invalid(quantityPath, value, "must be positive")}
+	}
+
+	return &quantity, nil
+}
+
+func validateAutoscaling(autoscaling *v1alpha1.AutoscalingPolicy, autoscalingPath *field.Path) field.ErrorList {
+	if autoscaling == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	if autoscaling.MaxReplicas < 1 {
+		errs = append(errs, field.Invalid(autoscalingPath.Child("maxReplicas"), autoscaling.MaxReplicas, "must be at least 1"))
+	}
+
+	if autoscaling.MinReplicas != nil {
+		if *autoscaling.MinReplicas < 1 {
+			errs = append(errs, field.Invalid(autoscalingPath.Child("minReplicas"), *autoscaling.MinReplicas, "must be at least 1"))
+		} else if *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			errs = append(errs, field.Invalid(autoscalingPath.Child("minReplicas"), *autoscaling.MinReplicas, "must not exceed maxReplicas"))
+		}
+	}
+
+	if target := autoscaling.TargetCPUUtilizationPercentage; target != nil && (*target < 1 || *target > 100) {
+		errs = append(errs, field.Invalid(autoscalingPath.Child("targetCPUUtilizationPercentage"), *target, "must be between 1 and 100"))
+	}
+
+	return errs
+}
+
+func validateProbes(probes *v1alpha1.AppProbes, probesPath *field.Path) field.ErrorList {
+	if probes == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	if probes.Path != "" {
+		if probePath, err := url.Parse(probes.Path); err != nil || probePath.Path != probes.Path {
+			errs = append(errs, field.Invalid(probesPath.Child("path"), probes.Path, "must be a url path"))
+		}
+	}
+
+	errs = append(errs, validateProbeSettings(probes.Startup, probesPath.Child("startup"))...)
+	errs = append(errs, validateProbeSettings(probes.Readiness, probesPath.Child("readiness"))...)
+	errs = append(errs, validateProbeSettings(probes.Liveness, probesPath.Child("liveness"))...)
+
+	return errs
+}
+
+func validateProbeSettings(settings *v1alpha1.ProbeSettings, settingsPath *field.Path) field.ErrorList {
+	if settings == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	if settings.InitialDelaySeconds != nil && *settings.InitialDelaySeconds < 0 {
+		errs = append(errs, field.Invalid(settingsPath.Child("initialDelaySeconds"), *settings.InitialDelaySeconds, "must not be negative"))
+	}
+
+	positiveSettings := []struct {
+		name  string
+		value *int32
+	}{
+		{"periodSeconds", settings.PeriodSeconds},
+		{"timeoutSeconds", settings.TimeoutSeconds},
+		{"failureThreshold", settings.FailureThreshold},
+	}
+	for _, setting := range positiveSettings {
+		if setting.value != nil && *setting.value < 1 {
+			errs = append(errs, field.Invalid(settingsPath.Child(setting.name), *setting.value, "must be at least 1"))
+		}
+	}
+
+	return errs
+}
+
+func validateNames(names []string, namesPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, name := range names {
+		if name == "" {
+			errs = append(errs, field.Required(namesPath.Index(i), "must not be empty"))
+		}
+	}
+	return errs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,367 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+)
+
+const testCommit = "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c"
+
+// newTestSpec returns valid spec of a Streamlit app from git, changed by given function.
+func newTestSpec(change func(spec *v1alpha1.TinyAppSpec)) *v1alpha1.TinyAppSpec {
+	spec := &v1alpha1.TinyAppSpec{
+		AppType:      v1alpha1.AppTypeStreamlit,
+		SourceType:   v1alpha1.SourceTypeGit,
+		MainFilePath: "app.py",
+		GitConfig: &v1alpha1.GitConfig{
+			GitUrl:          "https://github.com/org/repo.git",
+			RefType:         v1alpha1.GitRefTypeBranch,
+			GitRef:          "main",
+			TokenSecretName: "git-token",
+		},
+	}
+	if change != nil {
+		change(spec)
+	}
+	return spec
+}
+
+// errorFields returns field & type of each error, which is what tests compare rather than messages.
+func errorFields(errs field.ErrorList) []string {
+	var fields []string
+	for _, err := range errs {
+		fields = append(fields, fmt.Sprintf("%s: %s", err.Field, err.Type))
+	}
+	return fields
+}
+
+type specTest struct {
+	name   string
+	change func(spec *v1alpha1.TinyAppSpec)
+	want   []string
+}
+
+func runSpecTests(t *testing.T, tests []specTest) {
+	t.Helper()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidateTinyAppSpec(newTestSpec(test.change), field.NewPath("spec"), append(BuiltInAppTypes, "RShiny"))
+			if got := errorFields(errs); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got errors %v, want %v (%v)", got, test.want, errs)
+			}
+		})
+	}
+}
+
+func TestValidateTinyAppSpecGitConfig(t *testing.T) {
+	runSpecTests(t, []specTest{
+		{name: "valid branch"},
+		{name: "valid tag", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.RefType, spec.GitConfig.GitRef = v1alpha1.GitRefTypeTag, "v1.0.0"
+		}},
+		{name: "valid commit", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.RefType, spec.GitConfig.GitRef, spec.GitConfig.Branch = v1alpha1.GitRefTypeCommit, testCommit, "release"
+		}},
+		{name: "legacy ref type", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.RefType = ""
+		}},
+		{name: "missing git config", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig = nil
+		}, want: []string{"spec.gitConfig: Required value"}},
+		{name: "missing url, ref & token secret", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig = &v1alpha1.GitConfig{}
+		}, want: []string{
+			"spec.gitConfig.gitUrl: Required value",
+			"spec.gitConfig.gitRef: Required value",
+			"spec.gitConfig.tokenSecretName: Required value",
+		}},
+		{name: "ssh url", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.GitUrl = "git@github.com:org/repo.git"
+		}, want: []string{"spec.gitConfig.gitUrl: Invalid value"}},
+		{name: "unknown ref type", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.RefType = "Revision"
+		}, want: []string{"spec.gitConfig.refType: Unsupported value"}},
+		{name: "short commit", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.RefType, spec.GitConfig.GitRef = v1alpha1.GitRefTypeCommit, testCommit[:7]
+		}, want: []string{"spec.gitConfig.gitRef: Invalid value"}},
+		{name: "upper case commit", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.RefType, spec.GitConfig.GitRef = v1alpha1.GitRefTypeCommit, "0D1A26E67D8F5EAF1F6BA5C57FC3C7D91AC0FD1C"
+		}, want: []string{"spec.gitConfig.gitRef: Invalid value"}},
+		{name: "branch of branch ref", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.Branch = "release"
+		}, want: []string{"spec.gitConfig.branch: Forbidden"}},
+		{name: "invalid token secret name", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.TokenSecretName = "Git_Token"
+		}, want: []string{"spec.gitConfig.tokenSecretName: Invalid value"}},
+		{name: "sync interval too short", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.GitConfig.SyncInterval = &metav1.Duration{Duration: time.Second}
+		}, want: []string{"spec.gitConfig.syncInterval: Invalid value"}},
+		{name: "unknown source type", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType = "S3"
+		}, want: []string{"spec.sourceType: Unsupported value"}},
+	})
+}
+
+func TestValidateTinyAppSpecAppType(t *testing.T) {
+	runSpecTests(t, []specTest{
+		{name: "built-in app type", change: func(spec *v1alpha1.TinyAppSpec) { spec.AppType = v1alpha1.AppTypeGradio }},
+		{name: "app type of runtimes ConfigMap", change: func(spec *v1alpha1.TinyAppSpec) { spec.AppType = "RShiny" }},
+		{name: "unsupported app type", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.AppType = "Flask"
+		}, want: []string{"spec.appType: Unsupported value"}},
+		{name: "missing main file", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.MainFilePath = ""
+		}, want: []string{"spec.mainFile: Required value"}},
+		{name: "absolute main file", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.MainFilePath = "/app/app.py"
+		}, want: []string{"spec.mainFile: Invalid value"}},
+		{name: "custom app", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.AppType, spec.MainFilePath = v1alpha1.AppTypeCustom, ""
+			spec.Custom = &v1alpha1.CustomApp{Command: []string{"node", "server.js"}, Port: 3000, BasePathEnvVarName: "BASE_URL"}
+		}},
+		{name: "custom app without custom fields", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.AppType = v1alpha1.AppTypeCustom
+		}, want: []string{"spec.custom: Required value"}},
+		{name: "custom app with invalid port", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.AppType, spec.Custom = v1alpha1.AppTypeCustom, &v1alpha1.CustomApp{Port: 70000}
+		}, want: []string{"spec.custom.port: Invalid value"}},
+		{name: "custom app on gateway port", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.AppType, spec.Custom = v1alpha1.AppTypeCustom, &v1alpha1.CustomApp{Port: 8080}
+		}, want: []string{"spec.custom.port: Invalid value"}},
+		{name: "custom app on gateway admin port", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.AppType, spec.Custom = v1alpha1.AppTypeCustom, &v1alpha1.CustomApp{Port: 8081}
+		}, want: []string{"spec.custom.port: Invalid value"}},
+		{name: "custom app with invalid base path env var", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.AppType, spec.Custom = v1alpha1.AppTypeCustom, &v1alpha1.CustomApp{Port: 3000, BasePathEnvVarName: "BASE URL"}
+		}, want: []string{"spec.custom.basePathEnvVarName: Invalid value"}},
+		{name: "custom fields of other app type", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Custom = &v1alpha1.CustomApp{Port: 3000}
+		}, want: []string{"spec.custom: Forbidden"}},
+	})
+}
+
+func TestValidateTinyAppSpecSource(t *testing.T) {
+	runSpecTests(t, []specTest{
+		{name: "image", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType, spec.GitConfig, spec.Image = v1alpha1.SourceTypeImage, nil, "registry.example.com:5000/team/app:1.0"
+		}},
+		{name: "image with digest", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType, spec.GitConfig = v1alpha1.SourceTypeImage, nil
+			spec.Image = "team/app@sha256:6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b"
+		}},
+		{name: "missing image", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType, spec.GitConfig = v1alpha1.SourceTypeImage, nil
+		}, want: []string{"spec.image: Required value"}},
+		{name: "upper case image", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType, spec.GitConfig, spec.Image = v1alpha1.SourceTypeImage, nil, "Team/App:1.0"
+		}, want: []string{"spec.image: Invalid value"}},
+		{name: "image with invalid digest", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType, spec.GitConfig, spec.Image = v1alpha1.SourceTypeImage, nil, "team/app@6b86b273"
+		}, want: []string{"spec.image: Invalid value"}},
+		{name: "file system", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType, spec.GitConfig, spec.MainVolumeClaimName = v1alpha1.SourceTypeFileSystem, nil, "code"
+			spec.VolumeClaims = []*v1alpha1.VolumeClaim{{Name: "code", MountPath: "/app"}}
+		}},
+		{name: "file system without main volume claim", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType, spec.GitConfig = v1alpha1.SourceTypeFileSystem, nil
+		}, want: []string{"spec.mainVolumeClaimName: Required value"}},
+		{name: "file system with unknown main volume claim", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.SourceType, spec.GitConfig, spec.MainVolumeClaimName = v1alpha1.SourceTypeFileSystem, nil, "code"
+		}, want: []string{"spec.mainVolumeClaimName: Invalid value"}},
+	})
+}
+
+func TestValidateTinyAppSpecVolumeClaims(t *testing.T) {
+	runSpecTests(t, []specTest{
+		{name: "volume claims", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.VolumeClaims = []*v1alpha1.VolumeClaim{{Name: "data", MountPath: "/data"}, {Name: "models", MountPath: "/models", SubPath: "v2"}}
+		}},
+		{name: "duplicate name", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.VolumeClaims = []*v1alpha1.VolumeClaim{{Name: "data", MountPath: "/data"}, {Name: "data", MountPath: "/other"}}
+		}, want: []string{"spec.volumeClaims[1].name: Duplicate value"}},
+		{name: "duplicate mount path", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.VolumeClaims = []*v1alpha1.VolumeClaim{{Name: "data", MountPath: "/data"}, {Name: "models", MountPath: "/data/"}}
+		}, want: []string{"spec.volumeClaims[1].mountPath: Duplicate value"}},
+		{name: "mount over git clone", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.VolumeClaims = []*v1alpha1.VolumeClaim{{Name: "data", MountPath: "/app"}}
+		}, want: []string{"spec.volumeClaims[0].mountPath: Duplicate value"}},
+		{name: "reserved name", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.VolumeClaims = []*v1alpha1.VolumeClaim{{Name: "git", MountPath: "/data"}}
+		}, want: []string{"spec.volumeClaims[0].name: Invalid value"}},
+		{name: "relative mount path & absolute sub path", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.VolumeClaims = []*v1alpha1.VolumeClaim{{Name: "data", MountPath: "data", SubPath: "/v2"}}
+		}, want: []string{"spec.volumeClaims[0].mountPath: Invalid value", "spec.volumeClaims[0].subPath: Invalid value"}},
+		{name: "missing name & mount path", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.VolumeClaims = []*v1alpha1.VolumeClaim{{}, nil}
+		}, want: []string{
+			"spec.volumeClaims[0].name: Required value",
+			"spec.volumeClaims[0].mountPath: Required value",
+			"spec.volumeClaims[1]: Required value",
+		}},
+	})
+}
+
+func TestValidateTinyAppSpecEnvVars(t *testing.T) {
+	runSpecTests(t, []specTest{
+		{name: "env vars", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.EnvVars = []*corev1.EnvVar{{Name: "MODE", Value: "prod"}, {Name: "_DEBUG"}}
+		}},
+		{name: "invalid & duplicate names", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.EnvVars = []*corev1.EnvVar{{Name: "MODE"}, {Name: "MODE"}, {Name: "1MODE"}, {Name: ""}, nil}
+		}, want: []string{
+			"spec.envVars[1].name: Duplicate value",
+			"spec.envVars[2].name: Invalid value",
+			"spec.envVars[3].name: Required value",
+			"spec.envVars[4]: Required value",
+		}},
+	})
+}
+
+func TestValidateTinyAppSpecResources(t *testing.T) {
+	runSpecTests(t, []specTest{
+		{name: "resources", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Resources = &v1alpha1.AppResources{CPURequest: "500m", CPULimit: "2", MemoryRequest: "1Gi", MemoryLimit: "1Gi"}
+		}},
+		{name: "invalid quantities", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Resources = &v1alpha1.AppResources{CPURequest: "lots", MemoryLimit: "0"}
+		}, want: []string{"spec.resources.cpuRequest: Invalid value", "spec.resources.memoryLimit: Invalid value"}},
+		{name: "request above limit", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Resources = &v1alpha1.AppResources{MemoryRequest: "2Gi", MemoryLimit: "1Gi"}
+		}, want: []string{"spec.resources.memoryRequest: Invalid value"}},
+	})
+}
+
+func TestValidateTinyAppSpecScaling(t *testing.T) {
+	runSpecTests(t, []specTest{
+		{name: "replicas", change: func(spec *v1alpha1.TinyAppSpec) { spec.Replicas = pointer.Int32(0) }},
+		{name: "negative replicas", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Replicas = pointer.Int32(-1)
+		}, want: []string{"spec.replicas: Invalid value"}},
+		{name: "autoscaling", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Autoscaling = &v1alpha1.AutoscalingPolicy{MinReplicas: pointer.Int32(2), MaxReplicas: 5, TargetCPUUtilizationPercentage: pointer.Int32(70)}
+		}},
+		{name: "autoscaling out of range", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Autoscaling = &v1alpha1.AutoscalingPolicy{MinReplicas: pointer.Int32(0), MaxReplicas: 0, TargetCPUUtilizationPercentage: pointer.Int32(101)}
+		}, want: []string{
+			"spec.autoscaling.maxReplicas: Invalid value",
+			"spec.autoscaling.minReplicas: Invalid value",
+			"spec.autoscaling.targetCPUUtilizationPercentage: Invalid value",
+		}},
+		{name: "autoscaling min above max", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Autoscaling = &v1alpha1.AutoscalingPolicy{MinReplicas: pointer.Int32(3), MaxReplicas: 2}
+		}, want: []string{"spec.autoscaling.minReplicas: Invalid value"}},
+		{name: "idle timeout", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.IdleTimeout = &metav1.Duration{Duration: -time.Minute}
+		}, want: []string{"spec.idleTimeout: Invalid value"}},
+		{name: "empty allowed user & group", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Auth = &v1alpha1.AppAuth{AllowedUsers: []string{"jane", ""}, AllowedGroups: []string{""}}
+		}, want: []string{"spec.auth.allowedUsers[1]: Required value", "spec.auth.allowedGroups[0]: Required value"}},
+	})
+}
+
+func TestValidateTinyAppSpecProbes(t *testing.T) {
+	runSpecTests(t, []specTest{
+		{name: "probes", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Probes = &v1alpha1.AppProbes{
+				Path:     "/healthz",
+				Startup:  &v1alpha1.ProbeSettings{InitialDelaySeconds: pointer.Int32(0), FailureThreshold: pointer.Int32(60)},
+				Liveness: &v1alpha1.ProbeSettings{PeriodSeconds: pointer.Int32(30), TimeoutSeconds: pointer.Int32(5)},
+			}
+		}},
+		{name: "disabled", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Probes = &v1alpha1.AppProbes{Disabled: true}
+		}},
+		{name: "path with query", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Probes = &v1alpha1.AppProbes{Path: "/health?full=1"}
+		}, want: []string{"spec.probes.path: Invalid value"}},
+		{name: "invalid settings", change: func(spec *v1alpha1.TinyAppSpec) {
+			spec.Probes = &v1alpha1.AppProbes{
+				Startup:   &v1alpha1.ProbeSettings{InitialDelaySeconds: pointer.Int32(-1)},
+				Readiness: &v1alpha1.ProbeSettings{PeriodSeconds: pointer.Int32(0), TimeoutSeconds: pointer.Int32(0)},
+				Liveness:  &v1alpha1.ProbeSettings{FailureThreshold: pointer.Int32(0)},
+			}
+		}, want: []string{
+			"spec.probes.startup.initialDelaySeconds: Invalid value",
+			"spec.probes.readiness.periodSeconds: Invalid value",
+			"spec.probes.readiness.timeoutSeconds: Invalid value",
+			"spec.probes.liveness.failureThreshold: Invalid value",
+		}},
+	})
+}
+
+func TestValidateResourceMaximums(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources *v1alpha1.AppResources
+		maxCPU    string
+		maxMemory string
+		want      []string
+	}{
+		{name: "no resources", maxCPU: "1", maxMemory: "1Gi"},
+		{name: "no maximums", resources: &v1alpha1.AppResources{CPULimit: "64", MemoryLimit: "1Ti"}},
+		{
+			name:      "at maximums",
+			resources: &v1alpha1.AppResources{CPURequest: "1", CPULimit: "2", MemoryRequest: "1Gi", MemoryLimit: "8Gi"},
+			maxCPU:    "2000m",
+			maxMemory: "8Gi",
+		},
+		{
+			name:      "above maximums",
+			resources: &v1alpha1.AppResources{CPURequest: "3", CPULimit: "4", MemoryLimit: "9Gi"},
+			maxCPU:    "2",
+			maxMemory: "8Gi",
+			want: []string{
+				"spec.resources.cpuRequest: Invalid value",
+				"spec.resources.cpuLimit: Invalid value",
+				"spec.resources.memoryLimit: Invalid value",
+			},
+		},
+		{
+			// Reported by spec validation
+			name:      "invalid quantity",
+			resources: &v1alpha1.AppResources{CPULimit: "lots"},
+			maxCPU:    "2",
+		},
+		{
+			name:      "invalid maximum",
+			resources: &v1alpha1.AppResources{CPULimit: "1"},
+			maxCPU:    "two",
+			want:      []string{"spec.resources: Internal error"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := newTestSpec(func(spec *v1alpha1.TinyAppSpec) { spec.Resources = test.resources })
+			errs := ValidateResourceMaximums(spec, field.NewPath("spec"), test.maxCPU, test.maxMemory)
+			if got := errorFields(errs); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got errors %v, want %v (%v)", got, test.want, errs)
+			}
+		})
+	}
+}
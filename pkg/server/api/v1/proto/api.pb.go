@@ -23,12 +23,70 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GitRefType int32
+
+const (
+	GitRefType_GIT_REF_TYPE_UNSPECIFIED GitRefType = 0 // Tag if is_tag is true, branch otherwise
+	GitRefType_GIT_REF_TYPE_BRANCH      GitRefType = 1
+	GitRefType_GIT_REF_TYPE_TAG         GitRefType = 2
+	GitRefType_GIT_REF_TYPE_COMMIT      GitRefType = 3
+)
+
+// Enum value maps for GitRefType.
+var (
+	GitRefType_name = map[int32]string{
+		0: "GIT_REF_TYPE_UNSPECIFIED",
+		1: "GIT_REF_TYPE_BRANCH",
+		2: "GIT_REF_TYPE_TAG",
+		3: "GIT_REF_TYPE_COMMIT",
+	}
+	GitRefType_value = map[string]int32{
+		"GIT_REF_TYPE_UNSPECIFIED": 0,
+		"GIT_REF_TYPE_BRANCH":      1,
+		"GIT_REF_TYPE_TAG":         2,
+		"GIT_REF_TYPE_COMMIT":      3,
+	}
+)
+
+func (x GitRefType) Enum() *GitRefType {
+	p := new(GitRefType)
+	*p = x
+	return p
+}
+
+func (x GitRefType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GitRefType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[0].Descriptor()
+}
+
+func (GitRefType) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[0]
+}
+
+func (x GitRefType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GitRefType.Descriptor instead.
+func (GitRefType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{0}
+}
+
 type AppType int32
 
 const (
 	AppType_APP_TYPE_UNKNOWN    AppType = 0
 	AppType_APP_TYPE_STREAM_LIT AppType = 1
 	AppType_APP_TYPE_DASH       AppType = 2
+	AppType_APP_TYPE_GRADIO     AppType = 3
+	AppType_APP_TYPE_PANEL      AppType = 4
+	AppType_APP_TYPE_SHINY      AppType = 5 // Shiny for Python
+	AppType_APP_TYPE_VOILA      AppType = 6 // Jupyter notebook served with Voila
+	AppType_APP_TYPE_BOKEH      AppType = 7
+	AppType_APP_TYPE_CUSTOM     AppType = 8 // Run with the command & port set in custom (ex. FastAPI, Flask, Node or R apps)
 )
 
 // Enum value maps for AppType.
@@ -37,11 +95,23 @@ var (
 		0: "APP_TYPE_UNKNOWN",
 		1: "APP_TYPE_STREAM_LIT",
 		2: "APP_TYPE_DASH",
+		3: "APP_TYPE_GRADIO",
+		4: "APP_TYPE_PANEL",
+		5: "APP_TYPE_SHINY",
+		6: "APP_TYPE_VOILA",
+		7: "APP_TYPE_BOKEH",
+		8: "APP_TYPE_CUSTOM",
 	}
 	AppType_value = map[string]int32{
 		"APP_TYPE_UNKNOWN":    0,
 		"APP_TYPE_STREAM_LIT": 1,
 		"APP_TYPE_DASH":       2,
+		"APP_TYPE_GRADIO":     3,
+		"APP_TYPE_PANEL":      4,
+		"APP_TYPE_SHINY":      5,
+		"APP_TYPE_VOILA":      6,
+		"APP_TYPE_BOKEH":      7,
+		"APP_TYPE_CUSTOM":     8,
 	}
 )
 
@@ -56,11 +126,11 @@ func (x AppType) String() string {
 }
 
 func (AppType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_enumTypes[0].Descriptor()
+	return file_api_proto_enumTypes[1].Descriptor()
 }
 
 func (AppType) Type() protoreflect.EnumType {
-	return &file_api_proto_enumTypes[0]
+	return &file_api_proto_enumTypes[1]
 }
 
 func (x AppType) Number() protoreflect.EnumNumber {
@@ -69,7 +139,7 @@ func (x AppType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AppType.Descriptor instead.
 func (AppType) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_rawDescGZIP(), []int{1}
 }
 
 type SourceType int32
@@ -78,6 +148,7 @@ const (
 	SourceType_SOURCE_TYPE_UNKNOWN     SourceType = 0
 	SourceType_SOURCE_TYPE_GIT         SourceType = 1
 	SourceType_SOURCE_TYPE_FILE_SYSTEM SourceType = 2
+	SourceType_SOURCE_TYPE_IMAGE       SourceType = 3 // App code & dependencies are baked into app image
 )
 
 // Enum value maps for SourceType.
@@ -86,11 +157,13 @@ var (
 		0: "SOURCE_TYPE_UNKNOWN",
 		1: "SOURCE_TYPE_GIT",
 		2: "SOURCE_TYPE_FILE_SYSTEM",
+		3: "SOURCE_TYPE_IMAGE",
 	}
 	SourceType_value = map[string]int32{
 		"SOURCE_TYPE_UNKNOWN":     0,
 		"SOURCE_TYPE_GIT":         1,
 		"SOURCE_TYPE_FILE_SYSTEM": 2,
+		"SOURCE_TYPE_IMAGE":       3,
 	}
 )
 
@@ -105,11 +178,11 @@ func (x SourceType) String() string {
 }
 
 func (SourceType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_enumTypes[1].Descriptor()
+	return file_api_proto_enumTypes[2].Descriptor()
 }
 
 func (SourceType) Type() protoreflect.EnumType {
-	return &file_api_proto_enumTypes[1]
+	return &file_api_proto_enumTypes[2]
 }
 
 func (x SourceType) Number() protoreflect.EnumNumber {
@@ -118,7 +191,62 @@ func (x SourceType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SourceType.Descriptor instead.
 func (SourceType) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_rawDescGZIP(), []int{2}
+}
+
+type ListSortOrder int32
+
+const (
+	ListSortOrder_LIST_SORT_ORDER_UNSPECIFIED  ListSortOrder = 0 // Order apps are stored in
+	ListSortOrder_LIST_SORT_ORDER_NAME_ASC     ListSortOrder = 1
+	ListSortOrder_LIST_SORT_ORDER_NAME_DESC    ListSortOrder = 2
+	ListSortOrder_LIST_SORT_ORDER_CREATED_ASC  ListSortOrder = 3
+	ListSortOrder_LIST_SORT_ORDER_CREATED_DESC ListSortOrder = 4
+)
+
+// Enum value maps for ListSortOrder.
+var (
+	ListSortOrder_name = map[int32]string{
+		0: "LIST_SORT_ORDER_UNSPECIFIED",
+		1: "LIST_SORT_ORDER_NAME_ASC",
+		2: "LIST_SORT_ORDER_NAME_DESC",
+		3: "LIST_SORT_ORDER_CREATED_ASC",
+		4: "LIST_SORT_ORDER_CREATED_DESC",
+	}
+	ListSortOrder_value = map[string]int32{
+		"LIST_SORT_ORDER_UNSPECIFIED":  0,
+		"LIST_SORT_ORDER_NAME_ASC":     1,
+		"LIST_SORT_ORDER_NAME_DESC":    2,
+		"LIST_SORT_ORDER_CREATED_ASC":  3,
+		"LIST_SORT_ORDER_CREATED_DESC": 4,
+	}
+)
+
+func (x ListSortOrder) Enum() *ListSortOrder {
+	p := new(ListSortOrder)
+	*p = x
+	return p
+}
+
+func (x ListSortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListSortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[3].Descriptor()
+}
+
+func (ListSortOrder) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[3]
+}
+
+func (x ListSortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListSortOrder.Descriptor instead.
+func (ListSortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{3}
 }
 
 type VolumeClaim struct {
@@ -291,10 +419,13 @@ type GitConfig struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Url   string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                   // Url to clone
-	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`               // Git token for authentication. Will be empty in response.
-	Ref   string `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`                   // Branch or tag name depending on is_tag
-	IsTag bool   `protobuf:"varint,4,opt,name=is_tag,json=isTag,proto3" json:"is_tag,omitempty"` // If true, ref points to to tag instead of branch
+	Url          string     `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                                       // Url to clone
+	Token        string     `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`                                   // Git token for authentication. Will be empty in response.
+	Ref          string     `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`                                       // Branch, tag or full commit SHA depending on ref_type
+	IsTag        bool       `protobuf:"varint,4,opt,name=is_tag,json=isTag,proto3" json:"is_tag,omitempty"`                     // If true, ref points to to tag instead of branch. Only used if ref_type is not set.
+	SyncInterval string     `protobuf:"bytes,5,opt,name=sync_interval,json=syncInterval,proto3" json:"sync_interval,omitempty"` // Roll app onto new commits of branch, checking this often (ex. 5m). Empty means never.
+	RefType      GitRefType `protobuf:"varint,6,opt,name=ref_type,json=refType,proto3,enum=tiny.app.proto.GitRefType" json:"ref_type,omitempty"`
+	Branch       string     `protobuf:"bytes,7,opt,name=branch,proto3" json:"branch,omitempty"` // Branch that contains the commit of commit refs. Defaults to the default branch of repo.
 }
 
 func (x *GitConfig) Reset() {
@@ -357,26 +488,42 @@ func (x *GitConfig) GetIsTag() bool {
 	return false
 }
 
-type TinyAppDetail struct {
+func (x *GitConfig) GetSyncInterval() string {
+	if x != nil {
+		return x.SyncInterval
+	}
+	return ""
+}
+
+func (x *GitConfig) GetRefType() GitRefType {
+	if x != nil {
+		return x.RefType
+	}
+	return GitRefType_GIT_REF_TYPE_UNSPECIFIED
+}
+
+func (x *GitConfig) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+// Compute resources for the app container, as Kubernetes quantities (ex. 500m, 2Gi).
+// Empty values fall back to platform defaults.
+type Resources struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name                string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description         string         `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Documentation       string         `protobuf:"bytes,3,opt,name=documentation,proto3" json:"documentation,omitempty"`
-	Image               string         `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
-	AppType             AppType        `protobuf:"varint,5,opt,name=app_type,json=appType,proto3,enum=tiny.app.proto.AppType" json:"app_type,omitempty"`
-	SourceType          SourceType     `protobuf:"varint,6,opt,name=source_type,json=sourceType,proto3,enum=tiny.app.proto.SourceType" json:"source_type,omitempty"`
-	GitConfig           *GitConfig     `protobuf:"bytes,7,opt,name=git_config,json=gitConfig,proto3" json:"git_config,omitempty"`
-	MainFilePath        string         `protobuf:"bytes,8,opt,name=main_file_path,json=mainFilePath,proto3" json:"main_file_path,omitempty"`
-	Env                 []*EnvVar      `protobuf:"bytes,9,rep,name=env,proto3" json:"env,omitempty"`
-	VolumeClaims        []*VolumeClaim `protobuf:"bytes,10,rep,name=volume_claims,json=volumeClaims,proto3" json:"volume_claims,omitempty"`
-	MainVolumeClaimName string         `protobuf:"bytes,11,opt,name=mainVolumeClaimName,proto3" json:"mainVolumeClaimName,omitempty"`
+	CpuRequest    string `protobuf:"bytes,1,opt,name=cpu_request,json=cpuRequest,proto3" json:"cpu_request,omitempty"`
+	CpuLimit      string `protobuf:"bytes,2,opt,name=cpu_limit,json=cpuLimit,proto3" json:"cpu_limit,omitempty"`
+	MemoryRequest string `protobuf:"bytes,3,opt,name=memory_request,json=memoryRequest,proto3" json:"memory_request,omitempty"`
+	MemoryLimit   string `protobuf:"bytes,4,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
 }
 
-func (x *TinyAppDetail) Reset() {
-	*x = TinyAppDetail{}
+func (x *Resources) Reset() {
+	*x = Resources{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -384,13 +531,13 @@ func (x *TinyAppDetail) Reset() {
 	}
 }
 
-func (x *TinyAppDetail) String() string {
+func (x *Resources) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TinyAppDetail) ProtoMessage() {}
+func (*Resources) ProtoMessage() {}
 
-func (x *TinyAppDetail) ProtoReflect() protoreflect.Message {
+func (x *Resources) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -402,101 +549,52 @@ func (x *TinyAppDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TinyAppDetail.ProtoReflect.Descriptor instead.
-func (*TinyAppDetail) Descriptor() ([]byte, []int) {
+// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
+func (*Resources) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *TinyAppDetail) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *TinyAppDetail) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *TinyAppDetail) GetDocumentation() string {
+func (x *Resources) GetCpuRequest() string {
 	if x != nil {
-		return x.Documentation
+		return x.CpuRequest
 	}
 	return ""
 }
 
-func (x *TinyAppDetail) GetImage() string {
+func (x *Resources) GetCpuLimit() string {
 	if x != nil {
-		return x.Image
+		return x.CpuLimit
 	}
 	return ""
 }
 
-func (x *TinyAppDetail) GetAppType() AppType {
-	if x != nil {
-		return x.AppType
-	}
-	return AppType_APP_TYPE_UNKNOWN
-}
-
-func (x *TinyAppDetail) GetSourceType() SourceType {
-	if x != nil {
-		return x.SourceType
-	}
-	return SourceType_SOURCE_TYPE_UNKNOWN
-}
-
-func (x *TinyAppDetail) GetGitConfig() *GitConfig {
-	if x != nil {
-		return x.GitConfig
-	}
-	return nil
-}
-
-func (x *TinyAppDetail) GetMainFilePath() string {
+func (x *Resources) GetMemoryRequest() string {
 	if x != nil {
-		return x.MainFilePath
+		return x.MemoryRequest
 	}
 	return ""
 }
 
-func (x *TinyAppDetail) GetEnv() []*EnvVar {
-	if x != nil {
-		return x.Env
-	}
-	return nil
-}
-
-func (x *TinyAppDetail) GetVolumeClaims() []*VolumeClaim {
-	if x != nil {
-		return x.VolumeClaims
-	}
-	return nil
-}
-
-func (x *TinyAppDetail) GetMainVolumeClaimName() string {
+func (x *Resources) GetMemoryLimit() string {
 	if x != nil {
-		return x.MainVolumeClaimName
+		return x.MemoryLimit
 	}
 	return ""
 }
 
-type TinyAppRelease struct {
+// Horizontal scaling of app pods based on CPU utilization.
+type Autoscaling struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AppUrl            string `protobuf:"bytes,2,opt,name=app_url,json=appUrl,proto3" json:"app_url,omitempty"`
-	CreationTimeStamp string `protobuf:"bytes,3,opt,name=creation_time_stamp,json=creationTimeStamp,proto3" json:"creation_time_stamp,omitempty"`
-	AppImage          string `protobuf:"bytes,4,opt,name=app_image,json=appImage,proto3" json:"app_image,omitempty"`
+	MinReplicas                    *int32 `protobuf:"varint,1,opt,name=min_replicas,json=minReplicas,proto3,oneof" json:"min_replicas,omitempty"` // Defaults to 1
+	MaxReplicas                    int32  `protobuf:"varint,2,opt,name=max_replicas,json=maxReplicas,proto3" json:"max_replicas,omitempty"`
+	TargetCpuUtilizationPercentage *int32 `protobuf:"varint,3,opt,name=target_cpu_utilization_percentage,json=targetCpuUtilizationPercentage,proto3,oneof" json:"target_cpu_utilization_percentage,omitempty"` // Defaults to 80
 }
 
-func (x *TinyAppRelease) Reset() {
-	*x = TinyAppRelease{}
+func (x *Autoscaling) Reset() {
+	*x = Autoscaling{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -504,13 +602,13 @@ func (x *TinyAppRelease) Reset() {
 	}
 }
 
-func (x *TinyAppRelease) String() string {
+func (x *Autoscaling) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TinyAppRelease) ProtoMessage() {}
+func (*Autoscaling) ProtoMessage() {}
 
-func (x *TinyAppRelease) ProtoReflect() protoreflect.Message {
+func (x *Autoscaling) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -522,50 +620,47 @@ func (x *TinyAppRelease) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TinyAppRelease.ProtoReflect.Descriptor instead.
-func (*TinyAppRelease) Descriptor() ([]byte, []int) {
+// Deprecated: Use Autoscaling.ProtoReflect.Descriptor instead.
+func (*Autoscaling) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *TinyAppRelease) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *TinyAppRelease) GetAppUrl() string {
-	if x != nil {
-		return x.AppUrl
+func (x *Autoscaling) GetMinReplicas() int32 {
+	if x != nil && x.MinReplicas != nil {
+		return *x.MinReplicas
 	}
-	return ""
+	return 0
 }
 
-func (x *TinyAppRelease) GetCreationTimeStamp() string {
+func (x *Autoscaling) GetMaxReplicas() int32 {
 	if x != nil {
-		return x.CreationTimeStamp
+		return x.MaxReplicas
 	}
-	return ""
+	return 0
 }
 
-func (x *TinyAppRelease) GetAppImage() string {
-	if x != nil {
-		return x.AppImage
+func (x *Autoscaling) GetTargetCpuUtilizationPercentage() int32 {
+	if x != nil && x.TargetCpuUtilizationPercentage != nil {
+		return *x.TargetCpuUtilizationPercentage
 	}
-	return ""
+	return 0
 }
 
-type TinyApp struct {
+// How to run the app container of a custom app.
+type CustomApp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppRelease *TinyAppRelease `protobuf:"bytes,1,opt,name=app_release,json=appRelease,proto3" json:"app_release,omitempty"`
-	AppDetail  *TinyAppDetail  `protobuf:"bytes,2,opt,name=app_detail,json=appDetail,proto3" json:"app_detail,omitempty"`
+	Command []string `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"` // Image entrypoint if empty. $(VAR) references to app env vars are expanded.
+	Args    []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`       // Image cmd if empty
+	Port    int32    `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`      // Port app listens on
+	// Env var app reads its url path from. Gateway strips the path from requests if empty, serving app at root path.
+	BasePathEnvVarName string `protobuf:"bytes,4,opt,name=base_path_env_var_name,json=basePathEnvVarName,proto3" json:"base_path_env_var_name,omitempty"`
 }
 
-func (x *TinyApp) Reset() {
-	*x = TinyApp{}
+func (x *CustomApp) Reset() {
+	*x = CustomApp{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -573,13 +668,13 @@ func (x *TinyApp) Reset() {
 	}
 }
 
-func (x *TinyApp) String() string {
+func (x *CustomApp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TinyApp) ProtoMessage() {}
+func (*CustomApp) ProtoMessage() {}
 
-func (x *TinyApp) ProtoReflect() protoreflect.Message {
+func (x *CustomApp) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -591,35 +686,68 @@ func (x *TinyApp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TinyApp.ProtoReflect.Descriptor instead.
-func (*TinyApp) Descriptor() ([]byte, []int) {
+// Deprecated: Use CustomApp.ProtoReflect.Descriptor instead.
+func (*CustomApp) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *TinyApp) GetAppRelease() *TinyAppRelease {
+func (x *CustomApp) GetCommand() []string {
 	if x != nil {
-		return x.AppRelease
+		return x.Command
 	}
 	return nil
 }
 
-func (x *TinyApp) GetAppDetail() *TinyAppDetail {
+func (x *CustomApp) GetArgs() []string {
 	if x != nil {
-		return x.AppDetail
+		return x.Args
 	}
 	return nil
 }
 
-type CreateTinyAppRequest struct {
+func (x *CustomApp) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *CustomApp) GetBasePathEnvVarName() string {
+	if x != nil {
+		return x.BasePathEnvVarName
+	}
+	return ""
+}
+
+type TinyAppDetail struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppDetail *TinyAppDetail `protobuf:"bytes,1,opt,name=app_detail,json=appDetail,proto3" json:"app_detail,omitempty"`
+	Name                string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description         string         `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Documentation       string         `protobuf:"bytes,3,opt,name=documentation,proto3" json:"documentation,omitempty"`
+	Image               string         `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	AppType             AppType        `protobuf:"varint,5,opt,name=app_type,json=appType,proto3,enum=tiny.app.proto.AppType" json:"app_type,omitempty"`
+	SourceType          SourceType     `protobuf:"varint,6,opt,name=source_type,json=sourceType,proto3,enum=tiny.app.proto.SourceType" json:"source_type,omitempty"`
+	GitConfig           *GitConfig     `protobuf:"bytes,7,opt,name=git_config,json=gitConfig,proto3" json:"git_config,omitempty"`
+	MainFilePath        string         `protobuf:"bytes,8,opt,name=main_file_path,json=mainFilePath,proto3" json:"main_file_path,omitempty"`
+	Env                 []*EnvVar      `protobuf:"bytes,9,rep,name=env,proto3" json:"env,omitempty"`
+	VolumeClaims        []*VolumeClaim `protobuf:"bytes,10,rep,name=volume_claims,json=volumeClaims,proto3" json:"volume_claims,omitempty"`
+	MainVolumeClaimName string         `protobuf:"bytes,11,opt,name=mainVolumeClaimName,proto3" json:"mainVolumeClaimName,omitempty"`
+	Resources           *Resources     `protobuf:"bytes,12,opt,name=resources,proto3" json:"resources,omitempty"`
+	Replicas            *int32         `protobuf:"varint,13,opt,name=replicas,proto3,oneof" json:"replicas,omitempty"` // Number of app pods. Ignored when autoscaling is set.
+	Autoscaling         *Autoscaling   `protobuf:"bytes,14,opt,name=autoscaling,proto3" json:"autoscaling,omitempty"`
+	IdleTimeout         string         `protobuf:"bytes,15,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"` // Scale app to zero after no requests for this long (ex. 30m). Empty means never.
+	Auth                *AppAuth       `protobuf:"bytes,16,opt,name=auth,proto3" json:"auth,omitempty"`                                  // Require users to log in before using app. App is open to anyone if not set.
+	// App type that operators added in controller's app runtimes ConfigMap (ex. RShiny). Used instead of app_type if set.
+	AppRuntime string     `protobuf:"bytes,17,opt,name=app_runtime,json=appRuntime,proto3" json:"app_runtime,omitempty"`
+	Custom     *CustomApp `protobuf:"bytes,18,opt,name=custom,proto3" json:"custom,omitempty"` // Required if app_type is custom
+	Probes     *AppProbes `protobuf:"bytes,19,opt,name=probes,proto3" json:"probes,omitempty"` // Overrides health checks of app container. App type defaults are used if not set.
 }
 
-func (x *CreateTinyAppRequest) Reset() {
-	*x = CreateTinyAppRequest{}
+func (x *TinyAppDetail) Reset() {
+	*x = TinyAppDetail{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -627,13 +755,13 @@ func (x *CreateTinyAppRequest) Reset() {
 	}
 }
 
-func (x *CreateTinyAppRequest) String() string {
+func (x *TinyAppDetail) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTinyAppRequest) ProtoMessage() {}
+func (*TinyAppDetail) ProtoMessage() {}
 
-func (x *CreateTinyAppRequest) ProtoReflect() protoreflect.Message {
+func (x *TinyAppDetail) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -645,43 +773,2132 @@ func (x *CreateTinyAppRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTinyAppRequest.ProtoReflect.Descriptor instead.
-func (*CreateTinyAppRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TinyAppDetail.ProtoReflect.Descriptor instead.
+func (*TinyAppDetail) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CreateTinyAppRequest) GetAppDetail() *TinyAppDetail {
+func (x *TinyAppDetail) GetName() string {
 	if x != nil {
-		return x.AppDetail
+		return x.Name
 	}
-	return nil
-}
-
-type CreateTinyAppResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	AppRelease *TinyAppRelease `protobuf:"bytes,1,opt,name=app_release,json=appRelease,proto3" json:"app_release,omitempty"`
+	return ""
 }
 
-func (x *CreateTinyAppResponse) Reset() {
+func (x *TinyAppDetail) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TinyAppDetail) GetDocumentation() string {
+	if x != nil {
+		return x.Documentation
+	}
+	return ""
+}
+
+func (x *TinyAppDetail) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *TinyAppDetail) GetAppType() AppType {
+	if x != nil {
+		return x.AppType
+	}
+	return AppType_APP_TYPE_UNKNOWN
+}
+
+func (x *TinyAppDetail) GetSourceType() SourceType {
+	if x != nil {
+		return x.SourceType
+	}
+	return SourceType_SOURCE_TYPE_UNKNOWN
+}
+
+func (x *TinyAppDetail) GetGitConfig() *GitConfig {
+	if x != nil {
+		return x.GitConfig
+	}
+	return nil
+}
+
+func (x *TinyAppDetail) GetMainFilePath() string {
+	if x != nil {
+		return x.MainFilePath
+	}
+	return ""
+}
+
+func (x *TinyAppDetail) GetEnv() []*EnvVar {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *TinyAppDetail) GetVolumeClaims() []*VolumeClaim {
+	if x != nil {
+		return x.VolumeClaims
+	}
+	return nil
+}
+
+func (x *TinyAppDetail) GetMainVolumeClaimName() string {
+	if x != nil {
+		return x.MainVolumeClaimName
+	}
+	return ""
+}
+
+func (x *TinyAppDetail) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *TinyAppDetail) GetReplicas() int32 {
+	if x != nil && x.Replicas != nil {
+		return *x.Replicas
+	}
+	return 0
+}
+
+func (x *TinyAppDetail) GetAutoscaling() *Autoscaling {
+	if x != nil {
+		return x.Autoscaling
+	}
+	return nil
+}
+
+func (x *TinyAppDetail) GetIdleTimeout() string {
+	if x != nil {
+		return x.IdleTimeout
+	}
+	return ""
+}
+
+func (x *TinyAppDetail) GetAuth() *AppAuth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *TinyAppDetail) GetAppRuntime() string {
+	if x != nil {
+		return x.AppRuntime
+	}
+	return ""
+}
+
+func (x *TinyAppDetail) GetCustom() *CustomApp {
+	if x != nil {
+		return x.Custom
+	}
+	return nil
+}
+
+func (x *TinyAppDetail) GetProbes() *AppProbes {
+	if x != nil {
+		return x.Probes
+	}
+	return nil
+}
+
+// Startup, readiness & liveness probes of app container.
+type AppProbes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Disabled  bool           `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"` // For apps without an HTTP endpoint that responds while app is healthy
+	Path      string         `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`          // Relative to app base path. Defaults to health path of app type (ex. /_stcore/health).
+	Startup   *ProbeSettings `protobuf:"bytes,3,opt,name=startup,proto3" json:"startup,omitempty"`
+	Readiness *ProbeSettings `protobuf:"bytes,4,opt,name=readiness,proto3" json:"readiness,omitempty"`
+	Liveness  *ProbeSettings `protobuf:"bytes,5,opt,name=liveness,proto3" json:"liveness,omitempty"`
+}
+
+func (x *AppProbes) Reset() {
+	*x = AppProbes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppProbes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppProbes) ProtoMessage() {}
+
+func (x *AppProbes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppProbes.ProtoReflect.Descriptor instead.
+func (*AppProbes) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AppProbes) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *AppProbes) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AppProbes) GetStartup() *ProbeSettings {
+	if x != nil {
+		return x.Startup
+	}
+	return nil
+}
+
+func (x *AppProbes) GetReadiness() *ProbeSettings {
+	if x != nil {
+		return x.Readiness
+	}
+	return nil
+}
+
+func (x *AppProbes) GetLiveness() *ProbeSettings {
+	if x != nil {
+		return x.Liveness
+	}
+	return nil
+}
+
+// Controller defaults are used for any value that is not set.
+type ProbeSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InitialDelaySeconds *int32 `protobuf:"varint,1,opt,name=initial_delay_seconds,json=initialDelaySeconds,proto3,oneof" json:"initial_delay_seconds,omitempty"`
+	PeriodSeconds       *int32 `protobuf:"varint,2,opt,name=period_seconds,json=periodSeconds,proto3,oneof" json:"period_seconds,omitempty"`
+	TimeoutSeconds      *int32 `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3,oneof" json:"timeout_seconds,omitempty"`
+	FailureThreshold    *int32 `protobuf:"varint,4,opt,name=failure_threshold,json=failureThreshold,proto3,oneof" json:"failure_threshold,omitempty"`
+}
+
+func (x *ProbeSettings) Reset() {
+	*x = ProbeSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeSettings) ProtoMessage() {}
+
+func (x *ProbeSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeSettings.ProtoReflect.Descriptor instead.
+func (*ProbeSettings) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProbeSettings) GetInitialDelaySeconds() int32 {
+	if x != nil && x.InitialDelaySeconds != nil {
+		return *x.InitialDelaySeconds
+	}
+	return 0
+}
+
+func (x *ProbeSettings) GetPeriodSeconds() int32 {
+	if x != nil && x.PeriodSeconds != nil {
+		return *x.PeriodSeconds
+	}
+	return 0
+}
+
+func (x *ProbeSettings) GetTimeoutSeconds() int32 {
+	if x != nil && x.TimeoutSeconds != nil {
+		return *x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ProbeSettings) GetFailureThreshold() int32 {
+	if x != nil && x.FailureThreshold != nil {
+		return *x.FailureThreshold
+	}
+	return 0
+}
+
+// Users & groups allowed to use app. Any logged-in user is allowed if both are empty.
+type AppAuth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AllowedUsers  []string `protobuf:"bytes,1,rep,name=allowed_users,json=allowedUsers,proto3" json:"allowed_users,omitempty"`
+	AllowedGroups []string `protobuf:"bytes,2,rep,name=allowed_groups,json=allowedGroups,proto3" json:"allowed_groups,omitempty"`
+}
+
+func (x *AppAuth) Reset() {
+	*x = AppAuth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppAuth) ProtoMessage() {}
+
+func (x *AppAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppAuth.ProtoReflect.Descriptor instead.
+func (*AppAuth) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AppAuth) GetAllowedUsers() []string {
+	if x != nil {
+		return x.AllowedUsers
+	}
+	return nil
+}
+
+func (x *AppAuth) GetAllowedGroups() []string {
+	if x != nil {
+		return x.AllowedGroups
+	}
+	return nil
+}
+
+type TinyAppRelease struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AppUrl            string `protobuf:"bytes,2,opt,name=app_url,json=appUrl,proto3" json:"app_url,omitempty"`
+	CreationTimeStamp string `protobuf:"bytes,3,opt,name=creation_time_stamp,json=creationTimeStamp,proto3" json:"creation_time_stamp,omitempty"`
+	AppImage          string `protobuf:"bytes,4,opt,name=app_image,json=appImage,proto3" json:"app_image,omitempty"`
+	GitCommit         string `protobuf:"bytes,5,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`       // Commit SHA that git ref was resolved to. Empty if app source is not git.
+	ImageDigest       string `protobuf:"bytes,6,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"` // Digest that app image was resolved to. Empty if app source is not image.
+}
+
+func (x *TinyAppRelease) Reset() {
+	*x = TinyAppRelease{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TinyAppRelease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinyAppRelease) ProtoMessage() {}
+
+func (x *TinyAppRelease) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinyAppRelease.ProtoReflect.Descriptor instead.
+func (*TinyAppRelease) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TinyAppRelease) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TinyAppRelease) GetAppUrl() string {
+	if x != nil {
+		return x.AppUrl
+	}
+	return ""
+}
+
+func (x *TinyAppRelease) GetCreationTimeStamp() string {
+	if x != nil {
+		return x.CreationTimeStamp
+	}
+	return ""
+}
+
+func (x *TinyAppRelease) GetAppImage() string {
+	if x != nil {
+		return x.AppImage
+	}
+	return ""
+}
+
+func (x *TinyAppRelease) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *TinyAppRelease) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+type TinyAppCondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type               string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`     // ex. Available, Progressing, SourceSynced
+	Status             string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // True, False or Unknown
+	Reason             string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // ex. ImagePullBackOff, GitCloneFailed, OOMKilled
+	Message            string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	LastTransitionTime string `protobuf:"bytes,5,opt,name=last_transition_time,json=lastTransitionTime,proto3" json:"last_transition_time,omitempty"`
+}
+
+func (x *TinyAppCondition) Reset() {
+	*x = TinyAppCondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TinyAppCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinyAppCondition) ProtoMessage() {}
+
+func (x *TinyAppCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinyAppCondition.ProtoReflect.Descriptor instead.
+func (*TinyAppCondition) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TinyAppCondition) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TinyAppCondition) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TinyAppCondition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TinyAppCondition) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TinyAppCondition) GetLastTransitionTime() string {
+	if x != nil {
+		return x.LastTransitionTime
+	}
+	return ""
+}
+
+// Observed state of app, as reported by the controller.
+type TinyAppStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phase              string              `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"` // Deployed, Progressing, Failed or Sleeping
+	Conditions         []*TinyAppCondition `protobuf:"bytes,2,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	Replicas           int32               `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	ReadyReplicas      int32               `protobuf:"varint,4,opt,name=ready_replicas,json=readyReplicas,proto3" json:"ready_replicas,omitempty"`
+	ObservedGeneration int64               `protobuf:"varint,5,opt,name=observed_generation,json=observedGeneration,proto3" json:"observed_generation,omitempty"`
+	Generation         int64               `protobuf:"varint,6,opt,name=generation,proto3" json:"generation,omitempty"`                                         // Status is stale if observed_generation is behind generation
+	GitCommit          string              `protobuf:"bytes,7,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`                           // Commit of app source checked out by the newest app pod. Empty if app source is not git.
+	ResolvedGitCommit  string              `protobuf:"bytes,8,opt,name=resolved_git_commit,json=resolvedGitCommit,proto3" json:"resolved_git_commit,omitempty"` // Commit SHA that git ref was resolved to, which app pods check out
+	ImageDigest        string              `protobuf:"bytes,9,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"`                     // Digest that app image was resolved to, which app pods run. Empty if app source is not image.
+}
+
+func (x *TinyAppStatus) Reset() {
+	*x = TinyAppStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TinyAppStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinyAppStatus) ProtoMessage() {}
+
+func (x *TinyAppStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinyAppStatus.ProtoReflect.Descriptor instead.
+func (*TinyAppStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TinyAppStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *TinyAppStatus) GetConditions() []*TinyAppCondition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *TinyAppStatus) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *TinyAppStatus) GetReadyReplicas() int32 {
+	if x != nil {
+		return x.ReadyReplicas
+	}
+	return 0
+}
+
+func (x *TinyAppStatus) GetObservedGeneration() int64 {
+	if x != nil {
+		return x.ObservedGeneration
+	}
+	return 0
+}
+
+func (x *TinyAppStatus) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *TinyAppStatus) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *TinyAppStatus) GetResolvedGitCommit() string {
+	if x != nil {
+		return x.ResolvedGitCommit
+	}
+	return ""
+}
+
+func (x *TinyAppStatus) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+// Who can manage an app, in addition to admins.
+type TinyAppAccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Creator string   `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	Owners  []string `protobuf:"bytes,2,rep,name=owners,proto3" json:"owners,omitempty"`
+	Groups  []string `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *TinyAppAccess) Reset() {
+	*x = TinyAppAccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TinyAppAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinyAppAccess) ProtoMessage() {}
+
+func (x *TinyAppAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinyAppAccess.ProtoReflect.Descriptor instead.
+func (*TinyAppAccess) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TinyAppAccess) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *TinyAppAccess) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *TinyAppAccess) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type TinyApp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppRelease *TinyAppRelease `protobuf:"bytes,1,opt,name=app_release,json=appRelease,proto3" json:"app_release,omitempty"`
+	AppDetail  *TinyAppDetail  `protobuf:"bytes,2,opt,name=app_detail,json=appDetail,proto3" json:"app_detail,omitempty"`
+	Status     *TinyAppStatus  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Access     *TinyAppAccess  `protobuf:"bytes,4,opt,name=access,proto3" json:"access,omitempty"`
+}
+
+func (x *TinyApp) Reset() {
+	*x = TinyApp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TinyApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinyApp) ProtoMessage() {}
+
+func (x *TinyApp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinyApp.ProtoReflect.Descriptor instead.
+func (*TinyApp) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TinyApp) GetAppRelease() *TinyAppRelease {
+	if x != nil {
+		return x.AppRelease
+	}
+	return nil
+}
+
+func (x *TinyApp) GetAppDetail() *TinyAppDetail {
+	if x != nil {
+		return x.AppDetail
+	}
+	return nil
+}
+
+func (x *TinyApp) GetStatus() *TinyAppStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *TinyApp) GetAccess() *TinyAppAccess {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+type AddTinyAppCollaboratorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId  string   `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Users  []string `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	Groups []string `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *AddTinyAppCollaboratorsRequest) Reset() {
+	*x = AddTinyAppCollaboratorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddTinyAppCollaboratorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTinyAppCollaboratorsRequest) ProtoMessage() {}
+
+func (x *AddTinyAppCollaboratorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTinyAppCollaboratorsRequest.ProtoReflect.Descriptor instead.
+func (*AddTinyAppCollaboratorsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AddTinyAppCollaboratorsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *AddTinyAppCollaboratorsRequest) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *AddTinyAppCollaboratorsRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type RemoveTinyAppCollaboratorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId  string   `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Users  []string `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	Groups []string `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *RemoveTinyAppCollaboratorsRequest) Reset() {
+	*x = RemoveTinyAppCollaboratorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveTinyAppCollaboratorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTinyAppCollaboratorsRequest) ProtoMessage() {}
+
+func (x *RemoveTinyAppCollaboratorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTinyAppCollaboratorsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTinyAppCollaboratorsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveTinyAppCollaboratorsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *RemoveTinyAppCollaboratorsRequest) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *RemoveTinyAppCollaboratorsRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type CreateTinyAppRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppDetail *TinyAppDetail `protobuf:"bytes,1,opt,name=app_detail,json=appDetail,proto3" json:"app_detail,omitempty"`
+}
+
+func (x *CreateTinyAppRequest) Reset() {
+	*x = CreateTinyAppRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTinyAppRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTinyAppRequest) ProtoMessage() {}
+
+func (x *CreateTinyAppRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTinyAppRequest.ProtoReflect.Descriptor instead.
+func (*CreateTinyAppRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateTinyAppRequest) GetAppDetail() *TinyAppDetail {
+	if x != nil {
+		return x.AppDetail
+	}
+	return nil
+}
+
+type CreateTinyAppResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppRelease *TinyAppRelease `protobuf:"bytes,1,opt,name=app_release,json=appRelease,proto3" json:"app_release,omitempty"`
+}
+
+func (x *CreateTinyAppResponse) Reset() {
 	*x = CreateTinyAppResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[8]
+		mi := &file_api_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTinyAppResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTinyAppResponse) ProtoMessage() {}
+
+func (x *CreateTinyAppResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTinyAppResponse.ProtoReflect.Descriptor instead.
+func (*CreateTinyAppResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateTinyAppResponse) GetAppRelease() *TinyAppRelease {
+	if x != nil {
+		return x.AppRelease
+	}
+	return nil
+}
+
+type GetTinyAppAccessMetricsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId      string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	TimePeriod string `protobuf:"bytes,2,opt,name=time_period,json=timePeriod,proto3" json:"time_period,omitempty"`
+}
+
+func (x *GetTinyAppAccessMetricsRequest) Reset() {
+	*x = GetTinyAppAccessMetricsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppAccessMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppAccessMetricsRequest) ProtoMessage() {}
+
+func (x *GetTinyAppAccessMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppAccessMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetTinyAppAccessMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetTinyAppAccessMetricsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetTinyAppAccessMetricsRequest) GetTimePeriod() string {
+	if x != nil {
+		return x.TimePeriod
+	}
+	return ""
+}
+
+type GetTinyAppAccessMetricsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NumberOfAccess int32 `protobuf:"varint,1,opt,name=number_of_access,json=numberOfAccess,proto3" json:"number_of_access,omitempty"`
+}
+
+func (x *GetTinyAppAccessMetricsResponse) Reset() {
+	*x = GetTinyAppAccessMetricsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppAccessMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppAccessMetricsResponse) ProtoMessage() {}
+
+func (x *GetTinyAppAccessMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppAccessMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetTinyAppAccessMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetTinyAppAccessMetricsResponse) GetNumberOfAccess() int32 {
+	if x != nil {
+		return x.NumberOfAccess
+	}
+	return 0
+}
+
+type GetTinyAppUserMetricsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId      string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	TimePeriod string `protobuf:"bytes,2,opt,name=time_period,json=timePeriod,proto3" json:"time_period,omitempty"` // Prometheus duration (ex. 1h, 7d)
+}
+
+func (x *GetTinyAppUserMetricsRequest) Reset() {
+	*x = GetTinyAppUserMetricsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppUserMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppUserMetricsRequest) ProtoMessage() {}
+
+func (x *GetTinyAppUserMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppUserMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetTinyAppUserMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetTinyAppUserMetricsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetTinyAppUserMetricsRequest) GetTimePeriod() string {
+	if x != nil {
+		return x.TimePeriod
+	}
+	return ""
+}
+
+type TinyAppUserAccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username     string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	AccessCount  int32  `protobuf:"varint,2,opt,name=access_count,json=accessCount,proto3" json:"access_count,omitempty"`
+	SessionCount int32  `protobuf:"varint,3,opt,name=session_count,json=sessionCount,proto3" json:"session_count,omitempty"`
+}
+
+func (x *TinyAppUserAccess) Reset() {
+	*x = TinyAppUserAccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TinyAppUserAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinyAppUserAccess) ProtoMessage() {}
+
+func (x *TinyAppUserAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinyAppUserAccess.ProtoReflect.Descriptor instead.
+func (*TinyAppUserAccess) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TinyAppUserAccess) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TinyAppUserAccess) GetAccessCount() int32 {
+	if x != nil {
+		return x.AccessCount
+	}
+	return 0
+}
+
+func (x *TinyAppUserAccess) GetSessionCount() int32 {
+	if x != nil {
+		return x.SessionCount
+	}
+	return 0
+}
+
+type GetTinyAppUserMetricsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users                 []*TinyAppUserAccess `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`                                       // Identified users, most accesses first
+	DistinctUsers         int32                `protobuf:"varint,2,opt,name=distinct_users,json=distinctUsers,proto3" json:"distinct_users,omitempty"` // Number of identified users who used app
+	AnonymousAccessCount  int32                `protobuf:"varint,3,opt,name=anonymous_access_count,json=anonymousAccessCount,proto3" json:"anonymous_access_count,omitempty"`
+	AnonymousSessionCount int32                `protobuf:"varint,4,opt,name=anonymous_session_count,json=anonymousSessionCount,proto3" json:"anonymous_session_count,omitempty"`
+	TotalAccessCount      int32                `protobuf:"varint,5,opt,name=total_access_count,json=totalAccessCount,proto3" json:"total_access_count,omitempty"`
+	TotalSessionCount     int32                `protobuf:"varint,6,opt,name=total_session_count,json=totalSessionCount,proto3" json:"total_session_count,omitempty"`
+}
+
+func (x *GetTinyAppUserMetricsResponse) Reset() {
+	*x = GetTinyAppUserMetricsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppUserMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppUserMetricsResponse) ProtoMessage() {}
+
+func (x *GetTinyAppUserMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppUserMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetTinyAppUserMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTinyAppUserMetricsResponse) GetUsers() []*TinyAppUserAccess {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GetTinyAppUserMetricsResponse) GetDistinctUsers() int32 {
+	if x != nil {
+		return x.DistinctUsers
+	}
+	return 0
+}
+
+func (x *GetTinyAppUserMetricsResponse) GetAnonymousAccessCount() int32 {
+	if x != nil {
+		return x.AnonymousAccessCount
+	}
+	return 0
+}
+
+func (x *GetTinyAppUserMetricsResponse) GetAnonymousSessionCount() int32 {
+	if x != nil {
+		return x.AnonymousSessionCount
+	}
+	return 0
+}
+
+func (x *GetTinyAppUserMetricsResponse) GetTotalAccessCount() int32 {
+	if x != nil {
+		return x.TotalAccessCount
+	}
+	return 0
+}
+
+func (x *GetTinyAppUserMetricsResponse) GetTotalSessionCount() int32 {
+	if x != nil {
+		return x.TotalSessionCount
+	}
+	return 0
+}
+
+type GetTinyAppTrafficMetricsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId      string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	TimePeriod string `protobuf:"bytes,2,opt,name=time_period,json=timePeriod,proto3" json:"time_period,omitempty"` // Prometheus duration (ex. 5m, 1h)
+}
+
+func (x *GetTinyAppTrafficMetricsRequest) Reset() {
+	*x = GetTinyAppTrafficMetricsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppTrafficMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppTrafficMetricsRequest) ProtoMessage() {}
+
+func (x *GetTinyAppTrafficMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppTrafficMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetTinyAppTrafficMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTinyAppTrafficMetricsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetTinyAppTrafficMetricsRequest) GetTimePeriod() string {
+	if x != nil {
+		return x.TimePeriod
+	}
+	return ""
+}
+
+// Metrics are 0 if app had no requests in time period.
+// Latencies exclude websocket connections.
+type GetTinyAppTrafficMetricsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	P50LatencySeconds          float64 `protobuf:"fixed64,1,opt,name=p50_latency_seconds,json=p50LatencySeconds,proto3" json:"p50_latency_seconds,omitempty"`
+	P95LatencySeconds          float64 `protobuf:"fixed64,2,opt,name=p95_latency_seconds,json=p95LatencySeconds,proto3" json:"p95_latency_seconds,omitempty"`
+	RequestRate                float64 `protobuf:"fixed64,3,opt,name=request_rate,json=requestRate,proto3" json:"request_rate,omitempty"`                                                // Requests per second
+	ErrorRate                  float64 `protobuf:"fixed64,4,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`                                                      // Fraction of requests with 5xx response
+	ActiveWebsocketConnections float64 `protobuf:"fixed64,5,opt,name=active_websocket_connections,json=activeWebsocketConnections,proto3" json:"active_websocket_connections,omitempty"` // Current number of open websocket connections
+	ReceivedBytesRate          float64 `protobuf:"fixed64,6,opt,name=received_bytes_rate,json=receivedBytesRate,proto3" json:"received_bytes_rate,omitempty"`                            // Request body bytes per second
+	SentBytesRate              float64 `protobuf:"fixed64,7,opt,name=sent_bytes_rate,json=sentBytesRate,proto3" json:"sent_bytes_rate,omitempty"`                                        // Response body bytes per second
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) Reset() {
+	*x = GetTinyAppTrafficMetricsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppTrafficMetricsResponse) ProtoMessage() {}
+
+func (x *GetTinyAppTrafficMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppTrafficMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetTinyAppTrafficMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) GetP50LatencySeconds() float64 {
+	if x != nil {
+		return x.P50LatencySeconds
+	}
+	return 0
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) GetP95LatencySeconds() float64 {
+	if x != nil {
+		return x.P95LatencySeconds
+	}
+	return 0
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) GetRequestRate() float64 {
+	if x != nil {
+		return x.RequestRate
+	}
+	return 0
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) GetActiveWebsocketConnections() float64 {
+	if x != nil {
+		return x.ActiveWebsocketConnections
+	}
+	return 0
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) GetReceivedBytesRate() float64 {
+	if x != nil {
+		return x.ReceivedBytesRate
+	}
+	return 0
+}
+
+func (x *GetTinyAppTrafficMetricsResponse) GetSentBytesRate() float64 {
+	if x != nil {
+		return x.SentBytesRate
+	}
+	return 0
+}
+
+type GetTinyAppMetricsRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId  string   `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Start  string   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`   // RFC 3339. Defaults to 1h before end.
+	End    string   `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`       // RFC 3339. Defaults to now.
+	Step   string   `protobuf:"bytes,4,opt,name=step,proto3" json:"step,omitempty"`     // Time between points (ex. 30s, 5m). Defaults to giving about 120 points.
+	Series []string `protobuf:"bytes,5,rep,name=series,proto3" json:"series,omitempty"` // Any of cpu_usage, memory_usage, request_rate, access_count. All if empty.
+}
+
+func (x *GetTinyAppMetricsRangeRequest) Reset() {
+	*x = GetTinyAppMetricsRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppMetricsRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppMetricsRangeRequest) ProtoMessage() {}
+
+func (x *GetTinyAppMetricsRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppMetricsRangeRequest.ProtoReflect.Descriptor instead.
+func (*GetTinyAppMetricsRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetTinyAppMetricsRangeRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetTinyAppMetricsRangeRequest) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *GetTinyAppMetricsRangeRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *GetTinyAppMetricsRangeRequest) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *GetTinyAppMetricsRangeRequest) GetSeries() []string {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+type MetricPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp int64   `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix seconds
+	Value     float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *MetricPoint) Reset() {
+	*x = MetricPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetricPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricPoint) ProtoMessage() {}
+
+func (x *MetricPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricPoint.ProtoReflect.Descriptor instead.
+func (*MetricPoint) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MetricPoint) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MetricPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type MetricSeries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Unit   string         `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Points []*MetricPoint `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	Error  string         `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // Set if series could not be queried. Other series are still returned.
+}
+
+func (x *MetricSeries) Reset() {
+	*x = MetricSeries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetricSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricSeries) ProtoMessage() {}
+
+func (x *MetricSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricSeries.ProtoReflect.Descriptor instead.
+func (*MetricSeries) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MetricSeries) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MetricSeries) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *MetricSeries) GetPoints() []*MetricPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *MetricSeries) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetTinyAppMetricsRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Series []*MetricSeries `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	Start  string          `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End    string          `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Step   string          `protobuf:"bytes,4,opt,name=step,proto3" json:"step,omitempty"`
+}
+
+func (x *GetTinyAppMetricsRangeResponse) Reset() {
+	*x = GetTinyAppMetricsRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppMetricsRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppMetricsRangeResponse) ProtoMessage() {}
+
+func (x *GetTinyAppMetricsRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppMetricsRangeResponse.ProtoReflect.Descriptor instead.
+func (*GetTinyAppMetricsRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetTinyAppMetricsRangeResponse) GetSeries() []*MetricSeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *GetTinyAppMetricsRangeResponse) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *GetTinyAppMetricsRangeResponse) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *GetTinyAppMetricsRangeResponse) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+type GetTinyAppUsageMetricsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId      string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	TimePeriod string `protobuf:"bytes,2,opt,name=time_period,json=timePeriod,proto3" json:"time_period,omitempty"`
+}
+
+func (x *GetTinyAppUsageMetricsRequest) Reset() {
+	*x = GetTinyAppUsageMetricsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppUsageMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppUsageMetricsRequest) ProtoMessage() {}
+
+func (x *GetTinyAppUsageMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppUsageMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetTinyAppUsageMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetTinyAppUsageMetricsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetTinyAppUsageMetricsRequest) GetTimePeriod() string {
+	if x != nil {
+		return x.TimePeriod
+	}
+	return ""
+}
+
+type GetTinyAppUsageMetricsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CpuUsage          float64 `protobuf:"fixed64,1,opt,name=cpu_usage,json=cpuUsage,proto3" json:"cpu_usage,omitempty"`
+	CpuLimit          float64 `protobuf:"fixed64,2,opt,name=cpu_limit,json=cpuLimit,proto3" json:"cpu_limit,omitempty"`
+	MemoryUsage       float64 `protobuf:"fixed64,3,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+	MemoryLimit       float64 `protobuf:"fixed64,4,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
+	PercentCpuUsed    float64 `protobuf:"fixed64,5,opt,name=percent_cpu_used,json=percentCpuUsed,proto3" json:"percent_cpu_used,omitempty"`
+	PercentMemoryUsed float64 `protobuf:"fixed64,6,opt,name=percent_memory_used,json=percentMemoryUsed,proto3" json:"percent_memory_used,omitempty"`
+}
+
+func (x *GetTinyAppUsageMetricsResponse) Reset() {
+	*x = GetTinyAppUsageMetricsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppUsageMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppUsageMetricsResponse) ProtoMessage() {}
+
+func (x *GetTinyAppUsageMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppUsageMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetTinyAppUsageMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetTinyAppUsageMetricsResponse) GetCpuUsage() float64 {
+	if x != nil {
+		return x.CpuUsage
+	}
+	return 0
+}
+
+func (x *GetTinyAppUsageMetricsResponse) GetCpuLimit() float64 {
+	if x != nil {
+		return x.CpuLimit
+	}
+	return 0
+}
+
+func (x *GetTinyAppUsageMetricsResponse) GetMemoryUsage() float64 {
+	if x != nil {
+		return x.MemoryUsage
+	}
+	return 0
+}
+
+func (x *GetTinyAppUsageMetricsResponse) GetMemoryLimit() float64 {
+	if x != nil {
+		return x.MemoryLimit
+	}
+	return 0
+}
+
+func (x *GetTinyAppUsageMetricsResponse) GetPercentCpuUsed() float64 {
+	if x != nil {
+		return x.PercentCpuUsed
+	}
+	return 0
+}
+
+func (x *GetTinyAppUsageMetricsResponse) GetPercentMemoryUsed() float64 {
+	if x != nil {
+		return x.PercentMemoryUsed
+	}
+	return 0
+}
+
+type GetTinyAppRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+}
+
+func (x *GetTinyAppRequest) Reset() {
+	*x = GetTinyAppRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTinyAppRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTinyAppRequest) ProtoMessage() {}
+
+func (x *GetTinyAppRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTinyAppRequest.ProtoReflect.Descriptor instead.
+func (*GetTinyAppRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetTinyAppRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type ListGitWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"` // Only list deliveries that redeployed this app. Required unless caller is an admin.
+}
+
+func (x *ListGitWebhookDeliveriesRequest) Reset() {
+	*x = ListGitWebhookDeliveriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGitWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGitWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListGitWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGitWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListGitWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListGitWebhookDeliveriesRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type GitRefUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ref    string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"` // Full ref name (ex. refs/heads/main)
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (x *GitRefUpdate) Reset() {
+	*x = GitRefUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GitRefUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GitRefUpdate) ProtoMessage() {}
+
+func (x *GitRefUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GitRefUpdate.ProtoReflect.Descriptor instead.
+func (*GitRefUpdate) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GitRefUpdate) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *GitRefUpdate) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+type GitWebhookDelivery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`             // Delivery id sent by git host
+	Provider        string          `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"` // github, gitlab, bitbucket or gitea
+	RepoUrl         string          `protobuf:"bytes,3,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
+	Refs            []*GitRefUpdate `protobuf:"bytes,4,rep,name=refs,proto3" json:"refs,omitempty"`
+	ReceivedTime    string          `protobuf:"bytes,5,opt,name=received_time,json=receivedTime,proto3" json:"received_time,omitempty"` // RFC3339
+	TriggeredAppIds []string        `protobuf:"bytes,6,rep,name=triggered_app_ids,json=triggeredAppIds,proto3" json:"triggered_app_ids,omitempty"`
+	Error           string          `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // Set if apps could not be redeployed
+}
+
+func (x *GitWebhookDelivery) Reset() {
+	*x = GitWebhookDelivery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GitWebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GitWebhookDelivery) ProtoMessage() {}
+
+func (x *GitWebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GitWebhookDelivery.ProtoReflect.Descriptor instead.
+func (*GitWebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GitWebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GitWebhookDelivery) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GitWebhookDelivery) GetRepoUrl() string {
+	if x != nil {
+		return x.RepoUrl
+	}
+	return ""
+}
+
+func (x *GitWebhookDelivery) GetRefs() []*GitRefUpdate {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+func (x *GitWebhookDelivery) GetReceivedTime() string {
+	if x != nil {
+		return x.ReceivedTime
+	}
+	return ""
+}
+
+func (x *GitWebhookDelivery) GetTriggeredAppIds() []string {
+	if x != nil {
+		return x.TriggeredAppIds
+	}
+	return nil
+}
+
+func (x *GitWebhookDelivery) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListGitWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deliveries    []*GitWebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	RejectedCount int64                 `protobuf:"varint,2,opt,name=rejected_count,json=rejectedCount,proto3" json:"rejected_count,omitempty"` // Webhooks rejected for invalid signature since server started. Only set when app_id is empty.
+}
+
+func (x *ListGitWebhookDeliveriesResponse) Reset() {
+	*x = ListGitWebhookDeliveriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateTinyAppResponse) String() string {
+func (x *ListGitWebhookDeliveriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTinyAppResponse) ProtoMessage() {}
+func (*ListGitWebhookDeliveriesResponse) ProtoMessage() {}
 
-func (x *CreateTinyAppResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[8]
+func (x *ListGitWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,44 +2909,56 @@ func (x *CreateTinyAppResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTinyAppResponse.ProtoReflect.Descriptor instead.
-func (*CreateTinyAppResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListGitWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListGitWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *CreateTinyAppResponse) GetAppRelease() *TinyAppRelease {
+func (x *ListGitWebhookDeliveriesResponse) GetDeliveries() []*GitWebhookDelivery {
 	if x != nil {
-		return x.AppRelease
+		return x.Deliveries
 	}
 	return nil
 }
 
-type GetTinyAppAccessMetricsRequest struct {
+func (x *ListGitWebhookDeliveriesResponse) GetRejectedCount() int64 {
+	if x != nil {
+		return x.RejectedCount
+	}
+	return 0
+}
+
+type TinyAppPod struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppId      string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	TimePeriod string `protobuf:"bytes,2,opt,name=time_period,json=timePeriod,proto3" json:"time_period,omitempty"`
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Phase        string `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	Ready        bool   `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	RestartCount int32  `protobuf:"varint,4,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"` // Total restarts of all pod containers
+	ImageDigest  string `protobuf:"bytes,5,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"`     // Digest of app image running in pod
+	GitCommit    string `protobuf:"bytes,6,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`           // Commit of app source in pod. Empty if app source is not git.
+	StartTime    string `protobuf:"bytes,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
 }
 
-func (x *GetTinyAppAccessMetricsRequest) Reset() {
-	*x = GetTinyAppAccessMetricsRequest{}
+func (x *TinyAppPod) Reset() {
+	*x = TinyAppPod{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[9]
+		mi := &file_api_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTinyAppAccessMetricsRequest) String() string {
+func (x *TinyAppPod) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTinyAppAccessMetricsRequest) ProtoMessage() {}
+func (*TinyAppPod) ProtoMessage() {}
 
-func (x *GetTinyAppAccessMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[9]
+func (x *TinyAppPod) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -740,50 +2969,88 @@ func (x *GetTinyAppAccessMetricsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTinyAppAccessMetricsRequest.ProtoReflect.Descriptor instead.
-func (*GetTinyAppAccessMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use TinyAppPod.ProtoReflect.Descriptor instead.
+func (*TinyAppPod) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetTinyAppAccessMetricsRequest) GetAppId() string {
+func (x *TinyAppPod) GetName() string {
 	if x != nil {
-		return x.AppId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *GetTinyAppAccessMetricsRequest) GetTimePeriod() string {
+func (x *TinyAppPod) GetPhase() string {
 	if x != nil {
-		return x.TimePeriod
+		return x.Phase
 	}
 	return ""
 }
 
-type GetTinyAppAccessMetricsResponse struct {
+func (x *TinyAppPod) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *TinyAppPod) GetRestartCount() int32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+func (x *TinyAppPod) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+func (x *TinyAppPod) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *TinyAppPod) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+type TinyAppEndpoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NumberOfAccess int32 `protobuf:"varint,1,opt,name=number_of_access,json=numberOfAccess,proto3" json:"number_of_access,omitempty"`
+	AppUrl            string `protobuf:"bytes,1,opt,name=app_url,json=appUrl,proto3" json:"app_url,omitempty"`                          // Public url of app
+	ServiceUrl        string `protobuf:"bytes,2,opt,name=service_url,json=serviceUrl,proto3" json:"service_url,omitempty"`              // In-cluster url of app
+	ReadyAddresses    int32  `protobuf:"varint,3,opt,name=ready_addresses,json=readyAddresses,proto3" json:"ready_addresses,omitempty"` // Number of app pods receiving traffic
+	NotReadyAddresses int32  `protobuf:"varint,4,opt,name=not_ready_addresses,json=notReadyAddresses,proto3" json:"not_ready_addresses,omitempty"`
 }
 
-func (x *GetTinyAppAccessMetricsResponse) Reset() {
-	*x = GetTinyAppAccessMetricsResponse{}
+func (x *TinyAppEndpoint) Reset() {
+	*x = TinyAppEndpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[10]
+		mi := &file_api_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTinyAppAccessMetricsResponse) String() string {
+func (x *TinyAppEndpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTinyAppAccessMetricsResponse) ProtoMessage() {}
+func (*TinyAppEndpoint) ProtoMessage() {}
 
-func (x *GetTinyAppAccessMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[10]
+func (x *TinyAppEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,44 +3061,68 @@ func (x *GetTinyAppAccessMetricsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTinyAppAccessMetricsResponse.ProtoReflect.Descriptor instead.
-func (*GetTinyAppAccessMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use TinyAppEndpoint.ProtoReflect.Descriptor instead.
+func (*TinyAppEndpoint) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *GetTinyAppAccessMetricsResponse) GetNumberOfAccess() int32 {
+func (x *TinyAppEndpoint) GetAppUrl() string {
 	if x != nil {
-		return x.NumberOfAccess
+		return x.AppUrl
+	}
+	return ""
+}
+
+func (x *TinyAppEndpoint) GetServiceUrl() string {
+	if x != nil {
+		return x.ServiceUrl
+	}
+	return ""
+}
+
+func (x *TinyAppEndpoint) GetReadyAddresses() int32 {
+	if x != nil {
+		return x.ReadyAddresses
 	}
 	return 0
 }
 
-type GetTinyAppUsageMetricsRequest struct {
+func (x *TinyAppEndpoint) GetNotReadyAddresses() int32 {
+	if x != nil {
+		return x.NotReadyAddresses
+	}
+	return 0
+}
+
+type GetTinyAppResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppId      string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	TimePeriod string `protobuf:"bytes,2,opt,name=time_period,json=timePeriod,proto3" json:"time_period,omitempty"`
+	App         *TinyApp         `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	ImageDigest string           `protobuf:"bytes,2,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"` // Digest of app image deployed. Empty if no pod is running.
+	GitCommit   string           `protobuf:"bytes,3,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`       // Commit of app source deployed. Empty if app source is not git or no pod is running.
+	Pods        []*TinyAppPod    `protobuf:"bytes,4,rep,name=pods,proto3" json:"pods,omitempty"`
+	Endpoint    *TinyAppEndpoint `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
 }
 
-func (x *GetTinyAppUsageMetricsRequest) Reset() {
-	*x = GetTinyAppUsageMetricsRequest{}
+func (x *GetTinyAppResponse) Reset() {
+	*x = GetTinyAppResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[11]
+		mi := &file_api_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTinyAppUsageMetricsRequest) String() string {
+func (x *GetTinyAppResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTinyAppUsageMetricsRequest) ProtoMessage() {}
+func (*GetTinyAppResponse) ProtoMessage() {}
 
-func (x *GetTinyAppUsageMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[11]
+func (x *GetTinyAppResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -842,55 +3133,81 @@ func (x *GetTinyAppUsageMetricsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTinyAppUsageMetricsRequest.ProtoReflect.Descriptor instead.
-func (*GetTinyAppUsageMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetTinyAppResponse.ProtoReflect.Descriptor instead.
+func (*GetTinyAppResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetTinyAppUsageMetricsRequest) GetAppId() string {
+func (x *GetTinyAppResponse) GetApp() *TinyApp {
 	if x != nil {
-		return x.AppId
+		return x.App
+	}
+	return nil
+}
+
+func (x *GetTinyAppResponse) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
 	}
 	return ""
 }
 
-func (x *GetTinyAppUsageMetricsRequest) GetTimePeriod() string {
+func (x *GetTinyAppResponse) GetGitCommit() string {
 	if x != nil {
-		return x.TimePeriod
+		return x.GitCommit
 	}
 	return ""
 }
 
-type GetTinyAppUsageMetricsResponse struct {
+func (x *GetTinyAppResponse) GetPods() []*TinyAppPod {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+func (x *GetTinyAppResponse) GetEndpoint() *TinyAppEndpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+type ListTinyAppsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CpuUsage          float64 `protobuf:"fixed64,1,opt,name=cpu_usage,json=cpuUsage,proto3" json:"cpu_usage,omitempty"`
-	CpuLimit          float64 `protobuf:"fixed64,2,opt,name=cpu_limit,json=cpuLimit,proto3" json:"cpu_limit,omitempty"`
-	MemoryUsage       float64 `protobuf:"fixed64,3,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
-	MemoryLimit       float64 `protobuf:"fixed64,4,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
-	PercentCpuUsed    float64 `protobuf:"fixed64,5,opt,name=percent_cpu_used,json=percentCpuUsed,proto3" json:"percent_cpu_used,omitempty"`
-	PercentMemoryUsed float64 `protobuf:"fixed64,6,opt,name=percent_memory_used,json=percentMemoryUsed,proto3" json:"percent_memory_used,omitempty"`
+	AppId         string         `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	AppDetail     *TinyAppDetail `protobuf:"bytes,2,opt,name=app_detail,json=appDetail,proto3" json:"app_detail,omitempty"`
+	PageSize      int32          `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Max number of apps to return. All apps are returned if not set.
+	PageToken     string         `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of previous response, to get the next page
+	AppType       AppType        `protobuf:"varint,5,opt,name=app_type,json=appType,proto3,enum=tiny.app.proto.AppType" json:"app_type,omitempty"`
+	SourceType    SourceType     `protobuf:"varint,6,opt,name=source_type,json=sourceType,proto3,enum=tiny.app.proto.SourceType" json:"source_type,omitempty"`
+	Phase         string         `protobuf:"bytes,7,opt,name=phase,proto3" json:"phase,omitempty"`                                                              // ex. Deployed, Failed
+	LabelSelector string         `protobuf:"bytes,8,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`                         // Kubernetes label selector (ex. team=research)
+	NameContains  string         `protobuf:"bytes,9,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"`                            // Case-insensitive substring of app display name
+	SortOrder     ListSortOrder  `protobuf:"varint,10,opt,name=sort_order,json=sortOrder,proto3,enum=tiny.app.proto.ListSortOrder" json:"sort_order,omitempty"` // Apps are sorted across pages. page_token must come from a request with the same sort_order.
+	Mine          bool           `protobuf:"varint,11,opt,name=mine,proto3" json:"mine,omitempty"`                                                              // Only apps the caller can manage. Requires authentication.
 }
 
-func (x *GetTinyAppUsageMetricsResponse) Reset() {
-	*x = GetTinyAppUsageMetricsResponse{}
+func (x *ListTinyAppsRequest) Reset() {
+	*x = ListTinyAppsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[12]
+		mi := &file_api_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTinyAppUsageMetricsResponse) String() string {
+func (x *ListTinyAppsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTinyAppUsageMetricsResponse) ProtoMessage() {}
+func (*ListTinyAppsRequest) ProtoMessage() {}
 
-func (x *GetTinyAppUsageMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[12]
+func (x *ListTinyAppsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -901,79 +3218,114 @@ func (x *GetTinyAppUsageMetricsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTinyAppUsageMetricsResponse.ProtoReflect.Descriptor instead.
-func (*GetTinyAppUsageMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListTinyAppsRequest.ProtoReflect.Descriptor instead.
+func (*ListTinyAppsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *GetTinyAppUsageMetricsResponse) GetCpuUsage() float64 {
+func (x *ListTinyAppsRequest) GetAppId() string {
 	if x != nil {
-		return x.CpuUsage
+		return x.AppId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetTinyAppUsageMetricsResponse) GetCpuLimit() float64 {
+func (x *ListTinyAppsRequest) GetAppDetail() *TinyAppDetail {
 	if x != nil {
-		return x.CpuLimit
+		return x.AppDetail
 	}
-	return 0
+	return nil
 }
 
-func (x *GetTinyAppUsageMetricsResponse) GetMemoryUsage() float64 {
+func (x *ListTinyAppsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.MemoryUsage
+		return x.PageSize
 	}
 	return 0
 }
 
-func (x *GetTinyAppUsageMetricsResponse) GetMemoryLimit() float64 {
+func (x *ListTinyAppsRequest) GetPageToken() string {
 	if x != nil {
-		return x.MemoryLimit
+		return x.PageToken
 	}
-	return 0
+	return ""
 }
 
-func (x *GetTinyAppUsageMetricsResponse) GetPercentCpuUsed() float64 {
+func (x *ListTinyAppsRequest) GetAppType() AppType {
 	if x != nil {
-		return x.PercentCpuUsed
+		return x.AppType
 	}
-	return 0
+	return AppType_APP_TYPE_UNKNOWN
 }
 
-func (x *GetTinyAppUsageMetricsResponse) GetPercentMemoryUsed() float64 {
+func (x *ListTinyAppsRequest) GetSourceType() SourceType {
 	if x != nil {
-		return x.PercentMemoryUsed
+		return x.SourceType
 	}
-	return 0
+	return SourceType_SOURCE_TYPE_UNKNOWN
 }
 
-type ListTinyAppsRequest struct {
+func (x *ListTinyAppsRequest) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ListTinyAppsRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *ListTinyAppsRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *ListTinyAppsRequest) GetSortOrder() ListSortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ListSortOrder_LIST_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListTinyAppsRequest) GetMine() bool {
+	if x != nil {
+		return x.Mine
+	}
+	return false
+}
+
+type ListTinyAppsWarning struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppId     string         `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	AppDetail *TinyAppDetail `protobuf:"bytes,2,opt,name=app_detail,json=appDetail,proto3" json:"app_detail,omitempty"`
+	AppId   string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *ListTinyAppsRequest) Reset() {
-	*x = ListTinyAppsRequest{}
+func (x *ListTinyAppsWarning) Reset() {
+	*x = ListTinyAppsWarning{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[13]
+		mi := &file_api_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListTinyAppsRequest) String() string {
+func (x *ListTinyAppsWarning) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTinyAppsRequest) ProtoMessage() {}
+func (*ListTinyAppsWarning) ProtoMessage() {}
 
-func (x *ListTinyAppsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[13]
+func (x *ListTinyAppsWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,23 +3336,23 @@ func (x *ListTinyAppsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTinyAppsRequest.ProtoReflect.Descriptor instead.
-func (*ListTinyAppsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListTinyAppsWarning.ProtoReflect.Descriptor instead.
+func (*ListTinyAppsWarning) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ListTinyAppsRequest) GetAppId() string {
+func (x *ListTinyAppsWarning) GetAppId() string {
 	if x != nil {
 		return x.AppId
 	}
 	return ""
 }
 
-func (x *ListTinyAppsRequest) GetAppDetail() *TinyAppDetail {
+func (x *ListTinyAppsWarning) GetMessage() string {
 	if x != nil {
-		return x.AppDetail
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
 type ListTinyAppsResponse struct {
@@ -1008,13 +3360,15 @@ type ListTinyAppsResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Apps []*TinyApp `protobuf:"bytes,1,rep,name=apps,proto3" json:"apps,omitempty"`
+	Apps          []*TinyApp             `protobuf:"bytes,1,rep,name=apps,proto3" json:"apps,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty if there are no more apps
+	Warnings      []*ListTinyAppsWarning `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`                                  // Apps that matched but could not be returned
 }
 
 func (x *ListTinyAppsResponse) Reset() {
 	*x = ListTinyAppsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[14]
+		mi := &file_api_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1027,7 +3381,7 @@ func (x *ListTinyAppsResponse) String() string {
 func (*ListTinyAppsResponse) ProtoMessage() {}
 
 func (x *ListTinyAppsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[14]
+	mi := &file_api_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1038,14 +3392,28 @@ func (x *ListTinyAppsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTinyAppsResponse.ProtoReflect.Descriptor instead.
-func (*ListTinyAppsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListTinyAppsResponse.ProtoReflect.Descriptor instead.
+func (*ListTinyAppsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListTinyAppsResponse) GetApps() []*TinyApp {
+	if x != nil {
+		return x.Apps
+	}
+	return nil
+}
+
+func (x *ListTinyAppsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
 }
 
-func (x *ListTinyAppsResponse) GetApps() []*TinyApp {
+func (x *ListTinyAppsResponse) GetWarnings() []*ListTinyAppsWarning {
 	if x != nil {
-		return x.Apps
+		return x.Warnings
 	}
 	return nil
 }
@@ -1062,7 +3430,7 @@ type UpdateTinyAppRequest struct {
 func (x *UpdateTinyAppRequest) Reset() {
 	*x = UpdateTinyAppRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[15]
+		mi := &file_api_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1075,7 +3443,7 @@ func (x *UpdateTinyAppRequest) String() string {
 func (*UpdateTinyAppRequest) ProtoMessage() {}
 
 func (x *UpdateTinyAppRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[15]
+	mi := &file_api_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1088,7 +3456,7 @@ func (x *UpdateTinyAppRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTinyAppRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTinyAppRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *UpdateTinyAppRequest) GetAppId() string {
@@ -1116,7 +3484,7 @@ type UpdateTinyAppResponse struct {
 func (x *UpdateTinyAppResponse) Reset() {
 	*x = UpdateTinyAppResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[16]
+		mi := &file_api_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1129,7 +3497,7 @@ func (x *UpdateTinyAppResponse) String() string {
 func (*UpdateTinyAppResponse) ProtoMessage() {}
 
 func (x *UpdateTinyAppResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[16]
+	mi := &file_api_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1142,7 +3510,7 @@ func (x *UpdateTinyAppResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTinyAppResponse.ProtoReflect.Descriptor instead.
 func (*UpdateTinyAppResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{16}
+	return file_api_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *UpdateTinyAppResponse) GetAppRelease() *TinyAppRelease {
@@ -1163,7 +3531,7 @@ type DeleteTinyAppRequest struct {
 func (x *DeleteTinyAppRequest) Reset() {
 	*x = DeleteTinyAppRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[17]
+		mi := &file_api_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1176,7 +3544,7 @@ func (x *DeleteTinyAppRequest) String() string {
 func (*DeleteTinyAppRequest) ProtoMessage() {}
 
 func (x *DeleteTinyAppRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[17]
+	mi := &file_api_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1189,7 +3557,7 @@ func (x *DeleteTinyAppRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTinyAppRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTinyAppRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{17}
+	return file_api_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *DeleteTinyAppRequest) GetAppId() string {
@@ -1210,7 +3578,7 @@ type GetTinyAppLogsRequest struct {
 func (x *GetTinyAppLogsRequest) Reset() {
 	*x = GetTinyAppLogsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[18]
+		mi := &file_api_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1223,7 +3591,7 @@ func (x *GetTinyAppLogsRequest) String() string {
 func (*GetTinyAppLogsRequest) ProtoMessage() {}
 
 func (x *GetTinyAppLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[18]
+	mi := &file_api_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1236,7 +3604,7 @@ func (x *GetTinyAppLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTinyAppLogsRequest.ProtoReflect.Descriptor instead.
 func (*GetTinyAppLogsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{18}
+	return file_api_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *GetTinyAppLogsRequest) GetAppId() string {
@@ -1257,7 +3625,7 @@ type GetTinyAppLogsResponse struct {
 func (x *GetTinyAppLogsResponse) Reset() {
 	*x = GetTinyAppLogsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[19]
+		mi := &file_api_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1270,7 +3638,7 @@ func (x *GetTinyAppLogsResponse) String() string {
 func (*GetTinyAppLogsResponse) ProtoMessage() {}
 
 func (x *GetTinyAppLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[19]
+	mi := &file_api_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1283,7 +3651,7 @@ func (x *GetTinyAppLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTinyAppLogsResponse.ProtoReflect.Descriptor instead.
 func (*GetTinyAppLogsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{19}
+	return file_api_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *GetTinyAppLogsResponse) GetLogs() string {
@@ -1293,6 +3661,180 @@ func (x *GetTinyAppLogsResponse) GetLogs() string {
 	return ""
 }
 
+type StreamTinyAppLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId        string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Container    string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`                                  // One of app, git-sync, git-revision or reverse-proxy. Defaults to app.
+	Follow       bool   `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`                                       // Keep streaming new log lines until the request is cancelled
+	TailLines    *int64 `protobuf:"varint,4,opt,name=tail_lines,json=tailLines,proto3,oneof" json:"tail_lines,omitempty"`          // Number of lines from the end of the log to start from, per pod
+	SinceSeconds *int64 `protobuf:"varint,5,opt,name=since_seconds,json=sinceSeconds,proto3,oneof" json:"since_seconds,omitempty"` // Only return lines newer than this many seconds
+	Timestamps   bool   `protobuf:"varint,6,opt,name=timestamps,proto3" json:"timestamps,omitempty"`                               // Prefix each line with its timestamp
+	Previous     bool   `protobuf:"varint,7,opt,name=previous,proto3" json:"previous,omitempty"`                                   // Logs of the previous, terminated instance of the container (ex. after a crash)
+	PodName      string `protobuf:"bytes,8,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`                       // Only stream logs of this pod. All app pods are streamed if not set.
+}
+
+func (x *StreamTinyAppLogsRequest) Reset() {
+	*x = StreamTinyAppLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamTinyAppLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTinyAppLogsRequest) ProtoMessage() {}
+
+func (x *StreamTinyAppLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTinyAppLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamTinyAppLogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *StreamTinyAppLogsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *StreamTinyAppLogsRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *StreamTinyAppLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+func (x *StreamTinyAppLogsRequest) GetTailLines() int64 {
+	if x != nil && x.TailLines != nil {
+		return *x.TailLines
+	}
+	return 0
+}
+
+func (x *StreamTinyAppLogsRequest) GetSinceSeconds() int64 {
+	if x != nil && x.SinceSeconds != nil {
+		return *x.SinceSeconds
+	}
+	return 0
+}
+
+func (x *StreamTinyAppLogsRequest) GetTimestamps() bool {
+	if x != nil {
+		return x.Timestamps
+	}
+	return false
+}
+
+func (x *StreamTinyAppLogsRequest) GetPrevious() bool {
+	if x != nil {
+		return x.Previous
+	}
+	return false
+}
+
+func (x *StreamTinyAppLogsRequest) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+type TinyAppLogLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PodName   string `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Line      string `protobuf:"bytes,3,opt,name=line,proto3" json:"line,omitempty"`
+	Error     string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // Set instead of line if logs of the pod could not be streamed
+}
+
+func (x *TinyAppLogLine) Reset() {
+	*x = TinyAppLogLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TinyAppLogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinyAppLogLine) ProtoMessage() {}
+
+func (x *TinyAppLogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinyAppLogLine.ProtoReflect.Descriptor instead.
+func (*TinyAppLogLine) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TinyAppLogLine) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *TinyAppLogLine) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *TinyAppLogLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *TinyAppLogLine) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_api_proto protoreflect.FileDescriptor
 
 var file_api_proto_rawDesc = []byte{
@@ -1315,206 +3857,696 @@ var file_api_proto_rawDesc = []byte{
 	0x6e, 0x76, 0x56, 0x61, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
 	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
-	0x5c, 0x0a, 0x09, 0x47, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x0a, 0x03,
-	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x74, 0x61, 0x67,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x54, 0x61, 0x67, 0x22, 0xf0, 0x03,
-	0x0a, 0x0d, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6f,
-	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69,
-	0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67,
-	0x65, 0x12, 0x32, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x70, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x61, 0x70,
-	0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x69, 0x6e,
-	0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x67, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70,
-	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x52, 0x09, 0x67, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a, 0x0e,
-	0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x69, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61,
-	0x74, 0x68, 0x12, 0x28, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x40, 0x0a, 0x0d,
-	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x18, 0x0a, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x69, 0x6d,
-	0x52, 0x0c, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x12, 0x30,
-	0x0a, 0x13, 0x6d, 0x61, 0x69, 0x6e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x69,
-	0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x6d, 0x61, 0x69,
-	0x6e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4e, 0x61, 0x6d, 0x65,
-	0x22, 0x86, 0x01, 0x0a, 0x0e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x6c, 0x65,
-	0x61, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x70, 0x70, 0x55, 0x72, 0x6c, 0x12, 0x2e, 0x0a, 0x13,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1b, 0x0a, 0x09,
-	0x61, 0x70, 0x70, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x61, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x88, 0x01, 0x0a, 0x07, 0x54, 0x69,
-	0x6e, 0x79, 0x41, 0x70, 0x70, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x72, 0x65, 0x6c,
-	0x65, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x69, 0x6e,
-	0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79,
-	0x41, 0x70, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x52,
-	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x5f, 0x64, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x6e,
-	0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79,
-	0x41, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x09, 0x61, 0x70, 0x70, 0x44, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x22, 0x54, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69,
-	0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0a,
-	0x61, 0x70, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52,
-	0x09, 0x61, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x58, 0x0a, 0x15, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61,
-	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e,
+	0xd0, 0x01, 0x0a, 0x09, 0x47, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x74, 0x61,
+	0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x54, 0x61, 0x67, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x65, 0x66, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x66, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x07, 0x72, 0x65, 0x66, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x22, 0x93, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x70, 0x75, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x25,
+	0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xdf, 0x01, 0x0a, 0x0b, 0x41, 0x75, 0x74,
+	0x6f, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00,
+	0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x73, 0x12, 0x4e, 0x0a, 0x21, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x70,
+	0x75, 0x5f, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01,
+	0x52, 0x1e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x43, 0x70, 0x75, 0x55, 0x74, 0x69, 0x6c, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65,
+	0x88, 0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x73, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x63, 0x70, 0x75, 0x5f, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x09, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x41, 0x70, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x32, 0x0a, 0x16, 0x62, 0x61,
+	0x73, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x65, 0x6e, 0x76, 0x5f, 0x76, 0x61, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x62, 0x61, 0x73, 0x65,
+	0x50, 0x61, 0x74, 0x68, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xed,
+	0x06, 0x0a, 0x0d, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x70, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x61,
+	0x70, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x67, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61,
+	0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x09, 0x67, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a,
+	0x0e, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x69, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x40, 0x0a,
+	0x0d, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x69,
+	0x6d, 0x52, 0x0c, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x12,
+	0x30, 0x0a, 0x13, 0x6d, 0x61, 0x69, 0x6e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6c, 0x61,
+	0x69, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x6d, 0x61,
+	0x69, 0x6e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x37, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
+	0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x08, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3d, 0x0a, 0x0b, 0x61,
+	0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x0b, 0x61,
+	0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x64,
+	0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x2b, 0x0a,
+	0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x70, 0x70,
+	0x41, 0x75, 0x74, 0x68, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70,
+	0x70, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x61, 0x70, 0x70, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x41, 0x70, 0x70, 0x52, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x12, 0x31,
+	0x0a, 0x06, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x41, 0x70, 0x70, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x22, 0xec,
+	0x01, 0x0a, 0x09, 0x41, 0x70, 0x70, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x37, 0x0a, 0x07,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
+	0x72, 0x6f, 0x62, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x07, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x75, 0x70, 0x12, 0x3b, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65,
+	0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e,
+	0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65,
+	0x73, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x6c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x08, 0x6c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x22, 0xab, 0x02,
+	0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x37, 0x0a, 0x15, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00,
+	0x52, 0x13, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x01, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52,
+	0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x30, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52,
+	0x10, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x88, 0x01, 0x01, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x42, 0x11,
+	0x0a, 0x0f, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x55, 0x0a, 0x07, 0x41,
+	0x70, 0x70, 0x41, 0x75, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x22, 0xc8, 0x01, 0x0a, 0x0e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x70, 0x70, 0x55, 0x72, 0x6c, 0x12, 0x2e,
+	0x0a, 0x13, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1b,
+	0x0a, 0x09, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x61, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67,
+	0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x67, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0xa2, 0x01,
+	0x0a, 0x10, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x30, 0x0a, 0x14, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12,
+	0x6c, 0x61, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0xed, 0x02, 0x0a, 0x0d, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x61, 0x64,
+	0x79, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0d, 0x72, 0x65, 0x61, 0x64, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12,
+	0x2f, 0x0a, 0x13, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x6f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
+	0x2e, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x67, 0x69, 0x74, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x47, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x22, 0x59, 0x0a, 0x0d, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0xf6, 0x01,
+	0x0a, 0x07, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x70, 0x70,
+	0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x0a,
+	0x61, 0x70, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x61, 0x70,
+	0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x09, 0x61,
+	0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x35, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e,
 	0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70,
-	0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x52, 0x65, 0x6c,
-	0x65, 0x61, 0x73, 0x65, 0x22, 0x58, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41,
-	0x70, 0x70, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a,
-	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0x4b,
-	0x0a, 0x1f, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x61,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x4f, 0x66, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x57, 0x0a, 0x1d, 0x47,
-	0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65,
-	0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06,
-	0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70,
-	0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69,
-	0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x50, 0x65,
-	0x72, 0x69, 0x6f, 0x64, 0x22, 0xfa, 0x01, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79,
-	0x41, 0x70, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f, 0x75,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63, 0x70, 0x75, 0x55,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69,
-	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x63, 0x65,
-	0x6e, 0x74, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x0e, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x43, 0x70, 0x75, 0x55, 0x73, 0x65,
-	0x64, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x65, 0x6d,
-	0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11,
-	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x65,
-	0x64, 0x22, 0x6a, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
+	0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x35, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x06,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x65, 0x0a, 0x1e, 0x41, 0x64, 0x64, 0x54, 0x69, 0x6e,
+	0x79, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72,
 	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f,
 	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12,
-	0x3c, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20,
+	0x14, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x68, 0x0a,
+	0x21, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x43, 0x6f,
+	0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x54, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3c, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61,
-	0x69, 0x6c, 0x52, 0x09, 0x61, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x43, 0x0a,
-	0x14, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x61, 0x70, 0x70, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x04, 0x61, 0x70,
-	0x70, 0x73, 0x22, 0x6b, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79,
-	0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70,
-	0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49,
-	0x64, 0x12, 0x3c, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x44, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x52, 0x09, 0x61, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22,
-	0x58, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f,
-	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x69, 0x6c, 0x52, 0x09, 0x61, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x58, 0x0a,
+	0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x72, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e,
+	0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x0a, 0x61, 0x70, 0x70,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x58, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x54, 0x69,
+	0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x22, 0x4b, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f,
+	0x66, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x56,
+	0x0a, 0x1c, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73, 0x65, 0x72,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0x77, 0x0a, 0x11, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70,
+	0x70, 0x55, 0x73, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0xcb, 0x02, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73,
+	0x65, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x37, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73, 0x65, 0x72, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x69,
+	0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x12, 0x34, 0x0a, 0x16, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f, 0x75, 0x73, 0x5f, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x14, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f, 0x75, 0x73, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x61, 0x6e, 0x6f, 0x6e, 0x79,
+	0x6d, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x15, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d,
+	0x6f, 0x75, 0x73, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a,
+	0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x59, 0x0a,
+	0x1f, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x54, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69,
+	0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0xde, 0x02, 0x0a, 0x20, 0x47, 0x65, 0x74,
+	0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
+	0x13, 0x70, 0x35, 0x30, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x70, 0x35, 0x30, 0x4c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a,
+	0x13, 0x70, 0x39, 0x35, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x70, 0x39, 0x35, 0x4c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x61, 0x74, 0x65, 0x12,
+	0x40, 0x0a, 0x1c, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x1a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x57, 0x65, 0x62,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x61, 0x74,
+	0x65, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x73, 0x65, 0x6e, 0x74,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x61, 0x74, 0x65, 0x22, 0x8a, 0x01, 0x0a, 0x1d, 0x47, 0x65,
+	0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61,
+	0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70,
+	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74,
+	0x65, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x41, 0x0a, 0x0b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x0c, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e,
+	0x69, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x92, 0x01,
+	0x0a, 0x1e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x34, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x06,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74,
+	0x65, 0x70, 0x22, 0x57, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0xfa, 0x01, 0x0a, 0x1e,
+	0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x63, 0x70, 0x75, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x70, 0x75, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x28,
+	0x0a, 0x10, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x75, 0x73,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x43, 0x70, 0x75, 0x55, 0x73, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x65, 0x64, 0x22, 0x2a, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54,
+	0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a,
+	0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61,
+	0x70, 0x70, 0x49, 0x64, 0x22, 0x38, 0x0a, 0x1f, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x69, 0x74, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x22, 0x38,
+	0x0a, 0x0c, 0x47, 0x69, 0x74, 0x52, 0x65, 0x66, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0xf4, 0x01, 0x0a, 0x12, 0x47, 0x69, 0x74,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x72,
+	0x65, 0x70, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72,
+	0x65, 0x70, 0x6f, 0x55, 0x72, 0x6c, 0x12, 0x30, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x66, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2a, 0x0a,
+	0x11, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x70, 0x70, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65,
+	0x72, 0x65, 0x64, 0x41, 0x70, 0x70, 0x49, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22,
+	0x8d, 0x01, 0x0a, 0x20, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x69, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e,
+	0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x69, 0x74, 0x57, 0x65, 0x62,
+	0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x0a, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0xd2, 0x01, 0x0a, 0x0a, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x50, 0x6f, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x23,
+	0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x69, 0x74, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x69, 0x74, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x22, 0xa4, 0x01, 0x0a, 0x0f, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
+	0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x5f,
+	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x70, 0x70, 0x55, 0x72,
+	0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55,
+	0x72, 0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x61,
+	0x64, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6e,
+	0x6f, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6e, 0x6f, 0x74, 0x52, 0x65, 0x61,
+	0x64, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0xee, 0x01, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x29, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x21, 0x0a,
+	0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
+	0x2e, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
 	0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54,
-	0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x0a, 0x61,
-	0x70, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x2d, 0x0a, 0x14, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x54,
-	0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x54,
-	0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x2a, 0x4b, 0x0a, 0x07, 0x41, 0x70, 0x70, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x50, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e,
-	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x50, 0x50, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x5f, 0x4c, 0x49, 0x54, 0x10, 0x01,
-	0x12, 0x11, 0x0a, 0x0d, 0x41, 0x50, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x41, 0x53,
-	0x48, 0x10, 0x02, 0x2a, 0x57, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f,
-	0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x49, 0x54, 0x10, 0x01, 0x12,
-	0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46,
-	0x49, 0x4c, 0x45, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x02, 0x32, 0xed, 0x06, 0x0a,
-	0x0d, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x70,
-	0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x12,
-	0x24, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e,
-	0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x0c, 0x3a, 0x01, 0x2a, 0x22, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70,
-	0x12, 0x6b, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73,
-	0x12, 0x23, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41,
-	0x70, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x0a, 0x12, 0x08, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x73, 0x12, 0x70, 0x0a,
-	0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x12, 0x24,
+	0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x50, 0x6f, 0x64, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x12,
+	0x3b, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0xcb, 0x03, 0x0a,
+	0x13, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x0a, 0x61,
+	0x70, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x09,
+	0x61, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x32, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61,
+	0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x70, 0x70, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x07, 0x61, 0x70, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
 	0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79,
-	0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x0c, 0x3a, 0x01, 0x2a, 0x32, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x12,
-	0x5e, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
-	0x12, 0x24, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x0f,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x09, 0x2a, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x12,
-	0x75, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67,
-	0x73, 0x12, 0x25, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e,
-	0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e,
-	0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70,
-	0x70, 0x2d, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x9a, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x54, 0x69,
-	0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x12, 0x2e, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x12, 0x16, 0x2f, 0x76, 0x31,
-	0x2f, 0x61, 0x70, 0x70, 0x2d, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2d, 0x6d, 0x65, 0x74, 0x72,
-	0x69, 0x63, 0x73, 0x12, 0x96, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41,
-	0x70, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2d,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x61, 0x6d, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74,
+	0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x74,
+	0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x09, 0x73, 0x6f, 0x72,
+	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x69, 0x6e, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x65, 0x22, 0x46, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e,
+	0x67, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0xac, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41,
+	0x70, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x61,
+	0x70, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x69, 0x6e, 0x79,
+	0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41,
+	0x70, 0x70, 0x52, 0x04, 0x61, 0x70, 0x70, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x3f, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73,
+	0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67,
+	0x73, 0x22, 0x6b, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41,
+	0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64,
+	0x12, 0x3c, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x52, 0x09, 0x61, 0x70, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x58,
+	0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x72,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74,
+	0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69,
+	0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x0a, 0x61, 0x70,
+	0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x2d, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x54, 0x69,
+	0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x54, 0x69,
+	0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6c, 0x6f, 0x67, 0x73, 0x22, 0xad, 0x02, 0x0a, 0x18, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12,
+	0x22, 0x0a, 0x0a, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x74, 0x61, 0x69, 0x6c, 0x4c, 0x69, 0x6e, 0x65, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x0c, 0x73, 0x69,
+	0x6e, 0x63, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6f, 0x64,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64,
+	0x4e, 0x61, 0x6d, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x73, 0x0a, 0x0e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
+	0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6f, 0x64, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x72, 0x0a, 0x0a, 0x47, 0x69,
+	0x74, 0x52, 0x65, 0x66, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x47, 0x49, 0x54, 0x5f,
+	0x52, 0x45, 0x46, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x47, 0x49, 0x54, 0x5f, 0x52, 0x45,
+	0x46, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x52, 0x41, 0x4e, 0x43, 0x48, 0x10, 0x01, 0x12,
+	0x14, 0x0a, 0x10, 0x47, 0x49, 0x54, 0x5f, 0x52, 0x45, 0x46, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x54, 0x41, 0x47, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x47, 0x49, 0x54, 0x5f, 0x52, 0x45, 0x46,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x10, 0x03, 0x2a, 0xc5,
+	0x01, 0x0a, 0x07, 0x41, 0x70, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x50,
+	0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
+	0x12, 0x17, 0x0a, 0x13, 0x41, 0x50, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x52,
+	0x45, 0x41, 0x4d, 0x5f, 0x4c, 0x49, 0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x50, 0x50,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x41, 0x53, 0x48, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f,
+	0x41, 0x50, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x52, 0x41, 0x44, 0x49, 0x4f, 0x10,
+	0x03, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x50, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x41,
+	0x4e, 0x45, 0x4c, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x50, 0x50, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x53, 0x48, 0x49, 0x4e, 0x59, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x50, 0x50,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x56, 0x4f, 0x49, 0x4c, 0x41, 0x10, 0x06, 0x12, 0x12, 0x0a,
+	0x0e, 0x41, 0x50, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x4f, 0x4b, 0x45, 0x48, 0x10,
+	0x07, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x50, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x55,
+	0x53, 0x54, 0x4f, 0x4d, 0x10, 0x08, 0x2a, 0x6e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x13, 0x0a,
+	0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x49, 0x54,
+	0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x02, 0x12,
+	0x15, 0x0a, 0x11, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49,
+	0x4d, 0x41, 0x47, 0x45, 0x10, 0x03, 0x2a, 0xb0, 0x01, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x1b, 0x4c, 0x49, 0x53, 0x54,
+	0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x4c, 0x49, 0x53,
+	0x54, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x4e, 0x41, 0x4d,
+	0x45, 0x5f, 0x41, 0x53, 0x43, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x4c, 0x49, 0x53, 0x54, 0x5f,
+	0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x5f,
+	0x44, 0x45, 0x53, 0x43, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x53,
+	0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
+	0x44, 0x5f, 0x41, 0x53, 0x43, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x4c, 0x49, 0x53, 0x54, 0x5f,
+	0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54,
+	0x45, 0x44, 0x5f, 0x44, 0x45, 0x53, 0x43, 0x10, 0x04, 0x32, 0xfc, 0x0f, 0x0a, 0x0d, 0x54, 0x69,
+	0x6e, 0x79, 0x41, 0x70, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x70, 0x0a, 0x0d, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x12, 0x24, 0x2e, 0x74,
+	0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70,
+	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x0c, 0x3a, 0x01, 0x2a, 0x22, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x12, 0x6d, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x12, 0x21, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
 	0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x76, 0x31, 0x2f,
+	0x61, 0x70, 0x70, 0x2f, 0x7b, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x93, 0x01, 0x0a,
+	0x17, 0x41, 0x64, 0x64, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6c, 0x6c, 0x61,
+	0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2e, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e,
+	0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x69, 0x6e,
+	0x79, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e,
+	0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70,
+	0x70, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x29, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x23, 0x3a,
+	0x01, 0x2a, 0x22, 0x1e, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x2f, 0x7b, 0x61, 0x70, 0x70,
+	0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x12, 0x96, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x69, 0x6e,
+	0x79, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x12, 0x31, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
+	0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x22, 0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x2a, 0x1e, 0x2f, 0x76, 0x31,
+	0x2f, 0x61, 0x70, 0x70, 0x2f, 0x7b, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x6f,
+	0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x6b, 0x0a, 0x0c, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73, 0x12, 0x23, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x24, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x12, 0x08,
+	0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x73, 0x12, 0x70, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x12, 0x24, 0x2e, 0x74, 0x69, 0x6e, 0x79,
+	0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x3a, 0x01,
+	0x2a, 0x32, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x12, 0x5e, 0x0a, 0x0d, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x12, 0x24, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x0f, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x09, 0x2a, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x12, 0x75, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x25, 0x2e, 0x74,
+	0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c,
+	0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x2d, 0x6c, 0x6f, 0x67,
+	0x73, 0x12, 0x7c, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x69, 0x6e, 0x79, 0x41,
+	0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x28, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x69,
+	0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65,
+	0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70,
+	0x70, 0x2d, 0x6c, 0x6f, 0x67, 0x73, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x30, 0x01, 0x12,
+	0x9a, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2e, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x74, 0x69,
+	0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x18, 0x12, 0x16, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x2d, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x2d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x92, 0x01, 0x0a,
+	0x15, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73, 0x65, 0x72, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2c, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41,
+	0x70, 0x70, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
+	0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x76, 0x31,
+	0x2f, 0x61, 0x70, 0x70, 0x2d, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x12, 0x9e, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70,
+	0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2f,
+	0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x30, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x54, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x70, 0x70, 0x2d, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x2d, 0x6d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x12, 0x96, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70,
+	0x70, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2d, 0x2e,
 	0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47,
-	0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65,
-	0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x2d, 0x75,
-	0x73, 0x61, 0x67, 0x65, 0x2d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x42, 0x46, 0x5a, 0x44,
-	0x67, 0x6f, 0x2e, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x72, 0x6f, 0x63, 0x6b, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65,
-	0x2d, 0x61, 0x70, 0x70, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2d, 0x6f, 0x70, 0x65,
-	0x6e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x74,
+	0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x2d, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x2d, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x96, 0x01, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2d, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41,
+	0x70, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6e, 0x79, 0x41, 0x70,
+	0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f,
+	0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x2d, 0x75, 0x73, 0x61, 0x67, 0x65, 0x2d, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x12, 0xa1, 0x01, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x69, 0x74,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x2f, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x69, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x30, 0x2e, 0x74, 0x69, 0x6e, 0x79, 0x2e, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x69, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x12, 0x1a, 0x2f, 0x76,
+	0x31, 0x2f, 0x67, 0x69, 0x74, 0x2d, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x2d, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x69, 0x6e, 0x79, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x76, 0x65, 0x72, 0x73, 0x65, 0x2f, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x70, 0x70, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1529,66 +4561,139 @@ func file_api_proto_rawDescGZIP() []byte {
 	return file_api_proto_rawDescData
 }
 
-var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_api_proto_goTypes = []interface{}{
-	(AppType)(0),                            // 0: tiny.app.proto.AppType
-	(SourceType)(0),                         // 1: tiny.app.proto.SourceType
-	(*VolumeClaim)(nil),                     // 2: tiny.app.proto.VolumeClaim
-	(*Volume)(nil),                          // 3: tiny.app.proto.Volume
-	(*EnvVar)(nil),                          // 4: tiny.app.proto.EnvVar
-	(*GitConfig)(nil),                       // 5: tiny.app.proto.GitConfig
-	(*TinyAppDetail)(nil),                   // 6: tiny.app.proto.TinyAppDetail
-	(*TinyAppRelease)(nil),                  // 7: tiny.app.proto.TinyAppRelease
-	(*TinyApp)(nil),                         // 8: tiny.app.proto.TinyApp
-	(*CreateTinyAppRequest)(nil),            // 9: tiny.app.proto.CreateTinyAppRequest
-	(*CreateTinyAppResponse)(nil),           // 10: tiny.app.proto.CreateTinyAppResponse
-	(*GetTinyAppAccessMetricsRequest)(nil),  // 11: tiny.app.proto.GetTinyAppAccessMetricsRequest
-	(*GetTinyAppAccessMetricsResponse)(nil), // 12: tiny.app.proto.GetTinyAppAccessMetricsResponse
-	(*GetTinyAppUsageMetricsRequest)(nil),   // 13: tiny.app.proto.GetTinyAppUsageMetricsRequest
-	(*GetTinyAppUsageMetricsResponse)(nil),  // 14: tiny.app.proto.GetTinyAppUsageMetricsResponse
-	(*ListTinyAppsRequest)(nil),             // 15: tiny.app.proto.ListTinyAppsRequest
-	(*ListTinyAppsResponse)(nil),            // 16: tiny.app.proto.ListTinyAppsResponse
-	(*UpdateTinyAppRequest)(nil),            // 17: tiny.app.proto.UpdateTinyAppRequest
-	(*UpdateTinyAppResponse)(nil),           // 18: tiny.app.proto.UpdateTinyAppResponse
-	(*DeleteTinyAppRequest)(nil),            // 19: tiny.app.proto.DeleteTinyAppRequest
-	(*GetTinyAppLogsRequest)(nil),           // 20: tiny.app.proto.GetTinyAppLogsRequest
-	(*GetTinyAppLogsResponse)(nil),          // 21: tiny.app.proto.GetTinyAppLogsResponse
-	(*emptypb.Empty)(nil),                   // 22: google.protobuf.Empty
+	(GitRefType)(0),                           // 0: tiny.app.proto.GitRefType
+	(AppType)(0),                              // 1: tiny.app.proto.AppType
+	(SourceType)(0),                           // 2: tiny.app.proto.SourceType
+	(ListSortOrder)(0),                        // 3: tiny.app.proto.ListSortOrder
+	(*VolumeClaim)(nil),                       // 4: tiny.app.proto.VolumeClaim
+	(*Volume)(nil),                            // 5: tiny.app.proto.Volume
+	(*EnvVar)(nil),                            // 6: tiny.app.proto.EnvVar
+	(*GitConfig)(nil),                         // 7: tiny.app.proto.GitConfig
+	(*Resources)(nil),                         // 8: tiny.app.proto.Resources
+	(*Autoscaling)(nil),                       // 9: tiny.app.proto.Autoscaling
+	(*CustomApp)(nil),                         // 10: tiny.app.proto.CustomApp
+	(*TinyAppDetail)(nil),                     // 11: tiny.app.proto.TinyAppDetail
+	(*AppProbes)(nil),                         // 12: tiny.app.proto.AppProbes
+	(*ProbeSettings)(nil),                     // 13: tiny.app.proto.ProbeSettings
+	(*AppAuth)(nil),                           // 14: tiny.app.proto.AppAuth
+	(*TinyAppRelease)(nil),                    // 15: tiny.app.proto.TinyAppRelease
+	(*TinyAppCondition)(nil),                  // 16: tiny.app.proto.TinyAppCondition
+	(*TinyAppStatus)(nil),                     // 17: tiny.app.proto.TinyAppStatus
+	(*TinyAppAccess)(nil),                     // 18: tiny.app.proto.TinyAppAccess
+	(*TinyApp)(nil),                           // 19: tiny.app.proto.TinyApp
+	(*AddTinyAppCollaboratorsRequest)(nil),    // 20: tiny.app.proto.AddTinyAppCollaboratorsRequest
+	(*RemoveTinyAppCollaboratorsRequest)(nil), // 21: tiny.app.proto.RemoveTinyAppCollaboratorsRequest
+	(*CreateTinyAppRequest)(nil),              // 22: tiny.app.proto.CreateTinyAppRequest
+	(*CreateTinyAppResponse)(nil),             // 23: tiny.app.proto.CreateTinyAppResponse
+	(*GetTinyAppAccessMetricsRequest)(nil),    // 24: tiny.app.proto.GetTinyAppAccessMetricsRequest
+	(*GetTinyAppAccessMetricsResponse)(nil),   // 25: tiny.app.proto.GetTinyAppAccessMetricsResponse
+	(*GetTinyAppUserMetricsRequest)(nil),      // 26: tiny.app.proto.GetTinyAppUserMetricsRequest
+	(*TinyAppUserAccess)(nil),                 // 27: tiny.app.proto.TinyAppUserAccess
+	(*GetTinyAppUserMetricsResponse)(nil),     // 28: tiny.app.proto.GetTinyAppUserMetricsResponse
+	(*GetTinyAppTrafficMetricsRequest)(nil),   // 29: tiny.app.proto.GetTinyAppTrafficMetricsRequest
+	(*GetTinyAppTrafficMetricsResponse)(nil),  // 30: tiny.app.proto.GetTinyAppTrafficMetricsResponse
+	(*GetTinyAppMetricsRangeRequest)(nil),     // 31: tiny.app.proto.GetTinyAppMetricsRangeRequest
+	(*MetricPoint)(nil),                       // 32: tiny.app.proto.MetricPoint
+	(*MetricSeries)(nil),                      // 33: tiny.app.proto.MetricSeries
+	(*GetTinyAppMetricsRangeResponse)(nil),    // 34: tiny.app.proto.GetTinyAppMetricsRangeResponse
+	(*GetTinyAppUsageMetricsRequest)(nil),     // 35: tiny.app.proto.GetTinyAppUsageMetricsRequest
+	(*GetTinyAppUsageMetricsResponse)(nil),    // 36: tiny.app.proto.GetTinyAppUsageMetricsResponse
+	(*GetTinyAppRequest)(nil),                 // 37: tiny.app.proto.GetTinyAppRequest
+	(*ListGitWebhookDeliveriesRequest)(nil),   // 38: tiny.app.proto.ListGitWebhookDeliveriesRequest
+	(*GitRefUpdate)(nil),                      // 39: tiny.app.proto.GitRefUpdate
+	(*GitWebhookDelivery)(nil),                // 40: tiny.app.proto.GitWebhookDelivery
+	(*ListGitWebhookDeliveriesResponse)(nil),  // 41: tiny.app.proto.ListGitWebhookDeliveriesResponse
+	(*TinyAppPod)(nil),                        // 42: tiny.app.proto.TinyAppPod
+	(*TinyAppEndpoint)(nil),                   // 43: tiny.app.proto.TinyAppEndpoint
+	(*GetTinyAppResponse)(nil),                // 44: tiny.app.proto.GetTinyAppResponse
+	(*ListTinyAppsRequest)(nil),               // 45: tiny.app.proto.ListTinyAppsRequest
+	(*ListTinyAppsWarning)(nil),               // 46: tiny.app.proto.ListTinyAppsWarning
+	(*ListTinyAppsResponse)(nil),              // 47: tiny.app.proto.ListTinyAppsResponse
+	(*UpdateTinyAppRequest)(nil),              // 48: tiny.app.proto.UpdateTinyAppRequest
+	(*UpdateTinyAppResponse)(nil),             // 49: tiny.app.proto.UpdateTinyAppResponse
+	(*DeleteTinyAppRequest)(nil),              // 50: tiny.app.proto.DeleteTinyAppRequest
+	(*GetTinyAppLogsRequest)(nil),             // 51: tiny.app.proto.GetTinyAppLogsRequest
+	(*GetTinyAppLogsResponse)(nil),            // 52: tiny.app.proto.GetTinyAppLogsResponse
+	(*StreamTinyAppLogsRequest)(nil),          // 53: tiny.app.proto.StreamTinyAppLogsRequest
+	(*TinyAppLogLine)(nil),                    // 54: tiny.app.proto.TinyAppLogLine
+	(*emptypb.Empty)(nil),                     // 55: google.protobuf.Empty
 }
 var file_api_proto_depIdxs = []int32{
-	0,  // 0: tiny.app.proto.TinyAppDetail.app_type:type_name -> tiny.app.proto.AppType
-	1,  // 1: tiny.app.proto.TinyAppDetail.source_type:type_name -> tiny.app.proto.SourceType
-	5,  // 2: tiny.app.proto.TinyAppDetail.git_config:type_name -> tiny.app.proto.GitConfig
-	4,  // 3: tiny.app.proto.TinyAppDetail.env:type_name -> tiny.app.proto.EnvVar
-	2,  // 4: tiny.app.proto.TinyAppDetail.volume_claims:type_name -> tiny.app.proto.VolumeClaim
-	7,  // 5: tiny.app.proto.TinyApp.app_release:type_name -> tiny.app.proto.TinyAppRelease
-	6,  // 6: tiny.app.proto.TinyApp.app_detail:type_name -> tiny.app.proto.TinyAppDetail
-	6,  // 7: tiny.app.proto.CreateTinyAppRequest.app_detail:type_name -> tiny.app.proto.TinyAppDetail
-	7,  // 8: tiny.app.proto.CreateTinyAppResponse.app_release:type_name -> tiny.app.proto.TinyAppRelease
-	6,  // 9: tiny.app.proto.ListTinyAppsRequest.app_detail:type_name -> tiny.app.proto.TinyAppDetail
-	8,  // 10: tiny.app.proto.ListTinyAppsResponse.apps:type_name -> tiny.app.proto.TinyApp
-	6,  // 11: tiny.app.proto.UpdateTinyAppRequest.app_detail:type_name -> tiny.app.proto.TinyAppDetail
-	7,  // 12: tiny.app.proto.UpdateTinyAppResponse.app_release:type_name -> tiny.app.proto.TinyAppRelease
-	9,  // 13: tiny.app.proto.TinyAppServer.CreateTinyApp:input_type -> tiny.app.proto.CreateTinyAppRequest
-	15, // 14: tiny.app.proto.TinyAppServer.ListTinyApps:input_type -> tiny.app.proto.ListTinyAppsRequest
-	17, // 15: tiny.app.proto.TinyAppServer.UpdateTinyApp:input_type -> tiny.app.proto.UpdateTinyAppRequest
-	19, // 16: tiny.app.proto.TinyAppServer.DeleteTinyApp:input_type -> tiny.app.proto.DeleteTinyAppRequest
-	20, // 17: tiny.app.proto.TinyAppServer.GetTinyAppLogs:input_type -> tiny.app.proto.GetTinyAppLogsRequest
-	11, // 18: tiny.app.proto.TinyAppServer.GetTinyAppAccessMetrics:input_type -> tiny.app.proto.GetTinyAppAccessMetricsRequest
-	13, // 19: tiny.app.proto.TinyAppServer.GetTinyAppUsageMetrics:input_type -> tiny.app.proto.GetTinyAppUsageMetricsRequest
-	10, // 20: tiny.app.proto.TinyAppServer.CreateTinyApp:output_type -> tiny.app.proto.CreateTinyAppResponse
-	16, // 21: tiny.app.proto.TinyAppServer.ListTinyApps:output_type -> tiny.app.proto.ListTinyAppsResponse
-	18, // 22: tiny.app.proto.TinyAppServer.UpdateTinyApp:output_type -> tiny.app.proto.UpdateTinyAppResponse
-	22, // 23: tiny.app.proto.TinyAppServer.DeleteTinyApp:output_type -> google.protobuf.Empty
-	21, // 24: tiny.app.proto.TinyAppServer.GetTinyAppLogs:output_type -> tiny.app.proto.GetTinyAppLogsResponse
-	12, // 25: tiny.app.proto.TinyAppServer.GetTinyAppAccessMetrics:output_type -> tiny.app.proto.GetTinyAppAccessMetricsResponse
-	14, // 26: tiny.app.proto.TinyAppServer.GetTinyAppUsageMetrics:output_type -> tiny.app.proto.GetTinyAppUsageMetricsResponse
-	20, // [20:27] is the sub-list for method output_type
-	13, // [13:20] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	0,  // 0: tiny.app.proto.GitConfig.ref_type:type_name -> tiny.app.proto.GitRefType
+	1,  // 1: tiny.app.proto.TinyAppDetail.app_type:type_name -> tiny.app.proto.AppType
+	2,  // 2: tiny.app.proto.TinyAppDetail.source_type:type_name -> tiny.app.proto.SourceType
+	7,  // 3: tiny.app.proto.TinyAppDetail.git_config:type_name -> tiny.app.proto.GitConfig
+	6,  // 4: tiny.app.proto.TinyAppDetail.env:type_name -> tiny.app.proto.EnvVar
+	4,  // 5: tiny.app.proto.TinyAppDetail.volume_claims:type_name -> tiny.app.proto.VolumeClaim
+	8,  // 6: tiny.app.proto.TinyAppDetail.resources:type_name -> tiny.app.proto.Resources
+	9,  // 7: tiny.app.proto.TinyAppDetail.autoscaling:type_name -> tiny.app.proto.Autoscaling
+	14, // 8: tiny.app.proto.TinyAppDetail.auth:type_name -> tiny.app.proto.AppAuth
+	10, // 9: tiny.app.proto.TinyAppDetail.custom:type_name -> tiny.app.proto.CustomApp
+	12, // 10: tiny.app.proto.TinyAppDetail.probes:type_name -> tiny.app.proto.AppProbes
+	13, // 11: tiny.app.proto.AppProbes.startup:type_name -> tiny.app.proto.ProbeSettings
+	13, // 12: tiny.app.proto.AppProbes.readiness:type_name -> tiny.app.proto.ProbeSettings
+	13, // 13: tiny.app.proto.AppProbes.liveness:type_name -> tiny.app.proto.ProbeSettings
+	16, // 14: tiny.app.proto.TinyAppStatus.conditions:type_name -> tiny.app.proto.TinyAppCondition
+	15, // 15: tiny.app.proto.TinyApp.app_release:type_name -> tiny.app.proto.TinyAppRelease
+	11, // 16: tiny.app.proto.TinyApp.app_detail:type_name -> tiny.app.proto.TinyAppDetail
+	17, // 17: tiny.app.proto.TinyApp.status:type_name -> tiny.app.proto.TinyAppStatus
+	18, // 18: tiny.app.proto.TinyApp.access:type_name -> tiny.app.proto.TinyAppAccess
+	11, // 19: tiny.app.proto.CreateTinyAppRequest.app_detail:type_name -> tiny.app.proto.TinyAppDetail
+	15, // 20: tiny.app.proto.CreateTinyAppResponse.app_release:type_name -> tiny.app.proto.TinyAppRelease
+	27, // 21: tiny.app.proto.GetTinyAppUserMetricsResponse.users:type_name -> tiny.app.proto.TinyAppUserAccess
+	32, // 22: tiny.app.proto.MetricSeries.points:type_name -> tiny.app.proto.MetricPoint
+	33, // 23: tiny.app.proto.GetTinyAppMetricsRangeResponse.series:type_name -> tiny.app.proto.MetricSeries
+	39, // 24: tiny.app.proto.GitWebhookDelivery.refs:type_name -> tiny.app.proto.GitRefUpdate
+	40, // 25: tiny.app.proto.ListGitWebhookDeliveriesResponse.deliveries:type_name -> tiny.app.proto.GitWebhookDelivery
+	19, // 26: tiny.app.proto.GetTinyAppResponse.app:type_name -> tiny.app.proto.TinyApp
+	42, // 27: tiny.app.proto.GetTinyAppResponse.pods:type_name -> tiny.app.proto.TinyAppPod
+	43, // 28: tiny.app.proto.GetTinyAppResponse.endpoint:type_name -> tiny.app.proto.TinyAppEndpoint
+	11, // 29: tiny.app.proto.ListTinyAppsRequest.app_detail:type_name -> tiny.app.proto.TinyAppDetail
+	1,  // 30: tiny.app.proto.ListTinyAppsRequest.app_type:type_name -> tiny.app.proto.AppType
+	2,  // 31: tiny.app.proto.ListTinyAppsRequest.source_type:type_name -> tiny.app.proto.SourceType
+	3,  // 32: tiny.app.proto.ListTinyAppsRequest.sort_order:type_name -> tiny.app.proto.ListSortOrder
+	19, // 33: tiny.app.proto.ListTinyAppsResponse.apps:type_name -> tiny.app.proto.TinyApp
+	46, // 34: tiny.app.proto.ListTinyAppsResponse.warnings:type_name -> tiny.app.proto.ListTinyAppsWarning
+	11, // 35: tiny.app.proto.UpdateTinyAppRequest.app_detail:type_name -> tiny.app.proto.TinyAppDetail
+	15, // 36: tiny.app.proto.UpdateTinyAppResponse.app_release:type_name -> tiny.app.proto.TinyAppRelease
+	22, // 37: tiny.app.proto.TinyAppServer.CreateTinyApp:input_type -> tiny.app.proto.CreateTinyAppRequest
+	37, // 38: tiny.app.proto.TinyAppServer.GetTinyApp:input_type -> tiny.app.proto.GetTinyAppRequest
+	20, // 39: tiny.app.proto.TinyAppServer.AddTinyAppCollaborators:input_type -> tiny.app.proto.AddTinyAppCollaboratorsRequest
+	21, // 40: tiny.app.proto.TinyAppServer.RemoveTinyAppCollaborators:input_type -> tiny.app.proto.RemoveTinyAppCollaboratorsRequest
+	45, // 41: tiny.app.proto.TinyAppServer.ListTinyApps:input_type -> tiny.app.proto.ListTinyAppsRequest
+	48, // 42: tiny.app.proto.TinyAppServer.UpdateTinyApp:input_type -> tiny.app.proto.UpdateTinyAppRequest
+	50, // 43: tiny.app.proto.TinyAppServer.DeleteTinyApp:input_type -> tiny.app.proto.DeleteTinyAppRequest
+	51, // 44: tiny.app.proto.TinyAppServer.GetTinyAppLogs:input_type -> tiny.app.proto.GetTinyAppLogsRequest
+	53, // 45: tiny.app.proto.TinyAppServer.StreamTinyAppLogs:input_type -> tiny.app.proto.StreamTinyAppLogsRequest
+	24, // 46: tiny.app.proto.TinyAppServer.GetTinyAppAccessMetrics:input_type -> tiny.app.proto.GetTinyAppAccessMetricsRequest
+	26, // 47: tiny.app.proto.TinyAppServer.GetTinyAppUserMetrics:input_type -> tiny.app.proto.GetTinyAppUserMetricsRequest
+	29, // 48: tiny.app.proto.TinyAppServer.GetTinyAppTrafficMetrics:input_type -> tiny.app.proto.GetTinyAppTrafficMetricsRequest
+	31, // 49: tiny.app.proto.TinyAppServer.GetTinyAppMetricsRange:input_type -> tiny.app.proto.GetTinyAppMetricsRangeRequest
+	35, // 50: tiny.app.proto.TinyAppServer.GetTinyAppUsageMetrics:input_type -> tiny.app.proto.GetTinyAppUsageMetricsRequest
+	38, // 51: tiny.app.proto.TinyAppServer.ListGitWebhookDeliveries:input_type -> tiny.app.proto.ListGitWebhookDeliveriesRequest
+	23, // 52: tiny.app.proto.TinyAppServer.CreateTinyApp:output_type -> tiny.app.proto.CreateTinyAppResponse
+	44, // 53: tiny.app.proto.TinyAppServer.GetTinyApp:output_type -> tiny.app.proto.GetTinyAppResponse
+	18, // 54: tiny.app.proto.TinyAppServer.AddTinyAppCollaborators:output_type -> tiny.app.proto.TinyAppAccess
+	18, // 55: tiny.app.proto.TinyAppServer.RemoveTinyAppCollaborators:output_type -> tiny.app.proto.TinyAppAccess
+	47, // 56: tiny.app.proto.TinyAppServer.ListTinyApps:output_type -> tiny.app.proto.ListTinyAppsResponse
+	49, // 57: tiny.app.proto.TinyAppServer.UpdateTinyApp:output_type -> tiny.app.proto.UpdateTinyAppResponse
+	55, // 58: tiny.app.proto.TinyAppServer.DeleteTinyApp:output_type -> google.protobuf.Empty
+	52, // 59: tiny.app.proto.TinyAppServer.GetTinyAppLogs:output_type -> tiny.app.proto.GetTinyAppLogsResponse
+	54, // 60: tiny.app.proto.TinyAppServer.StreamTinyAppLogs:output_type -> tiny.app.proto.TinyAppLogLine
+	25, // 61: tiny.app.proto.TinyAppServer.GetTinyAppAccessMetrics:output_type -> tiny.app.proto.GetTinyAppAccessMetricsResponse
+	28, // 62: tiny.app.proto.TinyAppServer.GetTinyAppUserMetrics:output_type -> tiny.app.proto.GetTinyAppUserMetricsResponse
+	30, // 63: tiny.app.proto.TinyAppServer.GetTinyAppTrafficMetrics:output_type -> tiny.app.proto.GetTinyAppTrafficMetricsResponse
+	34, // 64: tiny.app.proto.TinyAppServer.GetTinyAppMetricsRange:output_type -> tiny.app.proto.GetTinyAppMetricsRangeResponse
+	36, // 65: tiny.app.proto.TinyAppServer.GetTinyAppUsageMetrics:output_type -> tiny.app.proto.GetTinyAppUsageMetricsResponse
+	41, // 66: tiny.app.proto.TinyAppServer.ListGitWebhookDeliveries:output_type -> tiny.app.proto.ListGitWebhookDeliveriesResponse
+	52, // [52:67] is the sub-list for method output_type
+	37, // [37:52] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_init() }
@@ -1609,8 +4714,116 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Volume); i {
+		file_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Volume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnvVar); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Autoscaling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CustomApp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppProbes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProbeSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppAuth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1621,8 +4834,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EnvVar); i {
+		file_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppRelease); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1633,8 +4846,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitConfig); i {
+		file_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppCondition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1645,8 +4858,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TinyAppDetail); i {
+		file_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1657,8 +4870,8 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TinyAppRelease); i {
+		file_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppAccess); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1669,7 +4882,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TinyApp); i {
 			case 0:
 				return &v.state
@@ -1681,7 +4894,31 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddTinyAppCollaboratorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveTinyAppCollaboratorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateTinyAppRequest); i {
 			case 0:
 				return &v.state
@@ -1693,7 +4930,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateTinyAppResponse); i {
 			case 0:
 				return &v.state
@@ -1705,7 +4942,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTinyAppAccessMetricsRequest); i {
 			case 0:
 				return &v.state
@@ -1717,7 +4954,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTinyAppAccessMetricsResponse); i {
 			case 0:
 				return &v.state
@@ -1729,7 +4966,115 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTinyAppUserMetricsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppUserAccess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTinyAppUserMetricsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTinyAppTrafficMetricsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTinyAppTrafficMetricsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTinyAppMetricsRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetricPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetricSeries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTinyAppMetricsRangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTinyAppUsageMetricsRequest); i {
 			case 0:
 				return &v.state
@@ -1741,7 +5086,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTinyAppUsageMetricsResponse); i {
 			case 0:
 				return &v.state
@@ -1753,7 +5098,103 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTinyAppRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGitWebhookDeliveriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitRefUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitWebhookDelivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGitWebhookDeliveriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppPod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppEndpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTinyAppResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListTinyAppsRequest); i {
 			case 0:
 				return &v.state
@@ -1765,7 +5206,19 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTinyAppsWarning); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListTinyAppsResponse); i {
 			case 0:
 				return &v.state
@@ -1777,7 +5230,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateTinyAppRequest); i {
 			case 0:
 				return &v.state
@@ -1789,7 +5242,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateTinyAppResponse); i {
 			case 0:
 				return &v.state
@@ -1801,7 +5254,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteTinyAppRequest); i {
 			case 0:
 				return &v.state
@@ -1813,7 +5266,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTinyAppLogsRequest); i {
 			case 0:
 				return &v.state
@@ -1825,7 +5278,7 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
-		file_api_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetTinyAppLogsResponse); i {
 			case 0:
 				return &v.state
@@ -1837,14 +5290,42 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
+		file_api_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamTinyAppLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TinyAppLogLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_api_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_api_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_api_proto_msgTypes[9].OneofWrappers = []interface{}{}
+	file_api_proto_msgTypes[49].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   20,
+			NumEnums:      4,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
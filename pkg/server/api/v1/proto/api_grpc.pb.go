@@ -20,13 +20,21 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	TinyAppServer_CreateTinyApp_FullMethodName           = "/tiny.app.proto.TinyAppServer/CreateTinyApp"
-	TinyAppServer_ListTinyApps_FullMethodName            = "/tiny.app.proto.TinyAppServer/ListTinyApps"
-	TinyAppServer_UpdateTinyApp_FullMethodName           = "/tiny.app.proto.TinyAppServer/UpdateTinyApp"
-	TinyAppServer_DeleteTinyApp_FullMethodName           = "/tiny.app.proto.TinyAppServer/DeleteTinyApp"
-	TinyAppServer_GetTinyAppLogs_FullMethodName          = "/tiny.app.proto.TinyAppServer/GetTinyAppLogs"
-	TinyAppServer_GetTinyAppAccessMetrics_FullMethodName = "/tiny.app.proto.TinyAppServer/GetTinyAppAccessMetrics"
-	TinyAppServer_GetTinyAppUsageMetrics_FullMethodName  = "/tiny.app.proto.TinyAppServer/GetTinyAppUsageMetrics"
+	TinyAppServer_CreateTinyApp_FullMethodName              = "/tiny.app.proto.TinyAppServer/CreateTinyApp"
+	TinyAppServer_GetTinyApp_FullMethodName                 = "/tiny.app.proto.TinyAppServer/GetTinyApp"
+	TinyAppServer_AddTinyAppCollaborators_FullMethodName    = "/tiny.app.proto.TinyAppServer/AddTinyAppCollaborators"
+	TinyAppServer_RemoveTinyAppCollaborators_FullMethodName = "/tiny.app.proto.TinyAppServer/RemoveTinyAppCollaborators"
+	TinyAppServer_ListTinyApps_FullMethodName               = "/tiny.app.proto.TinyAppServer/ListTinyApps"
+	TinyAppServer_UpdateTinyApp_FullMethodName              = "/tiny.app.proto.TinyAppServer/UpdateTinyApp"
+	TinyAppServer_DeleteTinyApp_FullMethodName              = "/tiny.app.proto.TinyAppServer/DeleteTinyApp"
+	TinyAppServer_GetTinyAppLogs_FullMethodName             = "/tiny.app.proto.TinyAppServer/GetTinyAppLogs"
+	TinyAppServer_StreamTinyAppLogs_FullMethodName          = "/tiny.app.proto.TinyAppServer/StreamTinyAppLogs"
+	TinyAppServer_GetTinyAppAccessMetrics_FullMethodName    = "/tiny.app.proto.TinyAppServer/GetTinyAppAccessMetrics"
+	TinyAppServer_GetTinyAppUserMetrics_FullMethodName      = "/tiny.app.proto.TinyAppServer/GetTinyAppUserMetrics"
+	TinyAppServer_GetTinyAppTrafficMetrics_FullMethodName   = "/tiny.app.proto.TinyAppServer/GetTinyAppTrafficMetrics"
+	TinyAppServer_GetTinyAppMetricsRange_FullMethodName     = "/tiny.app.proto.TinyAppServer/GetTinyAppMetricsRange"
+	TinyAppServer_GetTinyAppUsageMetrics_FullMethodName     = "/tiny.app.proto.TinyAppServer/GetTinyAppUsageMetrics"
+	TinyAppServer_ListGitWebhookDeliveries_FullMethodName   = "/tiny.app.proto.TinyAppServer/ListGitWebhookDeliveries"
 )
 
 // TinyAppServerClient is the client API for TinyAppServer service.
@@ -35,6 +43,12 @@ const (
 type TinyAppServerClient interface {
 	// Creates a new app.
 	CreateTinyApp(ctx context.Context, in *CreateTinyAppRequest, opts ...grpc.CallOption) (*CreateTinyAppResponse, error)
+	// Gets an app, with its status and what is currently deployed
+	GetTinyApp(ctx context.Context, in *GetTinyAppRequest, opts ...grpc.CallOption) (*GetTinyAppResponse, error)
+	// Gives users or groups permission to manage an app
+	AddTinyAppCollaborators(ctx context.Context, in *AddTinyAppCollaboratorsRequest, opts ...grpc.CallOption) (*TinyAppAccess, error)
+	// Takes permission to manage an app away from users or groups. App creator can't be removed.
+	RemoveTinyAppCollaborators(ctx context.Context, in *RemoveTinyAppCollaboratorsRequest, opts ...grpc.CallOption) (*TinyAppAccess, error)
 	// Gets list of apps
 	ListTinyApps(ctx context.Context, in *ListTinyAppsRequest, opts ...grpc.CallOption) (*ListTinyAppsResponse, error)
 	// Updates an app
@@ -42,10 +56,20 @@ type TinyAppServerClient interface {
 	// Deletes an app
 	DeleteTinyApp(ctx context.Context, in *DeleteTinyAppRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetTinyAppLogs(ctx context.Context, in *GetTinyAppLogsRequest, opts ...grpc.CallOption) (*GetTinyAppLogsResponse, error)
+	// Streams logs of an app container from all app pods, line by line
+	StreamTinyAppLogs(ctx context.Context, in *StreamTinyAppLogsRequest, opts ...grpc.CallOption) (TinyAppServer_StreamTinyAppLogsClient, error)
 	// Gets access metrics for a tiny app
 	GetTinyAppAccessMetrics(ctx context.Context, in *GetTinyAppAccessMetricsRequest, opts ...grpc.CallOption) (*GetTinyAppAccessMetricsResponse, error)
+	// Gets access & session counts of each app user, and number of distinct users
+	GetTinyAppUserMetrics(ctx context.Context, in *GetTinyAppUserMetricsRequest, opts ...grpc.CallOption) (*GetTinyAppUserMetricsResponse, error)
+	// Gets latency, error rate and request rate of a tiny app, as seen by its gateway
+	GetTinyAppTrafficMetrics(ctx context.Context, in *GetTinyAppTrafficMetricsRequest, opts ...grpc.CallOption) (*GetTinyAppTrafficMetricsResponse, error)
+	// Gets CPU, memory, request rate and access count of a tiny app as time series, for charting
+	GetTinyAppMetricsRange(ctx context.Context, in *GetTinyAppMetricsRangeRequest, opts ...grpc.CallOption) (*GetTinyAppMetricsRangeResponse, error)
 	// Gets CPU and memory metrics for a tiny app
 	GetTinyAppUsageMetrics(ctx context.Context, in *GetTinyAppUsageMetricsRequest, opts ...grpc.CallOption) (*GetTinyAppUsageMetricsResponse, error)
+	// Gets git push webhooks received since server started, newest first, with the apps they redeployed
+	ListGitWebhookDeliveries(ctx context.Context, in *ListGitWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListGitWebhookDeliveriesResponse, error)
 }
 
 type tinyAppServerClient struct {
@@ -65,6 +89,33 @@ func (c *tinyAppServerClient) CreateTinyApp(ctx context.Context, in *CreateTinyA
 	return out, nil
 }
 
+func (c *tinyAppServerClient) GetTinyApp(ctx context.Context, in *GetTinyAppRequest, opts ...grpc.CallOption) (*GetTinyAppResponse, error) {
+	out := new(GetTinyAppResponse)
+	err := c.cc.Invoke(ctx, TinyAppServer_GetTinyApp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyAppServerClient) AddTinyAppCollaborators(ctx context.Context, in *AddTinyAppCollaboratorsRequest, opts ...grpc.CallOption) (*TinyAppAccess, error) {
+	out := new(TinyAppAccess)
+	err := c.cc.Invoke(ctx, TinyAppServer_AddTinyAppCollaborators_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyAppServerClient) RemoveTinyAppCollaborators(ctx context.Context, in *RemoveTinyAppCollaboratorsRequest, opts ...grpc.CallOption) (*TinyAppAccess, error) {
+	out := new(TinyAppAccess)
+	err := c.cc.Invoke(ctx, TinyAppServer_RemoveTinyAppCollaborators_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tinyAppServerClient) ListTinyApps(ctx context.Context, in *ListTinyAppsRequest, opts ...grpc.CallOption) (*ListTinyAppsResponse, error) {
 	out := new(ListTinyAppsResponse)
 	err := c.cc.Invoke(ctx, TinyAppServer_ListTinyApps_FullMethodName, in, out, opts...)
@@ -101,6 +152,38 @@ func (c *tinyAppServerClient) GetTinyAppLogs(ctx context.Context, in *GetTinyApp
 	return out, nil
 }
 
+func (c *tinyAppServerClient) StreamTinyAppLogs(ctx context.Context, in *StreamTinyAppLogsRequest, opts ...grpc.CallOption) (TinyAppServer_StreamTinyAppLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TinyAppServer_ServiceDesc.Streams[0], TinyAppServer_StreamTinyAppLogs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tinyAppServerStreamTinyAppLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TinyAppServer_StreamTinyAppLogsClient interface {
+	Recv() (*TinyAppLogLine, error)
+	grpc.ClientStream
+}
+
+type tinyAppServerStreamTinyAppLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *tinyAppServerStreamTinyAppLogsClient) Recv() (*TinyAppLogLine, error) {
+	m := new(TinyAppLogLine)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *tinyAppServerClient) GetTinyAppAccessMetrics(ctx context.Context, in *GetTinyAppAccessMetricsRequest, opts ...grpc.CallOption) (*GetTinyAppAccessMetricsResponse, error) {
 	out := new(GetTinyAppAccessMetricsResponse)
 	err := c.cc.Invoke(ctx, TinyAppServer_GetTinyAppAccessMetrics_FullMethodName, in, out, opts...)
@@ -110,6 +193,33 @@ func (c *tinyAppServerClient) GetTinyAppAccessMetrics(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *tinyAppServerClient) GetTinyAppUserMetrics(ctx context.Context, in *GetTinyAppUserMetricsRequest, opts ...grpc.CallOption) (*GetTinyAppUserMetricsResponse, error) {
+	out := new(GetTinyAppUserMetricsResponse)
+	err := c.cc.Invoke(ctx, TinyAppServer_GetTinyAppUserMetrics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyAppServerClient) GetTinyAppTrafficMetrics(ctx context.Context, in *GetTinyAppTrafficMetricsRequest, opts ...grpc.CallOption) (*GetTinyAppTrafficMetricsResponse, error) {
+	out := new(GetTinyAppTrafficMetricsResponse)
+	err := c.cc.Invoke(ctx, TinyAppServer_GetTinyAppTrafficMetrics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyAppServerClient) GetTinyAppMetricsRange(ctx context.Context, in *GetTinyAppMetricsRangeRequest, opts ...grpc.CallOption) (*GetTinyAppMetricsRangeResponse, error) {
+	out := new(GetTinyAppMetricsRangeResponse)
+	err := c.cc.Invoke(ctx, TinyAppServer_GetTinyAppMetricsRange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tinyAppServerClient) GetTinyAppUsageMetrics(ctx context.Context, in *GetTinyAppUsageMetricsRequest, opts ...grpc.CallOption) (*GetTinyAppUsageMetricsResponse, error) {
 	out := new(GetTinyAppUsageMetricsResponse)
 	err := c.cc.Invoke(ctx, TinyAppServer_GetTinyAppUsageMetrics_FullMethodName, in, out, opts...)
@@ -119,12 +229,27 @@ func (c *tinyAppServerClient) GetTinyAppUsageMetrics(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *tinyAppServerClient) ListGitWebhookDeliveries(ctx context.Context, in *ListGitWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListGitWebhookDeliveriesResponse, error) {
+	out := new(ListGitWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, TinyAppServer_ListGitWebhookDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TinyAppServerServer is the server API for TinyAppServer service.
 // All implementations should embed UnimplementedTinyAppServerServer
 // for forward compatibility
 type TinyAppServerServer interface {
 	// Creates a new app.
 	CreateTinyApp(context.Context, *CreateTinyAppRequest) (*CreateTinyAppResponse, error)
+	// Gets an app, with its status and what is currently deployed
+	GetTinyApp(context.Context, *GetTinyAppRequest) (*GetTinyAppResponse, error)
+	// Gives users or groups permission to manage an app
+	AddTinyAppCollaborators(context.Context, *AddTinyAppCollaboratorsRequest) (*TinyAppAccess, error)
+	// Takes permission to manage an app away from users or groups. App creator can't be removed.
+	RemoveTinyAppCollaborators(context.Context, *RemoveTinyAppCollaboratorsRequest) (*TinyAppAccess, error)
 	// Gets list of apps
 	ListTinyApps(context.Context, *ListTinyAppsRequest) (*ListTinyAppsResponse, error)
 	// Updates an app
@@ -132,10 +257,20 @@ type TinyAppServerServer interface {
 	// Deletes an app
 	DeleteTinyApp(context.Context, *DeleteTinyAppRequest) (*emptypb.Empty, error)
 	GetTinyAppLogs(context.Context, *GetTinyAppLogsRequest) (*GetTinyAppLogsResponse, error)
+	// Streams logs of an app container from all app pods, line by line
+	StreamTinyAppLogs(*StreamTinyAppLogsRequest, TinyAppServer_StreamTinyAppLogsServer) error
 	// Gets access metrics for a tiny app
 	GetTinyAppAccessMetrics(context.Context, *GetTinyAppAccessMetricsRequest) (*GetTinyAppAccessMetricsResponse, error)
+	// Gets access & session counts of each app user, and number of distinct users
+	GetTinyAppUserMetrics(context.Context, *GetTinyAppUserMetricsRequest) (*GetTinyAppUserMetricsResponse, error)
+	// Gets latency, error rate and request rate of a tiny app, as seen by its gateway
+	GetTinyAppTrafficMetrics(context.Context, *GetTinyAppTrafficMetricsRequest) (*GetTinyAppTrafficMetricsResponse, error)
+	// Gets CPU, memory, request rate and access count of a tiny app as time series, for charting
+	GetTinyAppMetricsRange(context.Context, *GetTinyAppMetricsRangeRequest) (*GetTinyAppMetricsRangeResponse, error)
 	// Gets CPU and memory metrics for a tiny app
 	GetTinyAppUsageMetrics(context.Context, *GetTinyAppUsageMetricsRequest) (*GetTinyAppUsageMetricsResponse, error)
+	// Gets git push webhooks received since server started, newest first, with the apps they redeployed
+	ListGitWebhookDeliveries(context.Context, *ListGitWebhookDeliveriesRequest) (*ListGitWebhookDeliveriesResponse, error)
 }
 
 // UnimplementedTinyAppServerServer should be embedded to have forward compatible implementations.
@@ -145,6 +280,15 @@ type UnimplementedTinyAppServerServer struct {
 func (UnimplementedTinyAppServerServer) CreateTinyApp(context.Context, *CreateTinyAppRequest) (*CreateTinyAppResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateTinyApp not implemented")
 }
+func (UnimplementedTinyAppServerServer) GetTinyApp(context.Context, *GetTinyAppRequest) (*GetTinyAppResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTinyApp not implemented")
+}
+func (UnimplementedTinyAppServerServer) AddTinyAppCollaborators(context.Context, *AddTinyAppCollaboratorsRequest) (*TinyAppAccess, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTinyAppCollaborators not implemented")
+}
+func (UnimplementedTinyAppServerServer) RemoveTinyAppCollaborators(context.Context, *RemoveTinyAppCollaboratorsRequest) (*TinyAppAccess, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTinyAppCollaborators not implemented")
+}
 func (UnimplementedTinyAppServerServer) ListTinyApps(context.Context, *ListTinyAppsRequest) (*ListTinyAppsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTinyApps not implemented")
 }
@@ -157,12 +301,27 @@ func (UnimplementedTinyAppServerServer) DeleteTinyApp(context.Context, *DeleteTi
 func (UnimplementedTinyAppServerServer) GetTinyAppLogs(context.Context, *GetTinyAppLogsRequest) (*GetTinyAppLogsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTinyAppLogs not implemented")
 }
+func (UnimplementedTinyAppServerServer) StreamTinyAppLogs(*StreamTinyAppLogsRequest, TinyAppServer_StreamTinyAppLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTinyAppLogs not implemented")
+}
 func (UnimplementedTinyAppServerServer) GetTinyAppAccessMetrics(context.Context, *GetTinyAppAccessMetricsRequest) (*GetTinyAppAccessMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTinyAppAccessMetrics not implemented")
 }
+func (UnimplementedTinyAppServerServer) GetTinyAppUserMetrics(context.Context, *GetTinyAppUserMetricsRequest) (*GetTinyAppUserMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTinyAppUserMetrics not implemented")
+}
+func (UnimplementedTinyAppServerServer) GetTinyAppTrafficMetrics(context.Context, *GetTinyAppTrafficMetricsRequest) (*GetTinyAppTrafficMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTinyAppTrafficMetrics not implemented")
+}
+func (UnimplementedTinyAppServerServer) GetTinyAppMetricsRange(context.Context, *GetTinyAppMetricsRangeRequest) (*GetTinyAppMetricsRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTinyAppMetricsRange not implemented")
+}
 func (UnimplementedTinyAppServerServer) GetTinyAppUsageMetrics(context.Context, *GetTinyAppUsageMetricsRequest) (*GetTinyAppUsageMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTinyAppUsageMetrics not implemented")
 }
+func (UnimplementedTinyAppServerServer) ListGitWebhookDeliveries(context.Context, *ListGitWebhookDeliveriesRequest) (*ListGitWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGitWebhookDeliveries not implemented")
+}
 
 // UnsafeTinyAppServerServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to TinyAppServerServer will
@@ -193,6 +352,60 @@ func _TinyAppServer_CreateTinyApp_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyAppServer_GetTinyApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTinyAppRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyAppServerServer).GetTinyApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyAppServer_GetTinyApp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyAppServerServer).GetTinyApp(ctx, req.(*GetTinyAppRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyAppServer_AddTinyAppCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTinyAppCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyAppServerServer).AddTinyAppCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyAppServer_AddTinyAppCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyAppServerServer).AddTinyAppCollaborators(ctx, req.(*AddTinyAppCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyAppServer_RemoveTinyAppCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTinyAppCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyAppServerServer).RemoveTinyAppCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyAppServer_RemoveTinyAppCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyAppServerServer).RemoveTinyAppCollaborators(ctx, req.(*RemoveTinyAppCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TinyAppServer_ListTinyApps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListTinyAppsRequest)
 	if err := dec(in); err != nil {
@@ -265,6 +478,27 @@ func _TinyAppServer_GetTinyAppLogs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyAppServer_StreamTinyAppLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTinyAppLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TinyAppServerServer).StreamTinyAppLogs(m, &tinyAppServerStreamTinyAppLogsServer{stream})
+}
+
+type TinyAppServer_StreamTinyAppLogsServer interface {
+	Send(*TinyAppLogLine) error
+	grpc.ServerStream
+}
+
+type tinyAppServerStreamTinyAppLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *tinyAppServerStreamTinyAppLogsServer) Send(m *TinyAppLogLine) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _TinyAppServer_GetTinyAppAccessMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTinyAppAccessMetricsRequest)
 	if err := dec(in); err != nil {
@@ -283,6 +517,60 @@ func _TinyAppServer_GetTinyAppAccessMetrics_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyAppServer_GetTinyAppUserMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTinyAppUserMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyAppServerServer).GetTinyAppUserMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyAppServer_GetTinyAppUserMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyAppServerServer).GetTinyAppUserMetrics(ctx, req.(*GetTinyAppUserMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyAppServer_GetTinyAppTrafficMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTinyAppTrafficMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyAppServerServer).GetTinyAppTrafficMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyAppServer_GetTinyAppTrafficMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyAppServerServer).GetTinyAppTrafficMetrics(ctx, req.(*GetTinyAppTrafficMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyAppServer_GetTinyAppMetricsRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTinyAppMetricsRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyAppServerServer).GetTinyAppMetricsRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyAppServer_GetTinyAppMetricsRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyAppServerServer).GetTinyAppMetricsRange(ctx, req.(*GetTinyAppMetricsRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TinyAppServer_GetTinyAppUsageMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTinyAppUsageMetricsRequest)
 	if err := dec(in); err != nil {
@@ -301,6 +589,24 @@ func _TinyAppServer_GetTinyAppUsageMetrics_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyAppServer_ListGitWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGitWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyAppServerServer).ListGitWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyAppServer_ListGitWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyAppServerServer).ListGitWebhookDeliveries(ctx, req.(*ListGitWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TinyAppServer_ServiceDesc is the grpc.ServiceDesc for TinyAppServer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -312,6 +618,18 @@ var TinyAppServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateTinyApp",
 			Handler:    _TinyAppServer_CreateTinyApp_Handler,
 		},
+		{
+			MethodName: "GetTinyApp",
+			Handler:    _TinyAppServer_GetTinyApp_Handler,
+		},
+		{
+			MethodName: "AddTinyAppCollaborators",
+			Handler:    _TinyAppServer_AddTinyAppCollaborators_Handler,
+		},
+		{
+			MethodName: "RemoveTinyAppCollaborators",
+			Handler:    _TinyAppServer_RemoveTinyAppCollaborators_Handler,
+		},
 		{
 			MethodName: "ListTinyApps",
 			Handler:    _TinyAppServer_ListTinyApps_Handler,
@@ -332,11 +650,33 @@ var TinyAppServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTinyAppAccessMetrics",
 			Handler:    _TinyAppServer_GetTinyAppAccessMetrics_Handler,
 		},
+		{
+			MethodName: "GetTinyAppUserMetrics",
+			Handler:    _TinyAppServer_GetTinyAppUserMetrics_Handler,
+		},
+		{
+			MethodName: "GetTinyAppTrafficMetrics",
+			Handler:    _TinyAppServer_GetTinyAppTrafficMetrics_Handler,
+		},
+		{
+			MethodName: "GetTinyAppMetricsRange",
+			Handler:    _TinyAppServer_GetTinyAppMetricsRange_Handler,
+		},
 		{
 			MethodName: "GetTinyAppUsageMetrics",
 			Handler:    _TinyAppServer_GetTinyAppUsageMetrics_Handler,
 		},
+		{
+			MethodName: "ListGitWebhookDeliveries",
+			Handler:    _TinyAppServer_ListGitWebhookDeliveries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTinyAppLogs",
+			Handler:       _TinyAppServer_StreamTinyAppLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
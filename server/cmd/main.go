@@ -24,11 +24,14 @@ import (
 
 	proto2 "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
 	"github.com/tinymultiverse/tinyapp/server/internal"
+	"github.com/tinymultiverse/tinyapp/server/util"
 	"github.com/tinymultiverse/tinyapp/server/v1"
+	"github.com/tinymultiverse/tinyapp/util/auth"
 	"github.com/tinymultiverse/tinyapp/util/logging"
 
 	"github.com/caarlos0/env/v10"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -55,7 +58,20 @@ func main() {
 		zap.S().Fatalf("failed to create server: %v", err)
 	}
 
-	s := grpc.NewServer()
+	var serverOpts []grpc.ServerOption
+	if envVars.AuthEnabled {
+		authenticator, err := createAuthenticator(envVars)
+		if err != nil {
+			zap.S().Fatalf("failed to create authenticator: %v", err)
+		}
+		serverOpts = append(serverOpts,
+			grpc.UnaryInterceptor(auth.UnaryServerInterceptor(authenticator)),
+			grpc.StreamInterceptor(auth.StreamServerInterceptor(authenticator)))
+	} else {
+		zap.S().Warn("Authentication is disabled, anyone who can reach the server can manage apps")
+	}
+
+	s := grpc.NewServer(serverOpts...)
 	proto2.RegisterTinyAppServerServer(s, server)
 
 	// Start up gRPC and REST servers
@@ -66,10 +82,44 @@ func main() {
 		}
 	}()
 
-	createAndRunHttpServer(envVars)
+	createAndRunHttpServer(envVars, server)
 }
 
-func createAndRunHttpServer(envVars internal.EnvVars) {
+// createAuthenticator returns authenticator accepting static tokens and/or OIDC tokens, depending on configuration.
+func createAuthenticator(envVars internal.EnvVars) (auth.Authenticator, error) {
+	var authenticators auth.Authenticators
+
+	if envVars.StaticTokensFile != "" {
+		staticAuthenticator, err := auth.NewStaticTokenAuthenticatorFromFile(envVars.StaticTokensFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, staticAuthenticator)
+	}
+
+	if envVars.OIDCIssuerURL != "" {
+		oidcAuthenticator, err := auth.NewOIDCAuthenticator(context.Background(), auth.OIDCConfig{
+			IssuerURL:     envVars.OIDCIssuerURL,
+			Audience:      envVars.OIDCAudience,
+			JWKSURL:       envVars.OIDCJWKSURL,
+			JWKSFile:      envVars.OIDCJWKSFile,
+			UsernameClaim: envVars.OIDCUsernameClaim,
+			GroupsClaim:   envVars.OIDCGroupsClaim,
+		})
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, oidcAuthenticator)
+	}
+
+	if len(authenticators) == 0 {
+		return nil, errors.New("OIDC_ISSUER_URL or STATIC_TOKENS_FILE must be set when auth is enabled")
+	}
+
+	return authenticators, nil
+}
+
+func createAndRunHttpServer(envVars internal.EnvVars, server *v1.Server) {
 	mux := runtime.NewServeMux()
 	ctx := context.Background()
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
@@ -78,6 +128,14 @@ func createAndRunHttpServer(envVars internal.EnvVars) {
 		zap.S().Fatal(err)
 	}
 
+	// Git hosts authenticate with webhook signatures instead of tokens, so webhooks don't go through gRPC
+	if envVars.GitWebhookSecret != "" {
+		if err := mux.HandlePath(http.MethodPost, util.GitWebhookPath, server.HandleGitWebhook); err != nil {
+			zap.S().Fatal(err)
+		}
+		zap.S().Infof("serving git webhooks on %s", util.GitWebhookPath)
+	}
+
 	zap.S().Infof("starting http server on port %d", envVars.HTTPPort)
 	if err := http.ListenAndServe(fmt.Sprintf(":%d", envVars.HTTPPort), mux); err != nil {
 		zap.S().Fatal(err)
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitwebhook
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Delivery is a webhook received from a git host, and what it led to.
+type Delivery struct {
+	ID           string
+	Provider     string
+	RepoURL      string
+	Refs         []RefUpdate
+	ReceivedTime time.Time
+	// TriggeredApps are names of apps that were redeployed for the push
+	TriggeredApps []string
+	// Error is set if apps could not be redeployed
+	Error string
+}
+
+// DeliveryLog keeps the most recent deliveries in memory. Webhooks with invalid signature are only counted, so that
+// they can't push real deliveries out of the log. It is safe for concurrent use.
+type DeliveryLog struct {
+	mu         sync.Mutex
+	deliveries []Delivery
+	next       int
+	size       int
+	rejected   atomic.Int64
+}
+
+func NewDeliveryLog(size int) *DeliveryLog {
+	return &DeliveryLog{deliveries: make([]Delivery, 0, size), size: size}
+}
+
+// Add records delivery, dropping the oldest one if log is full.
+func (l *DeliveryLog) Add(delivery Delivery) {
+	if l.size <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.deliveries) < l.size {
+		l.deliveries = append(l.deliveries, delivery)
+		return
+	}
+	l.deliveries[l.next] = delivery
+	l.next = (l.next + 1) % l.size
+}
+
+// AddRejected counts a webhook with invalid signature, and returns how many were rejected so far.
+func (l *DeliveryLog) AddRejected() int64 {
+	return l.rejected.Add(1)
+}
+
+// Rejected returns how many webhooks with invalid signature were rejected.
+func (l *DeliveryLog) Rejected() int64 {
+	return l.rejected.Load()
+}
+
+// List returns deliveries that match filter, newest first. All deliveries are returned if filter is nil.
+func (l *DeliveryLog) List(filter func(delivery *Delivery) bool) []Delivery {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var deliveries []Delivery
+	for i := len(l.deliveries) - 1; i >= 0; i-- {
+		delivery := &l.deliveries[(l.next+i)%len(l.deliveries)]
+		if filter == nil || filter(delivery) {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+
+	return deliveries
+}
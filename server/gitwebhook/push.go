@@ -0,0 +1,300 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitwebhook parses push webhooks sent by git hosts, and keeps a log of recent deliveries.
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Git hosts that send push webhooks
+const (
+	ProviderGitHub    = "github"
+	ProviderGitLab    = "gitlab"
+	ProviderBitbucket = "bitbucket"
+	ProviderGitea     = "gitea"
+)
+
+var (
+	// ErrIgnoredEvent is returned for webhook events other than pushes, such as pings.
+	ErrIgnoredEvent = errors.New("not a push event")
+	// ErrInvalidSignature is returned when webhook is not signed with the shared secret.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Push is a push to a git repository.
+type Push struct {
+	Provider   string
+	DeliveryID string
+	// RepoURL is the web or clone url of repository, as sent by git host
+	RepoURL string
+	Refs    []RefUpdate
+}
+
+// RefUpdate is a branch or tag that was pushed.
+type RefUpdate struct {
+	// Ref is the full ref name (ex. refs/heads/main)
+	Ref    string
+	Commit string
+}
+
+// ParsePush verifies that webhook is signed with secret, and returns the push it describes.
+// Provider is told from webhook headers. Deleted branches & tags are left out of pushed refs.
+func ParsePush(header http.Header, body []byte, secret string) (*Push, error) {
+	switch {
+	// Gitea also sends GitHub headers, so it has to be checked first
+	case header.Get("X-Gitea-Event") != "":
+		return parseGiteaPush(header, body, secret)
+	case header.Get("X-GitHub-Event") != "":
+		return parseGitHubPush(header, body, secret)
+	case header.Get("X-Gitlab-Event") != "":
+		return parseGitLabPush(header, body, secret)
+	case header.Get("X-Event-Key") != "":
+		return parseBitbucketPush(header, body, secret)
+	default:
+		return nil, errors.New("unknown git webhook provider")
+	}
+}
+
+// githubPushPayload is the part of GitHub & Gitea push payloads that is used.
+type githubPushPayload struct {
+	Ref        string `json:"ref"`
+	After      string `json:"after"`
+	Deleted    bool   `json:"deleted"`
+	Repository struct {
+		HTMLURL  string `json:"html_url"`
+		CloneURL string `json:"clone_url"`
+	} `json:"repository"`
+}
+
+func parseGitHubPush(header http.Header, body []byte, secret string) (*Push, error) {
+	if !verifyHMAC(body, secret, strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")) {
+		return nil, ErrInvalidSignature
+	}
+	if header.Get("X-GitHub-Event") != "push" {
+		return nil, ErrIgnoredEvent
+	}
+
+	return parseGitHubPushPayload(ProviderGitHub, header.Get("X-GitHub-Delivery"), body)
+}
+
+func parseGiteaPush(header http.Header, body []byte, secret string) (*Push, error) {
+	if !verifyHMAC(body, secret, header.Get("X-Gitea-Signature")) {
+		return nil, ErrInvalidSignature
+	}
+	if header.Get("X-Gitea-Event") != "push" {
+		return nil, ErrIgnoredEvent
+	}
+
+	return parseGitHubPushPayload(ProviderGitea, header.Get("X-Gitea-Delivery"), body)
+}
+
+func parseGitHubPushPayload(provider, deliveryID string, body []byte) (*Push, error) {
+	var payload githubPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.WithMessage(err, "invalid push payload")
+	}
+
+	push := &Push{Provider: provider, DeliveryID: deliveryID, RepoURL: payload.Repository.CloneURL}
+	if push.RepoURL == "" {
+		push.RepoURL = payload.Repository.HTMLURL
+	}
+	if !payload.Deleted {
+		push.addRef(payload.Ref, payload.After)
+	}
+
+	return push, nil
+}
+
+// gitlabPushPayload is the part of GitLab push & tag push payloads that is used.
+type gitlabPushPayload struct {
+	Ref     string `json:"ref"`
+	After   string `json:"after"`
+	Project struct {
+		WebURL     string `json:"web_url"`
+		GitHTTPURL string `json:"git_http_url"`
+	} `json:"project"`
+}
+
+// parseGitLabPush parses GitLab push webhooks. GitLab sends secret token as is instead of signing payload.
+func parseGitLabPush(header http.Header, body []byte, secret string) (*Push, error) {
+	if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(secret)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+	if event := header.Get("X-Gitlab-Event"); event != "Push Hook" && event != "Tag Push Hook" {
+		return nil, ErrIgnoredEvent
+	}
+
+	var payload gitlabPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.WithMessage(err, "invalid push payload")
+	}
+
+	push := &Push{Provider: ProviderGitLab, DeliveryID: header.Get("X-Gitlab-Event-UUID"), RepoURL: payload.Project.GitHTTPURL}
+	if push.RepoURL == "" {
+		push.RepoURL = payload.Project.WebURL
+	}
+	push.addRef(payload.Ref, payload.After)
+
+	return push, nil
+}
+
+// bitbucketPushPayload holds the parts of Bitbucket Cloud (repo:push) & Bitbucket Data Center (repo:refs_changed)
+// push payloads that are used.
+type bitbucketPushPayload struct {
+	// Bitbucket Cloud
+	Push struct {
+		Changes []struct {
+			New *struct {
+				Type   string `json:"type"`
+				Name   string `json:"name"`
+				Target struct {
+					Hash string `json:"hash"`
+				} `json:"target"`
+			} `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+	// Bitbucket Data Center
+	Changes []struct {
+		Ref struct {
+			ID string `json:"id"`
+		} `json:"ref"`
+		ToHash string `json:"toHash"`
+		Type   string `json:"type"`
+	} `json:"changes"`
+	Repository struct {
+		Links struct {
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+			Clone []struct {
+				Href string `json:"href"`
+				Name string `json:"name"`
+			} `json:"clone"`
+		} `json:"links"`
+	} `json:"repository"`
+}
+
+func parseBitbucketPush(header http.Header, body []byte, secret string) (*Push, error) {
+	if !verifyHMAC(body, secret, strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256=")) {
+		return nil, ErrInvalidSignature
+	}
+
+	event := header.Get("X-Event-Key")
+	if event != "repo:push" && event != "repo:refs_changed" {
+		return nil, ErrIgnoredEvent
+	}
+
+	var payload bitbucketPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.WithMessage(err, "invalid push payload")
+	}
+
+	deliveryID := header.Get("X-Request-UUID")
+	if deliveryID == "" {
+		deliveryID = header.Get("X-Request-Id")
+	}
+	push := &Push{Provider: ProviderBitbucket, DeliveryID: deliveryID, RepoURL: payload.Repository.Links.HTML.Href}
+	for _, link := range payload.Repository.Links.Clone {
+		if link.Name == "http" || link.Name == "https" {
+			push.RepoURL = link.Href
+		}
+	}
+
+	for _, change := range payload.Push.Changes {
+		// New is null for deleted branches & tags
+		if change.New == nil {
+			continue
+		}
+		switch change.New.Type {
+		case "branch":
+			push.addRef("refs/heads/"+change.New.Name, change.New.Target.Hash)
+		case "tag", "annotated_tag":
+			push.addRef("refs/tags/"+change.New.Name, change.New.Target.Hash)
+		}
+	}
+	for _, change := range payload.Changes {
+		if change.Type != "DELETE" {
+			push.addRef(change.Ref.ID, change.ToHash)
+		}
+	}
+
+	return push, nil
+}
+
+// addRef adds pushed ref, unless it was deleted.
+func (p *Push) addRef(ref, commit string) {
+	if ref == "" || commit == "" || strings.Trim(commit, "0") == "" {
+		return
+	}
+	p.Refs = append(p.Refs, RefUpdate{Ref: ref, Commit: commit})
+}
+
+// verifyHMAC returns true if hex signature is the HMAC-SHA256 of body keyed with secret.
+func verifyHMAC(body []byte, secret, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// NormalizeRepoURL returns host & path of git repository url in lower case, so that web, https & ssh urls of the
+// same repository compare equal. Returns empty string if url can't be parsed.
+func NormalizeRepoURL(repoURL string) string {
+	repoURL = strings.TrimSpace(repoURL)
+
+	var host, path string
+	if strings.Contains(repoURL, "://") {
+		parsed, err := url.Parse(repoURL)
+		if err != nil {
+			return ""
+		}
+		host, path = parsed.Hostname(), parsed.Path
+	} else {
+		// scp-like ssh url (ex. git@github.com:org/repo.git)
+		hostAndPath := repoURL
+		if _, afterUser, ok := strings.Cut(repoURL, "@"); ok {
+			hostAndPath = afterUser
+		}
+		var ok bool
+		if host, path, ok = strings.Cut(hostAndPath, ":"); !ok {
+			return ""
+		}
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	// Bitbucket Data Center clone urls have an scm prefix that web urls don't
+	path = strings.TrimPrefix(path, "scm/")
+	if host == "" || path == "" {
+		return ""
+	}
+
+	return strings.ToLower(host + "/" + path)
+}
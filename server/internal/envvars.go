@@ -26,9 +26,39 @@ type EnvVars struct {
 	AppIngressDomain      string `env:"APP_INGRESS_DOMAIN,notEmpty"`
 	AppIngressSubPath     string `env:"APP_INGRESS_SUB_PATH"`
 	AppIngressTlsEnabled  bool   `env:"APP_INGRESS_TLS_ENABLED" envDefault:"true"`
-	PrometheusUserName    string `env:"PROMETHEUS_USER_NAME"` // Ignored if PrometheusSecretPath is set
-	PrometheusPassword    string `env:"PROMETHEUS_PASSWORD"`  // Ignored if PrometheusSecretPath is set
-	PrometheusSecretPath  string `env:"PROMETHEUS_SECRET_PATH"`
-	PrometheusUrl         string `env:"PROMETHEUS_URL"`           // Required if utilizing metrics endpoints
 	DefaultGitTokenSecret string `env:"DEFAULT_GIT_TOKEN_SECRET"` // Default k8s secret name for git token
+	// Controller's ConfigMap of app runtimes, whose app types are accepted along with built-in ones
+	AppRuntimesConfigMap string `env:"APP_RUNTIMES_CONFIGMAP"`
+	// Upper bounds for app container requests & limits, checked before apps are deployed. Should match
+	// tinyapp-controller's values.
+	MaxAppCPU    string `env:"MAX_APP_CPU"`
+	MaxAppMemory string `env:"MAX_APP_MEMORY"`
+	// One of prometheus, thanos, victoriametrics & metrics-server
+	MetricsProvider string `env:"METRICS_PROVIDER" envDefault:"prometheus"`
+	// Used by prometheus, thanos & victoriametrics metrics providers
+	PrometheusUrl                string            `env:"PROMETHEUS_URL"`       // Required if utilizing metrics endpoints
+	PrometheusUserName           string            `env:"PROMETHEUS_USER_NAME"` // Ignored if PrometheusSecretPath is set
+	PrometheusPassword           string            `env:"PROMETHEUS_PASSWORD"`  // Ignored if PrometheusSecretPath is set
+	PrometheusSecretPath         string            `env:"PROMETHEUS_SECRET_PATH"`
+	PrometheusBearerTokenFile    string            `env:"PROMETHEUS_BEARER_TOKEN_FILE"` // Used instead of basic auth if set
+	PrometheusCAFile             string            `env:"PROMETHEUS_CA_FILE"`
+	PrometheusCertFile           string            `env:"PROMETHEUS_CERT_FILE"` // Client certificate for mTLS
+	PrometheusKeyFile            string            `env:"PROMETHEUS_KEY_FILE"`
+	PrometheusInsecureSkipVerify bool              `env:"PROMETHEUS_INSECURE_SKIP_VERIFY" envDefault:"false"`
+	PrometheusHeaders            map[string]string `env:"PROMETHEUS_HEADERS" envKeyValSeparator:"="` // ex. X-Scope-OrgID=tenant
+	// Authentication. At least one of OIDC_ISSUER_URL & STATIC_TOKENS_FILE is required if AUTH_ENABLED is true.
+	AuthEnabled       bool   `env:"AUTH_ENABLED" envDefault:"false"`
+	OIDCIssuerURL     string `env:"OIDC_ISSUER_URL"`
+	OIDCAudience      string `env:"OIDC_AUDIENCE"`  // Client id tokens must be issued for
+	OIDCJWKSURL       string `env:"OIDC_JWKS_URL"`  // Discovered from issuer if not set
+	OIDCJWKSFile      string `env:"OIDC_JWKS_FILE"` // Local signing keys, used instead of fetching them if set
+	OIDCUsernameClaim string `env:"OIDC_USERNAME_CLAIM" envDefault:"email"`
+	OIDCGroupsClaim   string `env:"OIDC_GROUPS_CLAIM" envDefault:"groups"`
+	StaticTokensFile  string `env:"STATIC_TOKENS_FILE"` // YAML list of {token, username, groups} for service accounts
+	// Admins can manage every app. Only used if AUTH_ENABLED is true.
+	AdminUsers  []string `env:"ADMIN_USERS" envSeparator:","`
+	AdminGroups []string `env:"ADMIN_GROUPS" envSeparator:","`
+	// Secret that git push webhooks are signed with. Git webhook endpoint is only served if set.
+	GitWebhookSecret          string `env:"GIT_WEBHOOK_SECRET"`
+	GitWebhookDeliveryLogSize int    `env:"GIT_WEBHOOK_DELIVERY_LOG_SIZE" envDefault:"100"`
 }
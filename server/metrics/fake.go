@@ -0,0 +1,66 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// FakeProvider returns fixed metrics, for tests. Zero value returns empty metrics.
+type FakeProvider struct {
+	UsageResult   Usage
+	CountsPerUser map[UserCounter]map[string]float64
+	TrafficResult Traffic
+	// Points of each series, returned regardless of requested range
+	SeriesPoints map[Series][]Point
+	// Err is returned by every method if set
+	Err error
+}
+
+func (p *FakeProvider) Usage(context.Context, string, time.Duration) (*Usage, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	usage := p.UsageResult
+	return &usage, nil
+}
+
+func (p *FakeProvider) CountPerUser(_ context.Context, _ string, counter UserCounter, _ time.Duration) (map[string]float64, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	counts := make(map[string]float64)
+	for username, count := range p.CountsPerUser[counter] {
+		counts[username] = count
+	}
+	return counts, nil
+}
+
+func (p *FakeProvider) Traffic(context.Context, string, time.Duration) (*Traffic, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	traffic := p.TrafficResult
+	return &traffic, nil
+}
+
+func (p *FakeProvider) Range(_ context.Context, _ string, series Series, _, _ time.Time, _ time.Duration) ([]Point, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	return append([]Point(nil), p.SeriesPoints[series]...), nil
+}
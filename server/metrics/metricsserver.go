@@ -0,0 +1,123 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	controllerutil "github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/util"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podMetricsList is the part of metrics.k8s.io/v1beta1 PodMetricsList that is used.
+type podMetricsList struct {
+	Items []struct {
+		Containers []struct {
+			Name  string              `json:"name"`
+			Usage corev1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// MetricsServerProvider gets current CPU & memory usage from metrics-server, for clusters without Prometheus.
+// Gateway metrics & history are not supported.
+type MetricsServerProvider struct {
+	k8sClient kubernetes.Interface
+	namespace string
+}
+
+func NewMetricsServerProvider(k8sClient kubernetes.Interface, namespace string) *MetricsServerProvider {
+	return &MetricsServerProvider{
+		k8sClient: k8sClient,
+		namespace: namespace,
+	}
+}
+
+// Usage returns current usage, since metrics-server keeps no history to average over window.
+func (p *MetricsServerProvider) Usage(ctx context.Context, appId string, _ time.Duration) (*Usage, error) {
+	labelSelector := fmt.Sprintf("%s=%s", util.K8sNameLabel, appId)
+
+	body, err := p.k8sClient.Discovery().RESTClient().Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", p.namespace, "pods").
+		Param("labelSelector", labelSelector).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get pod metrics")
+	}
+
+	podMetrics := podMetricsList{}
+	if err := json.Unmarshal(body, &podMetrics); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode pod metrics")
+	}
+
+	cpuUsage, memoryUsage := resource.Quantity{}, resource.Quantity{}
+	for _, pod := range podMetrics.Items {
+		for _, container := range pod.Containers {
+			if container.Name != controllerutil.AppContainerName {
+				continue
+			}
+			cpuUsage.Add(container.Usage[corev1.ResourceCPU])
+			memoryUsage.Add(container.Usage[corev1.ResourceMemory])
+		}
+	}
+
+	pods, err := p.k8sClient.CoreV1().Pods(p.namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list app pods")
+	}
+
+	cpuLimit, memoryLimit := resource.Quantity{}, resource.Quantity{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name != controllerutil.AppContainerName {
+				continue
+			}
+			cpuLimit.Add(container.Resources.Limits[corev1.ResourceCPU])
+			memoryLimit.Add(container.Resources.Limits[corev1.ResourceMemory])
+		}
+	}
+
+	return &Usage{
+		CPUUsage:    cpuUsage.AsApproximateFloat64(),
+		CPULimit:    cpuLimit.AsApproximateFloat64(),
+		MemoryUsage: memoryUsage.AsApproximateFloat64(),
+		MemoryLimit: memoryLimit.AsApproximateFloat64(),
+	}, nil
+}
+
+func (p *MetricsServerProvider) CountPerUser(context.Context, string, UserCounter, time.Duration) (map[string]float64, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *MetricsServerProvider) Traffic(context.Context, string, time.Duration) (*Traffic, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *MetricsServerProvider) Range(context.Context, string, Series, time.Time, time.Time, time.Duration) ([]Point, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/tinymultiverse/tinyapp/server/internal"
+	"go.uber.org/zap"
+)
+
+const (
+	prometheusQueryPath      = "/api/v1/query"
+	prometheusRangeQueryPath = "/api/v1/query_range"
+	prometheusQueryTimeout   = 30 * time.Second
+)
+
+// prometheusSecret holds basic auth credentials of Prometheus.
+type prometheusSecret struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// prometheusResults is response of Prometheus query API.
+type prometheusResults struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+			// Value is [unix time, "value"], set for instant queries
+			Value [2]interface{} `json:"value"`
+			// Values are set for range queries
+			Values [][2]interface{} `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// PrometheusProvider gets metrics through Prometheus HTTP API,
+// which Thanos & VictoriaMetrics also serve.
+type PrometheusProvider struct {
+	baseUrl         string
+	httpClient      *http.Client
+	secret          prometheusSecret
+	bearerTokenFile string
+	headers         map[string]string
+	// Thanos deduplicates series of Prometheus replicas if asked to
+	thanos bool
+}
+
+func NewPrometheusProvider(env internal.EnvVars, thanos bool) (*PrometheusProvider, error) {
+	secret := prometheusSecret{
+		Username: env.PrometheusUserName,
+		Password: env.PrometheusPassword,
+	}
+	if env.PrometheusSecretPath != "" {
+		var err error
+		secret, err = readPrometheusSecret(env.PrometheusSecretPath)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read Prometheus secret")
+		}
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: env.PrometheusInsecureSkipVerify}
+	if env.PrometheusCAFile != "" {
+		caCert, err := os.ReadFile(env.PrometheusCAFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read Prometheus CA file")
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no certificates found in Prometheus CA file")
+		}
+	}
+	if env.PrometheusCertFile != "" || env.PrometheusKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(env.PrometheusCertFile, env.PrometheusKeyFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to load Prometheus client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	// PROMETHEUS_URL used to be the query endpoint, so accept that as well as the base url
+	baseUrl := strings.TrimSuffix(strings.TrimSuffix(env.PrometheusUrl, "/"), prometheusQueryPath)
+
+	return &PrometheusProvider{
+		baseUrl:         baseUrl,
+		httpClient:      &http.Client{Transport: transport, Timeout: prometheusQueryTimeout},
+		secret:          secret,
+		bearerTokenFile: env.PrometheusBearerTokenFile,
+		headers:         env.PrometheusHeaders,
+		thanos:          thanos,
+	}, nil
+}
+
+func readPrometheusSecret(filepath string) (prometheusSecret, error) {
+	promSecret := prometheusSecret{}
+	file, err := os.ReadFile(filepath)
+	if err != nil {
+		return promSecret, err
+	}
+	err = yaml.Unmarshal(file, &promSecret)
+	if err != nil {
+		return promSecret, err
+	}
+	return promSecret, nil
+}
+
+func (p *PrometheusProvider) Usage(ctx context.Context, appId string, window time.Duration) (*Usage, error) {
+	podNameRegex := getPodNameRegex(appId)
+	timeRange := model.Duration(window).String()
+
+	usage := &Usage{}
+	queries := []struct {
+		query  string
+		result *float64
+	}{
+		{query: fmt.Sprintf("sum(rate(container_cpu_usage_seconds_total{container=\"app\", pod=~\"%s\"}[%s]))", podNameRegex, timeRange), result: &usage.CPUUsage},
+		{query: fmt.Sprintf("sum(kube_pod_container_resource_limits{container=\"app\", resource=\"cpu\", pod=~\"%s\"})", podNameRegex), result: &usage.CPULimit},
+		{query: fmt.Sprintf("sum(container_memory_working_set_bytes{container=\"app\", pod=~\"%s\"})", podNameRegex), result: &usage.MemoryUsage},
+		{query: fmt.Sprintf("sum(kube_pod_container_resource_limits{container=\"app\", resource=\"memory\", pod=~\"%s\"})", podNameRegex), result: &usage.MemoryLimit},
+	}
+
+	// Usage is still useful if some of it is unknown, ex. when kube-state-metrics is not installed
+	for _, query := range queries {
+		value, err := p.queryValue(ctx, query.query)
+		if err != nil {
+			zap.S().Errorw("Failed to query usage metric", "query", query.query, "error", err)
+			continue
+		}
+		*query.result = value
+	}
+
+	return usage, nil
+}
+
+func (p *PrometheusProvider) CountPerUser(ctx context.Context, appId string, counter UserCounter, window time.Duration) (map[string]float64, error) {
+	query := fmt.Sprintf("sum by(username) (increase(%s{kubernetes_pod_name=~\"%s\"}[%s]))",
+		counter, getPodNameRegex(appId), model.Duration(window).String())
+
+	results, err := p.query(ctx, prometheusQueryPath, url.Values{"query": {query}})
+	if err != nil {
+		return nil, err
+	}
+
+	countPerUser := make(map[string]float64)
+	for _, result := range results.Data.Result {
+		count, err := parseSampleValue(result.Value)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse username count")
+		}
+		countPerUser[result.Metric["username"]] = count
+	}
+
+	return countPerUser, nil
+}
+
+func (p *PrometheusProvider) Traffic(ctx context.Context, appId string, window time.Duration) (*Traffic, error) {
+	appSelector := fmt.Sprintf("app_name=\"%s\"", appId)
+	timeRange := model.Duration(window).String()
+
+	traffic := &Traffic{}
+	latencyQuery := "histogram_quantile(%s, sum by(le) (rate(gateway_request_duration_seconds_bucket{%s}[%s])))"
+	queries := []struct {
+		name   string
+		query  string
+		result *float64
+	}{
+		{name: "p50 latency", query: fmt.Sprintf(latencyQuery, "0.5", appSelector, timeRange), result: &traffic.P50LatencySeconds},
+		{name: "p95 latency", query: fmt.Sprintf(latencyQuery, "0.95", appSelector, timeRange), result: &traffic.P95LatencySeconds},
+		{name: "request rate", query: fmt.Sprintf("sum(rate(gateway_requests_total{%s}[%s]))", appSelector, timeRange), result: &traffic.RequestRate},
+		{name: "error rate", query: fmt.Sprintf("sum(rate(gateway_requests_total{%s, code_class=\"5xx\"}[%s])) / sum(rate(gateway_requests_total{%s}[%s]))",
+			appSelector, timeRange, appSelector, timeRange), result: &traffic.ErrorRate},
+		{name: "websocket connections", query: fmt.Sprintf("sum(gateway_websocket_connections_active{%s})", appSelector), result: &traffic.ActiveWebsocketConnections},
+		{name: "received bytes rate", query: fmt.Sprintf("sum(rate(gateway_request_bytes_total{%s}[%s]))", appSelector, timeRange), result: &traffic.ReceivedBytesRate},
+		{name: "sent bytes rate", query: fmt.Sprintf("sum(rate(gateway_response_bytes_total{%s}[%s]))", appSelector, timeRange), result: &traffic.SentBytesRate},
+	}
+
+	for _, query := range queries {
+		value, err := p.queryValue(ctx, query.query)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get %s", query.name)
+		}
+		*query.result = value
+	}
+
+	return traffic, nil
+}
+
+func (p *PrometheusProvider) Range(ctx context.Context, appId string, series Series, start, end time.Time, step time.Duration) ([]Point, error) {
+	podNameRegex := getPodNameRegex(appId)
+	stepRange := model.Duration(step).String()
+
+	var query string
+	switch series {
+	case CPUUsageSeries:
+		query = fmt.Sprintf("sum(rate(container_cpu_usage_seconds_total{container=\"app\", pod=~\"%s\"}[%s]))", podNameRegex, stepRange)
+	case MemoryUsageSeries:
+		query = fmt.Sprintf("sum(container_memory_working_set_bytes{container=\"app\", pod=~\"%s\"})", podNameRegex)
+	case RequestRateSeries:
+		query = fmt.Sprintf("sum(rate(gateway_requests_total{app_name=\"%s\"}[%s]))", appId, stepRange)
+	case AccessCountSeries:
+		query = fmt.Sprintf("sum(increase(%s{kubernetes_pod_name=~\"%s\"}[%s]))", AccessCounter, podNameRegex, stepRange)
+	default:
+		return nil, errors.Errorf("unknown series %s", series)
+	}
+
+	params := url.Values{
+		"query": {query},
+		"start": {strconv.FormatInt(start.Unix(), 10)},
+		"end":   {strconv.FormatInt(end.Unix(), 10)},
+		"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	}
+	if p.thanos {
+		// Use downsampled data for long ranges
+		params.Set("max_source_resolution", "auto")
+	}
+
+	results, err := p.query(ctx, prometheusRangeQueryPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results.Data.Result) == 0 {
+		return nil, nil
+	}
+
+	var points []Point
+	for _, sample := range results.Data.Result[0].Values {
+		timestamp, ok := sample[0].(float64)
+		if !ok {
+			return nil, errors.New("unexpected timestamp in Prometheus API response")
+		}
+
+		value, err := parseSampleValue(sample)
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, Point{Time: time.Unix(int64(timestamp), 0), Value: value})
+	}
+
+	return points, nil
+}
+
+// queryValue makes instant query for a single value.
+// Returns 0 if there is no data, or the value is undefined (ex. quantile or ratio of no requests).
+func (p *PrometheusProvider) queryValue(ctx context.Context, query string) (float64, error) {
+	results, err := p.query(ctx, prometheusQueryPath, url.Values{"query": {query}})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(results.Data.Result) == 0 {
+		return 0, nil
+	}
+
+	value, err := parseSampleValue(results.Data.Result[0].Value)
+	if err != nil {
+		return 0, err
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, nil
+	}
+
+	return value, nil
+}
+
+// query sends query to given Prometheus API endpoint and returns decoded response.
+func (p *PrometheusProvider) query(ctx context.Context, path string, params url.Values) (*prometheusResults, error) {
+	if p.baseUrl == "" {
+		return nil, errors.New("PROMETHEUS_URL is not set")
+	}
+
+	if p.thanos {
+		params.Set("dedup", "true")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseUrl+path, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create request to Prometheus API")
+	}
+	req.URL.RawQuery = params.Encode()
+
+	if p.bearerTokenFile != "" {
+		// Token is read on every request, since it may be rotated
+		token, err := os.ReadFile(p.bearerTokenFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read Prometheus bearer token")
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	} else if p.secret.Username != "" || p.secret.Password != "" {
+		req.SetBasicAuth(p.secret.Username, p.secret.Password)
+	}
+
+	for name, value := range p.headers {
+		req.Header.Set(name, value)
+	}
+
+	response, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to complete request to Prometheus API")
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read Prometheus API response")
+	}
+
+	zap.S().Debug("Finished querying results")
+
+	results := &prometheusResults{}
+	if err := json.Unmarshal(body, results); err != nil {
+		return nil, errors.Errorf("unexpected Prometheus API response (status %d)", response.StatusCode)
+	}
+
+	if results.Status != "success" {
+		return nil, errors.Errorf("prometheus query failed: %s", results.Error)
+	}
+
+	return results, nil
+}
+
+// parseSampleValue parses value of [unix time, "value"] sample.
+func parseSampleValue(sample [2]interface{}) (float64, error) {
+	valueString, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.New("unexpected value in Prometheus API response")
+	}
+
+	value, err := strconv.ParseFloat(valueString, 64)
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to convert string to float")
+	}
+
+	return value, nil
+}
+
+func getPodNameRegex(appId string) string {
+	return appId + "-.*"
+}
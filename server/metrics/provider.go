@@ -0,0 +1,125 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package metrics gets app metrics from the metrics backend chosen by the operator.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/server/internal"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Metrics providers
+const (
+	ProviderPrometheus      = "prometheus"
+	ProviderThanos          = "thanos"
+	ProviderVictoriaMetrics = "victoriametrics"
+	ProviderMetricsServer   = "metrics-server"
+)
+
+// ErrNotSupported is returned for metrics that provider can't get, ex. gateway metrics from metrics-server.
+var ErrNotSupported = errors.New("not supported by metrics provider")
+
+// UserCounter is a gateway counter kept per app user.
+type UserCounter string
+
+const (
+	AccessCounter  UserCounter = "username_counter"
+	SessionCounter UserCounter = "session_counter"
+)
+
+// Series is a time series that can be charted.
+type Series string
+
+const (
+	CPUUsageSeries    Series = "cpu_usage"
+	MemoryUsageSeries Series = "memory_usage"
+	RequestRateSeries Series = "request_rate"
+	AccessCountSeries Series = "access_count"
+)
+
+// AllSeries lists series in the order they are returned.
+var AllSeries = []Series{CPUUsageSeries, MemoryUsageSeries, RequestRateSeries, AccessCountSeries}
+
+// Unit returns unit of series values.
+func (s Series) Unit() string {
+	switch s {
+	case CPUUsageSeries:
+		return "cores"
+	case MemoryUsageSeries:
+		return "bytes"
+	case RequestRateSeries:
+		return "requests/s"
+	case AccessCountSeries:
+		return "accesses"
+	}
+	return ""
+}
+
+// Usage is CPU & memory of app container, summed over all app pods.
+// Values are 0 if unknown.
+type Usage struct {
+	CPUUsage    float64 // Cores
+	CPULimit    float64 // Cores
+	MemoryUsage float64 // Bytes
+	MemoryLimit float64 // Bytes
+}
+
+// Traffic is app requests as seen by gateway. Values are 0 if app had no requests.
+type Traffic struct {
+	P50LatencySeconds          float64
+	P95LatencySeconds          float64
+	RequestRate                float64 // Requests per second
+	ErrorRate                  float64 // Fraction of requests with 5xx response
+	ActiveWebsocketConnections float64
+	ReceivedBytesRate          float64
+	SentBytesRate              float64
+}
+
+// Point is a value of series at a time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Provider gets app metrics from a metrics backend.
+type Provider interface {
+	// Usage returns CPU & memory of app. CPU usage is averaged over window if provider supports it.
+	Usage(ctx context.Context, appId string, window time.Duration) (*Usage, error)
+	// CountPerUser returns increase of counter over window, by username.
+	CountPerUser(ctx context.Context, appId string, counter UserCounter, window time.Duration) (map[string]float64, error)
+	// Traffic returns app requests over window.
+	Traffic(ctx context.Context, appId string, window time.Duration) (*Traffic, error)
+	// Range returns points of series from start to end, step apart. Returns no points if there is no data.
+	Range(ctx context.Context, appId string, series Series, start, end time.Time, step time.Duration) ([]Point, error)
+}
+
+// NewProvider creates provider chosen with METRICS_PROVIDER.
+func NewProvider(env internal.EnvVars, k8sClient kubernetes.Interface) (Provider, error) {
+	switch env.MetricsProvider {
+	case ProviderPrometheus, ProviderVictoriaMetrics:
+		return NewPrometheusProvider(env, false)
+	case ProviderThanos:
+		return NewPrometheusProvider(env, true)
+	case ProviderMetricsServer:
+		return NewMetricsServerProvider(k8sClient, env.TinyAppNamespace), nil
+	}
+
+	return nil, errors.Errorf("unknown metrics provider %s", env.MetricsProvider)
+}
@@ -17,3 +17,6 @@ limitations under the License.
 package util
 
 const FieldManager = "tinyapp-server"
+
+// GitWebhookPath is where git hosts send push webhooks to.
+const GitWebhookPath = "/v1/git-webhook"
@@ -17,10 +17,12 @@ limitations under the License.
 package util
 
 import (
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/defaulting"
 	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
 	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
 	"github.com/tinymultiverse/tinyapp/server/internal"
-	globalutil "github.com/tinymultiverse/tinyapp/util"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +45,9 @@ func ConvertToProtoTinyApp(in *v1alpha1.TinyApp) (*pb.TinyApp, error) {
 			Id:                in.Name,
 			AppUrl:            appUrl,
 			CreationTimeStamp: in.CreationTimestamp.Time.String(),
+			AppImage:          in.Spec.Image,
+			GitCommit:         ConvertToProtoGitCommit(in),
+			ImageDigest:       ConvertToProtoImageDigest(in),
 		},
 		AppDetail: &pb.TinyAppDetail{
 			Name:                in.Spec.DisplayName,
@@ -50,16 +55,74 @@ func ConvertToProtoTinyApp(in *v1alpha1.TinyApp) (*pb.TinyApp, error) {
 			Documentation:       in.Spec.Documentation,
 			Image:               in.Spec.Image,
 			AppType:             ConvertToProtoAppType(in.Spec.AppType),
+			AppRuntime:          ConvertToProtoAppRuntime(in.Spec.AppType),
+			Custom:              ConvertToProtoCustomApp(in.Spec.Custom),
+			Probes:              ConvertToProtoProbes(in.Spec.Probes),
 			SourceType:          ConvertToProtoSourceType(in.Spec.SourceType),
 			GitConfig:           ConvertToProtoGitConfig(in.Spec.GitConfig),
 			MainFilePath:        in.Spec.MainFilePath,
 			Env:                 ConvertToProtoEnvVars(in.Spec.EnvVars),
 			VolumeClaims:        ConvertToProtoVolumeClaims(in.Spec.VolumeClaims),
 			MainVolumeClaimName: in.Spec.MainVolumeClaimName,
+			Resources:           ConvertToProtoResources(in.Spec.Resources),
+			Replicas:            in.Spec.Replicas,
+			Autoscaling:         ConvertToProtoAutoscaling(in.Spec.Autoscaling),
+			IdleTimeout:         ConvertToProtoDuration(in.Spec.IdleTimeout),
+			Auth:                ConvertToProtoAuth(in.Spec.Auth),
 		},
+		Status: ConvertToProtoStatus(in),
+		Access: ConvertToProtoAccess(in.Spec.Access),
 	}, nil
 }
 
+func ConvertToProtoAccess(access *v1alpha1.AppAccess) *pb.TinyAppAccess {
+	if access == nil {
+		return nil
+	}
+
+	return &pb.TinyAppAccess{
+		Creator: access.Creator,
+		Owners:  access.Owners,
+		Groups:  access.Groups,
+	}
+}
+
+func ConvertToProtoStatus(in *v1alpha1.TinyApp) *pb.TinyAppStatus {
+	var protoConditions []*pb.TinyAppCondition
+	for _, condition := range in.Status.Conditions {
+		protoCondition := &pb.TinyAppCondition{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		}
+		if !condition.LastTransitionTime.IsZero() {
+			protoCondition.LastTransitionTime = condition.LastTransitionTime.Time.String()
+		}
+		protoConditions = append(protoConditions, protoCondition)
+	}
+
+	return &pb.TinyAppStatus{
+		Phase:              string(in.Status.Phase),
+		Conditions:         protoConditions,
+		Replicas:           in.Status.Replicas,
+		ReadyReplicas:      in.Status.ReadyReplicas,
+		ObservedGeneration: in.Status.ObservedGeneration,
+		Generation:         in.Generation,
+		GitCommit:          in.Status.GitCommit,
+		ResolvedGitCommit:  in.Status.ResolvedGitCommit,
+		ImageDigest:        in.Status.ImageDigest,
+	}
+}
+
+func ConvertToProtoDuration(duration *metav1.Duration) string {
+	if duration == nil {
+		return ""
+	}
+
+	return duration.Duration.String()
+}
+
 func ConvertToProtoEnvVars(envVars []*corev1.EnvVar) []*pb.EnvVar {
 	var protoEnvVars []*pb.EnvVar
 	for _, envVar := range envVars {
@@ -72,12 +135,33 @@ func ConvertToProtoEnvVars(envVars []*corev1.EnvVar) []*pb.EnvVar {
 	return protoEnvVars
 }
 
+// ConvertToProtoAppRuntime returns app type if it has no proto AppType, such as app types added in app runtimes
+// ConfigMap. Returns empty string otherwise.
+func ConvertToProtoAppRuntime(appType v1alpha1.AppType) string {
+	if ConvertToProtoAppType(appType) != pb.AppType_APP_TYPE_UNKNOWN {
+		return ""
+	}
+	return string(appType)
+}
+
 func ConvertToProtoAppType(appType v1alpha1.AppType) pb.AppType {
 	switch appType {
 	case v1alpha1.AppTypeStreamlit:
 		return pb.AppType_APP_TYPE_STREAM_LIT
 	case v1alpha1.AppTypeDash:
 		return pb.AppType_APP_TYPE_DASH
+	case v1alpha1.AppTypeGradio:
+		return pb.AppType_APP_TYPE_GRADIO
+	case v1alpha1.AppTypePanel:
+		return pb.AppType_APP_TYPE_PANEL
+	case v1alpha1.AppTypeShiny:
+		return pb.AppType_APP_TYPE_SHINY
+	case v1alpha1.AppTypeVoila:
+		return pb.AppType_APP_TYPE_VOILA
+	case v1alpha1.AppTypeBokeh:
+		return pb.AppType_APP_TYPE_BOKEH
+	case v1alpha1.AppTypeCustom:
+		return pb.AppType_APP_TYPE_CUSTOM
 	default:
 		return pb.AppType_APP_TYPE_UNKNOWN
 	}
@@ -89,6 +173,8 @@ func ConvertToProtoSourceType(sourceType v1alpha1.SourceType) pb.SourceType {
 		return pb.SourceType_SOURCE_TYPE_GIT
 	case v1alpha1.SourceTypeFileSystem:
 		return pb.SourceType_SOURCE_TYPE_FILE_SYSTEM
+	case v1alpha1.SourceTypeImage:
+		return pb.SourceType_SOURCE_TYPE_IMAGE
 	default:
 		return pb.SourceType_SOURCE_TYPE_UNKNOWN
 	}
@@ -100,9 +186,129 @@ func ConvertToProtoGitConfig(gitConfig *v1alpha1.GitConfig) *pb.GitConfig {
 	}
 
 	return &pb.GitConfig{
-		Url:   gitConfig.GitUrl,
-		Ref:   gitConfig.GitRef,
-		IsTag: gitConfig.IsTag,
+		Url:          gitConfig.GitUrl,
+		Ref:          gitConfig.GitRef,
+		IsTag:        gitConfig.IsTag,
+		SyncInterval: ConvertToProtoDuration(gitConfig.SyncInterval),
+		RefType:      ConvertToProtoGitRefType(gitConfig.GetRefType()),
+		Branch:       gitConfig.Branch,
+	}
+}
+
+func ConvertToProtoGitRefType(refType v1alpha1.GitRefType) pb.GitRefType {
+	switch refType {
+	case v1alpha1.GitRefTypeBranch:
+		return pb.GitRefType_GIT_REF_TYPE_BRANCH
+	case v1alpha1.GitRefTypeTag:
+		return pb.GitRefType_GIT_REF_TYPE_TAG
+	case v1alpha1.GitRefTypeCommit:
+		return pb.GitRefType_GIT_REF_TYPE_COMMIT
+	default:
+		return pb.GitRefType_GIT_REF_TYPE_UNSPECIFIED
+	}
+}
+
+// ConvertToProtoGitCommit returns the commit SHA that app git ref was resolved to, or empty string if it hasn't been
+// resolved for the current app spec yet.
+func ConvertToProtoGitCommit(in *v1alpha1.TinyApp) string {
+	gitConfig := in.Spec.GitConfig
+	if in.Spec.SourceType != v1alpha1.SourceTypeGit || gitConfig == nil {
+		return ""
+	}
+
+	if gitConfig.GetRefType() == v1alpha1.GitRefTypeCommit {
+		return gitConfig.GitRef
+	}
+	if in.Status.ObservedGeneration != in.Generation {
+		return ""
+	}
+
+	return in.Status.ResolvedGitCommit
+}
+
+// ConvertToProtoImageDigest returns the digest that app image was resolved to, or empty string if it hasn't been
+// resolved for the current app spec yet.
+func ConvertToProtoImageDigest(in *v1alpha1.TinyApp) string {
+	if in.Spec.SourceType != v1alpha1.SourceTypeImage || in.Status.ObservedGeneration != in.Generation {
+		return ""
+	}
+
+	return in.Status.ImageDigest
+}
+
+func ConvertToProtoResources(resources *v1alpha1.AppResources) *pb.Resources {
+	if resources == nil {
+		return nil
+	}
+
+	return &pb.Resources{
+		CpuRequest:    resources.CPURequest,
+		CpuLimit:      resources.CPULimit,
+		MemoryRequest: resources.MemoryRequest,
+		MemoryLimit:   resources.MemoryLimit,
+	}
+}
+
+func ConvertToProtoAutoscaling(autoscaling *v1alpha1.AutoscalingPolicy) *pb.Autoscaling {
+	if autoscaling == nil {
+		return nil
+	}
+
+	return &pb.Autoscaling{
+		MinReplicas:                    autoscaling.MinReplicas,
+		MaxReplicas:                    autoscaling.MaxReplicas,
+		TargetCpuUtilizationPercentage: autoscaling.TargetCPUUtilizationPercentage,
+	}
+}
+
+func ConvertToProtoAuth(auth *v1alpha1.AppAuth) *pb.AppAuth {
+	if auth == nil {
+		return nil
+	}
+
+	return &pb.AppAuth{
+		AllowedUsers:  auth.AllowedUsers,
+		AllowedGroups: auth.AllowedGroups,
+	}
+}
+
+func ConvertToProtoCustomApp(custom *v1alpha1.CustomApp) *pb.CustomApp {
+	if custom == nil {
+		return nil
+	}
+
+	return &pb.CustomApp{
+		Command:            custom.Command,
+		Args:               custom.Args,
+		Port:               custom.Port,
+		BasePathEnvVarName: custom.BasePathEnvVarName,
+	}
+}
+
+func ConvertToProtoProbes(probes *v1alpha1.AppProbes) *pb.AppProbes {
+	if probes == nil {
+		return nil
+	}
+
+	return &pb.AppProbes{
+		Disabled:  probes.Disabled,
+		Path:      probes.Path,
+		Startup:   ConvertToProtoProbeSettings(probes.Startup),
+		Readiness: ConvertToProtoProbeSettings(probes.Readiness),
+		Liveness:  ConvertToProtoProbeSettings(probes.Liveness),
+	}
+}
+
+func ConvertToProtoProbeSettings(settings *v1alpha1.ProbeSettings) *pb.ProbeSettings {
+	if settings == nil {
+		return nil
+	}
+
+	return &pb.ProbeSettings{
+		InitialDelaySeconds: settings.InitialDelaySeconds,
+		PeriodSeconds:       settings.PeriodSeconds,
+		TimeoutSeconds:      settings.TimeoutSeconds,
+		FailureThreshold:    settings.FailureThreshold,
 	}
 }
 
@@ -126,37 +332,57 @@ func ConvertToK8sTinyApp(in *pb.TinyAppDetail, objName string, envVars internal.
 		return nil, errors.New("empty TinyApp request")
 	}
 
-	image := envVars.DefaultAppImage
-	if in.Image != "" {
-		image = in.Image
+	idleTimeout, err := ConvertToK8sIdleTimeout(in.IdleTimeout)
+	if err != nil {
+		return nil, err
 	}
 
-	tinyAppLabels := make(map[string]string)
-	tinyAppLabels[globalutil.K8sNameLabel] = objName
-	tinyAppLabels[globalutil.K8sPartOfLabel] = globalutil.TinyAppPartOfLabel
+	gitConfig, err := ConvertToK8sGitConfig(in.GitConfig, objName, envVars)
+	if err != nil {
+		return nil, err
+	}
 
-	return &v1alpha1.TinyApp{
+	appType := ConvertToK8sAppType(in.AppType)
+	if in.AppRuntime != "" {
+		appType = v1alpha1.AppType(in.AppRuntime)
+	}
+
+	app := &v1alpha1.TinyApp{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   objName,
-			Labels: tinyAppLabels,
+			Name: objName,
 		},
 		Spec: v1alpha1.TinyAppSpec{
 			DisplayName:         in.Name,
 			Description:         in.Description,
 			Documentation:       in.Documentation,
-			Image:               image,
-			AppType:             ConvertToK8sAppType(in.AppType),
+			Image:               in.Image,
+			AppType:             appType,
+			Custom:              ConvertToK8sCustomApp(in.Custom),
+			Probes:              ConvertToK8sProbes(in.Probes),
 			SourceType:          ConvertToK8sSourceType(in.SourceType),
-			GitConfig:           ConvertToK8sGitConfig(in.GitConfig, objName, envVars),
+			GitConfig:           gitConfig,
 			MainFilePath:        in.MainFilePath,
 			EnvVars:             ConvertToK8sEnvVars(in.Env),
 			VolumeClaims:        ConvertToK8sVolumeClaims(in.VolumeClaims),
 			MainVolumeClaimName: in.MainVolumeClaimName,
-			IngressDomain:       envVars.AppIngressDomain,
-			IngressSubPath:      envVars.AppIngressSubPath,
-			IngressTlsEnabled:   envVars.AppIngressTlsEnabled,
+			Resources:           ConvertToK8sResources(in.Resources),
+			Replicas:            in.Replicas,
+			Autoscaling:         ConvertToK8sAutoscaling(in.Autoscaling),
+			IdleTimeout:         idleTimeout,
+			Auth:                ConvertToK8sAuth(in.Auth),
 		},
-	}, nil
+	}
+
+	// Same defaults as controller's defaulting webhook, except for resources which are only known to controller
+	defaulting.SetDefaults(app, defaulting.Defaults{
+		Image:              envVars.DefaultAppImage,
+		IngressDomain:      envVars.AppIngressDomain,
+		IngressSubPath:     envVars.AppIngressSubPath,
+		IngressTlsEnabled:  envVars.AppIngressTlsEnabled,
+		GitTokenSecretName: envVars.DefaultGitTokenSecret,
+	})
+
+	return app, nil
 }
 
 func ConvertToK8sEnvVars(envVars []*pb.EnvVar) []*corev1.EnvVar {
@@ -177,6 +403,18 @@ func ConvertToK8sAppType(appType pb.AppType) v1alpha1.AppType {
 		return v1alpha1.AppTypeStreamlit
 	case pb.AppType_APP_TYPE_DASH:
 		return v1alpha1.AppTypeDash
+	case pb.AppType_APP_TYPE_GRADIO:
+		return v1alpha1.AppTypeGradio
+	case pb.AppType_APP_TYPE_PANEL:
+		return v1alpha1.AppTypePanel
+	case pb.AppType_APP_TYPE_SHINY:
+		return v1alpha1.AppTypeShiny
+	case pb.AppType_APP_TYPE_VOILA:
+		return v1alpha1.AppTypeVoila
+	case pb.AppType_APP_TYPE_BOKEH:
+		return v1alpha1.AppTypeBokeh
+	case pb.AppType_APP_TYPE_CUSTOM:
+		return v1alpha1.AppTypeCustom
 	default:
 		return v1alpha1.AppTypeUnknown
 	}
@@ -188,14 +426,30 @@ func ConvertToK8sSourceType(sourceType pb.SourceType) v1alpha1.SourceType {
 		return v1alpha1.SourceTypeGit
 	case pb.SourceType_SOURCE_TYPE_FILE_SYSTEM:
 		return v1alpha1.SourceTypeFileSystem
+	case pb.SourceType_SOURCE_TYPE_IMAGE:
+		return v1alpha1.SourceTypeImage
 	default:
 		return v1alpha1.SourceTypeUnknown
 	}
 }
 
-func ConvertToK8sGitConfig(gitConfig *pb.GitConfig, appObjName string, envVars internal.EnvVars) *v1alpha1.GitConfig {
+func ConvertToK8sGitRefType(refType pb.GitRefType) v1alpha1.GitRefType {
+	switch refType {
+	case pb.GitRefType_GIT_REF_TYPE_BRANCH:
+		return v1alpha1.GitRefTypeBranch
+	case pb.GitRefType_GIT_REF_TYPE_TAG:
+		return v1alpha1.GitRefTypeTag
+	case pb.GitRefType_GIT_REF_TYPE_COMMIT:
+		return v1alpha1.GitRefTypeCommit
+	default:
+		// Derived from is_tag
+		return ""
+	}
+}
+
+func ConvertToK8sGitConfig(gitConfig *pb.GitConfig, appObjName string, envVars internal.EnvVars) (*v1alpha1.GitConfig, error) {
 	if gitConfig == nil {
-		return nil
+		return nil, nil
 	}
 
 	tokenSecretName := envVars.DefaultGitTokenSecret
@@ -204,12 +458,116 @@ func ConvertToK8sGitConfig(gitConfig *pb.GitConfig, appObjName string, envVars i
 		tokenSecretName = appObjName
 	}
 
+	var syncInterval *metav1.Duration
+	if gitConfig.SyncInterval != "" {
+		duration, err := time.ParseDuration(gitConfig.SyncInterval)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid git sync interval %q", gitConfig.SyncInterval)
+		}
+		syncInterval = &metav1.Duration{Duration: duration}
+	}
+
 	return &v1alpha1.GitConfig{
 		GitUrl:          gitConfig.Url,
 		GitRef:          gitConfig.Ref,
 		IsTag:           gitConfig.IsTag,
+		RefType:         ConvertToK8sGitRefType(gitConfig.RefType),
+		Branch:          gitConfig.Branch,
 		TokenSecretName: tokenSecretName,
+		SyncInterval:    syncInterval,
+	}, nil
+}
+
+func ConvertToK8sResources(resources *pb.Resources) *v1alpha1.AppResources {
+	if resources == nil {
+		return nil
+	}
+
+	return &v1alpha1.AppResources{
+		CPURequest:    resources.CpuRequest,
+		CPULimit:      resources.CpuLimit,
+		MemoryRequest: resources.MemoryRequest,
+		MemoryLimit:   resources.MemoryLimit,
+	}
+}
+
+func ConvertToK8sAutoscaling(autoscaling *pb.Autoscaling) *v1alpha1.AutoscalingPolicy {
+	if autoscaling == nil {
+		return nil
+	}
+
+	return &v1alpha1.AutoscalingPolicy{
+		MinReplicas:                    autoscaling.MinReplicas,
+		MaxReplicas:                    autoscaling.MaxReplicas,
+		TargetCPUUtilizationPercentage: autoscaling.TargetCpuUtilizationPercentage,
+	}
+}
+
+func ConvertToK8sAuth(auth *pb.AppAuth) *v1alpha1.AppAuth {
+	if auth == nil {
+		return nil
+	}
+
+	return &v1alpha1.AppAuth{
+		AllowedUsers:  auth.AllowedUsers,
+		AllowedGroups: auth.AllowedGroups,
+	}
+}
+
+func ConvertToK8sCustomApp(custom *pb.CustomApp) *v1alpha1.CustomApp {
+	if custom == nil {
+		return nil
+	}
+
+	return &v1alpha1.CustomApp{
+		Command:            custom.Command,
+		Args:               custom.Args,
+		Port:               custom.Port,
+		BasePathEnvVarName: custom.BasePathEnvVarName,
+	}
+}
+
+func ConvertToK8sProbes(probes *pb.AppProbes) *v1alpha1.AppProbes {
+	if probes == nil {
+		return nil
 	}
+
+	return &v1alpha1.AppProbes{
+		Disabled:  probes.Disabled,
+		Path:      probes.Path,
+		Startup:   ConvertToK8sProbeSettings(probes.Startup),
+		Readiness: ConvertToK8sProbeSettings(probes.Readiness),
+		Liveness:  ConvertToK8sProbeSettings(probes.Liveness),
+	}
+}
+
+func ConvertToK8sProbeSettings(settings *pb.ProbeSettings) *v1alpha1.ProbeSettings {
+	if settings == nil {
+		return nil
+	}
+
+	return &v1alpha1.ProbeSettings{
+		InitialDelaySeconds: settings.InitialDelaySeconds,
+		PeriodSeconds:       settings.PeriodSeconds,
+		TimeoutSeconds:      settings.TimeoutSeconds,
+		FailureThreshold:    settings.FailureThreshold,
+	}
+}
+
+func ConvertToK8sIdleTimeout(idleTimeout string) (*metav1.Duration, error) {
+	if idleTimeout == "" {
+		return nil, nil
+	}
+
+	duration, err := time.ParseDuration(idleTimeout)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid idle timeout %q", idleTimeout)
+	}
+	if duration <= 0 {
+		return nil, errors.Errorf("idle timeout must be positive, got %q", idleTimeout)
+	}
+
+	return &metav1.Duration{Duration: duration}, nil
 }
 
 func ConvertToK8sVolumeClaims(volumeClaims []*pb.VolumeClaim) []*v1alpha1.VolumeClaim {
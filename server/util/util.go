@@ -17,39 +17,9 @@ limitations under the License.
 package util
 
 import (
-	"fmt"
-	"net/url"
-
-	"github.com/pkg/errors"
 	"github.com/tinymultiverse/tinyapp/controller/reconciler/builder"
-	"github.com/tinymultiverse/tinyapp/util"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 func GetURLForTinyApp(domain, subPath, appId string, tlsEnabled bool) (string, error) {
-	ingressPath, err := builder.BuildIngressPath(subPath, appId)
-	if err != nil {
-		return "", errors.WithMessage(err, "failed to build ingress path")
-	}
-
-	appUrl, err := url.JoinPath(domain, ingressPath)
-	if err != nil {
-		return "", errors.WithMessage(err, "failed to join domain and ingress path")
-	}
-
-	if tlsEnabled {
-		appUrl = fmt.Sprintf("%s%s", util.Https, appUrl)
-	} else {
-		appUrl = fmt.Sprintf("%s%s", util.Http, appUrl)
-	}
-
-	return appUrl, nil
-}
-
-func GetKubeConfig(kubeconfig string) (*rest.Config, error) {
-	if kubeconfig != "" {
-		return clientcmd.BuildConfigFromFlags("", kubeconfig)
-	}
-	return rest.InClusterConfig()
+	return builder.BuildAppURL(domain, subPath, appId, tlsEnabled)
 }
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"slices"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"github.com/tinymultiverse/tinyapp/server/util"
+	"github.com/tinymultiverse/tinyapp/util/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+func (s *Server) AddTinyAppCollaborators(ctx context.Context, in *pb.AddTinyAppCollaboratorsRequest) (*pb.TinyAppAccess, error) {
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
+	logger.Infow("Received request to add tiny app collaborators", "users", in.Users, "groups", in.Groups)
+
+	access, err := s.updateTinyAppAccess(ctx, in.AppId, func(access *v1alpha1.AppAccess) {
+		for _, user := range in.Users {
+			if user != "" && user != access.Creator && !slices.Contains(access.Owners, user) {
+				access.Owners = append(access.Owners, user)
+			}
+		}
+		for _, group := range in.Groups {
+			if group != "" && !slices.Contains(access.Groups, group) {
+				access.Groups = append(access.Groups, group)
+			}
+		}
+	})
+	if err != nil {
+		logger.Errorw("Failed to add tiny app collaborators", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully added tiny app collaborators")
+
+	return util.ConvertToProtoAccess(access), nil
+}
+
+func (s *Server) RemoveTinyAppCollaborators(ctx context.Context, in *pb.RemoveTinyAppCollaboratorsRequest) (*pb.TinyAppAccess, error) {
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
+	logger.Infow("Received request to remove tiny app collaborators", "users", in.Users, "groups", in.Groups)
+
+	access, err := s.updateTinyAppAccess(ctx, in.AppId, func(access *v1alpha1.AppAccess) {
+		access.Owners = slices.DeleteFunc(access.Owners, func(user string) bool {
+			return slices.Contains(in.Users, user)
+		})
+		access.Groups = slices.DeleteFunc(access.Groups, func(group string) bool {
+			return slices.Contains(in.Groups, group)
+		})
+	})
+	if err != nil {
+		logger.Errorw("Failed to remove tiny app collaborators", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully removed tiny app collaborators")
+
+	return util.ConvertToProtoAccess(access), nil
+}
+
+// updateTinyAppAccess applies change to access of app, if caller is allowed to manage app.
+func (s *Server) updateTinyAppAccess(ctx context.Context, appId string, change func(access *v1alpha1.AppAccess)) (*v1alpha1.AppAccess, error) {
+	if appId == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty app id")
+	}
+
+	var updatedApp *v1alpha1.TinyApp
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tinyApp, err := s.getTinyApp(ctx, appId)
+		if err != nil {
+			return err
+		}
+
+		if err := s.authorizeAppAccess(ctx, tinyApp); err != nil {
+			return err
+		}
+
+		if tinyApp.Spec.Access == nil {
+			tinyApp.Spec.Access = &v1alpha1.AppAccess{}
+		}
+		change(tinyApp.Spec.Access)
+
+		updatedApp, err = s.tinyAppClient.TinymultiverseV1alpha1().TinyApps(s.env.TinyAppNamespace).Update(ctx, tinyApp, v1.UpdateOptions{FieldManager: util.FieldManager})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedApp.Spec.Access, nil
+}
+
+// authorizeAppAccess returns PermissionDenied error unless caller is allowed to manage app.
+// Everyone is allowed when authentication is disabled.
+func (s *Server) authorizeAppAccess(ctx context.Context, app *v1alpha1.TinyApp) error {
+	if !s.env.AuthEnabled {
+		return nil
+	}
+
+	identity := auth.IdentityFromContext(ctx)
+	if identity == nil {
+		return status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	if s.isAdmin(identity) || app.Spec.Access.IsOwner(identity.Username, identity.Groups) {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "%s is not allowed to manage app %s", identity.Username, app.Name)
+}
+
+func (s *Server) isAdmin(identity *auth.Identity) bool {
+	if slices.Contains(s.env.AdminUsers, identity.Username) {
+		return true
+	}
+
+	for _, group := range identity.Groups {
+		if slices.Contains(s.env.AdminGroups, group) {
+			return true
+		}
+	}
+
+	return false
+}
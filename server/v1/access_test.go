@@ -0,0 +1,84 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/util/auth"
+	"google.golang.org/grpc/codes"
+)
+
+func TestIsAdminStaticTokenGroups(t *testing.T) {
+	tokensFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	err := os.WriteFile(tokensFile, []byte(`
+- token: ops-token
+  username: ops-bot
+  groups: [platform-admins]
+- token: admin-token
+  username: admin-bot
+- token: ci-token
+  username: ci-bot
+  groups: [ci]
+`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write static tokens file: %v", err)
+	}
+
+	authenticator, err := auth.NewStaticTokenAuthenticatorFromFile(tokensFile)
+	if err != nil {
+		t.Fatalf("failed to load static tokens: %v", err)
+	}
+
+	server := newTestServer()
+	server.env.AuthEnabled = true
+	server.env.AdminUsers = []string{"admin-bot"}
+	server.env.AdminGroups = []string{"platform-admins"}
+
+	tests := []struct {
+		token     string
+		wantAdmin bool
+	}{
+		{token: "ops-token", wantAdmin: true},
+		{token: "admin-token", wantAdmin: true},
+		{token: "ci-token", wantAdmin: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.token, func(t *testing.T) {
+			identity, err := authenticator.Authenticate(context.Background(), test.token)
+			if err != nil {
+				t.Fatalf("failed to authenticate: %v", err)
+			}
+
+			if isAdmin := server.isAdmin(identity); isAdmin != test.wantAdmin {
+				t.Errorf("got admin %t, want %t", isAdmin, test.wantAdmin)
+			}
+
+			err = server.authorizeAdmin(auth.WithIdentity(context.Background(), identity))
+			if test.wantAdmin && err != nil {
+				t.Errorf("got error %v for admin", err)
+			}
+			if !test.wantAdmin {
+				assertCode(t, err, codes.PermissionDenied)
+			}
+		})
+	}
+}
@@ -19,17 +19,24 @@ package v1
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 
 	"github.com/pkg/errors"
 	controllerutil "github.com/tinymultiverse/tinyapp/controller/util"
 	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/validation"
 	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
 	"github.com/tinymultiverse/tinyapp/server/util"
 	globalutil "github.com/tinymultiverse/tinyapp/util"
+	"github.com/tinymultiverse/tinyapp/util/auth"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
@@ -40,9 +47,7 @@ func (s *Server) CreateTinyApp(ctx context.Context, in *pb.CreateTinyAppRequest)
 		return nil, errors.New("empty input")
 	}
 
-	// TODO more input validation
-
-	logger := zap.S().With("appName", in.AppDetail.Name, "appType", in.AppDetail.AppType.String())
+	logger := loggerFromContext(ctx).With("appName", in.AppDetail.Name, "appType", in.AppDetail.AppType.String())
 	logger.Info("Received request to create tiny app")
 
 	tinyApp, err := s.deployTinyApp(ctx, in.AppDetail)
@@ -64,30 +69,96 @@ func (s *Server) CreateTinyApp(ctx context.Context, in *pb.CreateTinyAppRequest)
 			Id:                tinyApp.Name,
 			AppUrl:            appUrl,
 			CreationTimeStamp: tinyApp.CreationTimestamp.Time.String(),
-			AppImage:          in.AppDetail.Image,
+			AppImage:          tinyApp.Spec.Image,
+			GitCommit:         util.ConvertToProtoGitCommit(tinyApp),
+			ImageDigest:       util.ConvertToProtoImageDigest(tinyApp),
 		},
 	}, nil
 }
 
+func (s *Server) GetTinyApp(ctx context.Context, in *pb.GetTinyAppRequest) (*pb.GetTinyAppResponse, error) {
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
+	logger.Info("Received request to get tiny app")
+
+	if in.AppId == "" {
+		logger.Info("Empty app id")
+		return nil, status.Error(codes.InvalidArgument, "empty app id")
+	}
+
+	tinyApp, err := s.getTinyApp(ctx, in.AppId)
+	if err != nil {
+		logger.Errorw("Failed to get TinyApp", "error", err)
+		return nil, err
+	}
+
+	protoTinyApp, err := util.ConvertToProtoTinyApp(tinyApp)
+	if err != nil {
+		logger.Errorw("Error while converting to proto TinyApp", "error", err)
+		return nil, err
+	}
+
+	pods, err := s.getTinyAppPods(ctx, tinyApp)
+	if err != nil {
+		logger.Errorw("Failed to get TinyApp pods", "error", err)
+		return nil, err
+	}
+
+	endpoint, err := s.getTinyAppEndpoint(ctx, tinyApp, protoTinyApp.AppRelease.AppUrl)
+	if err != nil {
+		logger.Errorw("Failed to get TinyApp endpoint", "error", err)
+		return nil, err
+	}
+
+	res := &pb.GetTinyAppResponse{
+		App:      protoTinyApp,
+		Pods:     pods,
+		Endpoint: endpoint,
+	}
+
+	// Report what is deployed from the newest pod, preferring one that is serving traffic
+	if deployedPod := getDeployedPod(pods); deployedPod != nil {
+		res.ImageDigest = deployedPod.ImageDigest
+		res.GitCommit = deployedPod.GitCommit
+	}
+
+	logger.Info("Successfully got tiny app")
+
+	return res, nil
+}
+
 func (s *Server) ListTinyApps(ctx context.Context, req *pb.ListTinyAppsRequest) (*pb.ListTinyAppsResponse, error) {
-	logger := zap.S()
+	logger := loggerFromContext(ctx)
 	logger.Info("Received request to list tiny apps")
 
-	tinyApps, err := s.tinyAppClient.
-		TinymultiverseV1alpha1().
-		TinyApps(s.env.TinyAppNamespace).
-		List(context.Background(), v1.ListOptions{})
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size must not be negative")
+	}
+
+	if req.Mine && auth.IdentityFromContext(ctx) == nil {
+		return nil, status.Error(codes.InvalidArgument, "listing own apps requires authentication")
+	}
+
+	var tinyApps []*v1alpha1.TinyApp
+	var nextPageToken string
+	var err error
+	if req.SortOrder != pb.ListSortOrder_LIST_SORT_ORDER_UNSPECIFIED {
+		tinyApps, nextPageToken, err = s.listSortedTinyApps(ctx, req)
+	} else {
+		tinyApps, nextPageToken, err = s.listTinyApps(ctx, req)
+	}
 	if err != nil {
 		logger.Errorw("Failed to fetch TinyApps from K8s", "error", err)
 		return nil, err
 	}
 
-	apps := make([]*pb.TinyApp, 0)
+	apps := make([]*pb.TinyApp, 0, len(tinyApps))
+	var warnings []*pb.ListTinyAppsWarning
 
-	for _, tinyApp := range tinyApps.Items {
-		protoTinyApp, err := util.ConvertToProtoTinyApp(&tinyApp)
+	for _, tinyApp := range tinyApps {
+		protoTinyApp, err := util.ConvertToProtoTinyApp(tinyApp)
 		if err != nil {
-			logger.Errorw("Error while converting to proto TinyApp", "error", err)
+			logger.Errorw("Error while converting to proto TinyApp", "appId", tinyApp.Name, "error", err)
+			warnings = append(warnings, &pb.ListTinyAppsWarning{AppId: tinyApp.Name, Message: err.Error()})
 			continue
 		}
 
@@ -96,11 +167,15 @@ func (s *Server) ListTinyApps(ctx context.Context, req *pb.ListTinyAppsRequest)
 
 	logger.Infof("Listed %d tiny apps", len(apps))
 
-	return &pb.ListTinyAppsResponse{Apps: apps}, nil
+	return &pb.ListTinyAppsResponse{
+		Apps:          apps,
+		NextPageToken: nextPageToken,
+		Warnings:      warnings,
+	}, nil
 }
 
 func (s *Server) UpdateTinyApp(ctx context.Context, in *pb.UpdateTinyAppRequest) (*pb.UpdateTinyAppResponse, error) {
-	logger := zap.S().With("appId", in.AppId)
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
 	logger.Info("Received request to update tiny app")
 
 	if in.AppId == "" {
@@ -127,14 +202,25 @@ func (s *Server) UpdateTinyApp(ctx context.Context, in *pb.UpdateTinyAppRequest)
 	}
 
 	existingApp := appsList.Items[0]
+	if err := s.authorizeAppAccess(ctx, &existingApp); err != nil {
+		logger.Errorw("Not allowed to update TinyApp", "error", err)
+		return nil, err
+	}
+
 	newApp, err := util.ConvertToK8sTinyApp(in.AppDetail, existingApp.Name, s.env)
 	if err != nil {
 		return nil, err
 	}
 
-	// Override existing app object's spec with new app spec
+	// Override existing app object's spec with new app spec. Access is managed through collaborator RPCs.
+	newApp.Spec.Access = existingApp.Spec.Access
 	existingApp.Spec = newApp.Spec
 
+	if err := s.validateTinyApp(ctx, &existingApp); err != nil {
+		logger.Infow("Invalid TinyApp update", "error", err)
+		return nil, err
+	}
+
 	if err := s.deploySecret(ctx, &existingApp.Name, in.AppDetail); err != nil {
 		return nil, err
 	}
@@ -159,27 +245,36 @@ func (s *Server) UpdateTinyApp(ctx context.Context, in *pb.UpdateTinyAppRequest)
 			AppUrl:            appUrl,
 			CreationTimeStamp: updatedApp.CreationTimestamp.Time.String(),
 			AppImage:          updatedApp.Spec.Image,
+			GitCommit:         util.ConvertToProtoGitCommit(updatedApp),
+			ImageDigest:       util.ConvertToProtoImageDigest(updatedApp),
 		},
 	}, nil
 }
 
 func (s *Server) DeleteTinyApp(ctx context.Context, in *pb.DeleteTinyAppRequest) (*emptypb.Empty, error) {
-	logger := zap.S().With("appId", in.AppId)
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
 	logger.Info("Received request to delete TinyApp")
 
+	tinyApp, err := s.getTinyApp(ctx, in.AppId)
+	if err != nil {
+		logger.Errorw("Failed to get TinyApp", "error", err)
+		return nil, err
+	}
+
+	if err := s.authorizeAppAccess(ctx, tinyApp); err != nil {
+		logger.Errorw("Not allowed to delete TinyApp", "error", err)
+		return nil, err
+	}
+
 	// Execute deletion
 	dp := v1.DeletePropagationBackground
-	err := s.tinyAppClient.TinymultiverseV1alpha1().TinyApps(s.env.TinyAppNamespace).Delete(ctx, in.AppId,
+	err = s.tinyAppClient.TinymultiverseV1alpha1().TinyApps(s.env.TinyAppNamespace).Delete(ctx, in.AppId,
 		v1.DeleteOptions{PropagationPolicy: &dp})
 	if err != nil {
 		logger.Errorw("Failed to delete TinyApp", "error", err)
 		return nil, err
 	}
-
-	err = s.k8sClient.CoreV1().Secrets(s.env.TinyAppNamespace).Delete(ctx, in.AppId, v1.DeleteOptions{})
-	if err != nil && !k8sErrors.IsNotFound(err) {
-		logger.Errorw("Failed to delete git token secret", "error", err)
-	}
+	// Git token secret is deleted by controller before TinyApp is gone
 
 	zap.S().Infof("Successfully deleted tiny app")
 
@@ -189,7 +284,7 @@ func (s *Server) DeleteTinyApp(ctx context.Context, in *pb.DeleteTinyAppRequest)
 // deployTinyApp converts given TinyAppDetail into k8s TinyApp object and deploys it.
 // Returns TinyApp k8s object if deployment is successful.
 func (s *Server) deployTinyApp(ctx context.Context, appDetail *pb.TinyAppDetail) (*v1alpha1.TinyApp, error) {
-	logger := zap.S().With("appName", appDetail.Name, "appType", appDetail.AppType.String())
+	logger := loggerFromContext(ctx).With("appName", appDetail.Name, "appType", appDetail.AppType.String())
 
 	appObjName := globalutil.GenerateTinyAppObjName()
 	newApp, err := util.ConvertToK8sTinyApp(appDetail, appObjName, s.env)
@@ -197,6 +292,15 @@ func (s *Server) deployTinyApp(ctx context.Context, appDetail *pb.TinyAppDetail)
 		return nil, err
 	}
 
+	if identity := auth.IdentityFromContext(ctx); identity != nil {
+		newApp.Spec.Access = &v1alpha1.AppAccess{Creator: identity.Username}
+	}
+
+	if err := s.validateTinyApp(ctx, newApp); err != nil {
+		logger.Infow("Invalid TinyApp", "error", err)
+		return nil, err
+	}
+
 	if err := s.deploySecret(ctx, &newApp.Name, appDetail); err != nil {
 		return nil, err
 	}
@@ -210,6 +314,48 @@ func (s *Server) deployTinyApp(ctx context.Context, appDetail *pb.TinyAppDetail)
 	return tinyApp, nil
 }
 
+// validateTinyApp returns InvalidArgument error listing everything that is wrong with app spec.
+func (s *Server) validateTinyApp(ctx context.Context, app *v1alpha1.TinyApp) error {
+	appTypes, err := s.getSupportedAppTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	errs := validation.ValidateTinyApp(app, appTypes)
+	errs = append(errs, validation.ValidateResourceMaximums(&app.Spec, field.NewPath("spec"), s.env.MaxAppCPU, s.env.MaxAppMemory)...)
+	if len(errs) > 0 {
+		return status.Error(codes.InvalidArgument, errs.ToAggregate().Error())
+	}
+	return nil
+}
+
+// getSupportedAppTypes returns app types that controller has runtimes for: built-in ones, and the ones operators
+// added in app runtimes ConfigMap.
+func (s *Server) getSupportedAppTypes(ctx context.Context) ([]string, error) {
+	appTypes := append([]string{}, validation.BuiltInAppTypes...)
+	if s.env.AppRuntimesConfigMap == "" {
+		return appTypes, nil
+	}
+
+	configMap, err := s.k8sClient.CoreV1().ConfigMaps(s.env.TinyAppNamespace).Get(ctx, s.env.AppRuntimesConfigMap, v1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return appTypes, nil
+		}
+		return nil, errors.WithMessage(err, "failed to get app runtimes ConfigMap")
+	}
+
+	var extraAppTypes []string
+	for appType := range configMap.Data {
+		if !slices.Contains(appTypes, appType) {
+			extraAppTypes = append(extraAppTypes, appType)
+		}
+	}
+	sort.Strings(extraAppTypes)
+
+	return append(appTypes, extraAppTypes...), nil
+}
+
 // deploySecret deploys k8s secret containing git token if applicable.
 func (s *Server) deploySecret(ctx context.Context, name *string, appDetail *pb.TinyAppDetail) error {
 	kind := "Secret"
@@ -223,6 +369,11 @@ func (s *Server) deploySecret(ctx context.Context, name *string, appDetail *pb.T
 			ObjectMetaApplyConfiguration: &applymetav1.ObjectMetaApplyConfiguration{
 				Name:      name,
 				Namespace: &s.env.TinyAppNamespace,
+				// Controller deletes secrets labelled with app name along with app
+				Labels: map[string]string{
+					globalutil.K8sNameLabel:   *name,
+					globalutil.K8sPartOfLabel: globalutil.TinyAppPartOfLabel,
+				},
 			},
 			StringData: map[string]string{
 				controllerutil.GitTokenSecretKey: appDetail.GitConfig.Token,
@@ -238,9 +389,12 @@ func (s *Server) deploySecret(ctx context.Context, name *string, appDetail *pb.T
 	return nil
 }
 
-func (s *Server) GetTinyApp(ctx context.Context, appId string) (*v1alpha1.TinyApp, error) {
+func (s *Server) getTinyApp(ctx context.Context, appId string) (*v1alpha1.TinyApp, error) {
 	tinyApp, err := s.tinyAppClient.TinymultiverseV1alpha1().TinyApps(s.env.TinyAppNamespace).Get(ctx, appId, v1.GetOptions{})
 	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "TinyApp %s not found", appId)
+		}
 		return nil, errors.Wrap(err, fmt.Sprintf("failed to load TinyApp %s", appId))
 	}
 	return tinyApp, nil
@@ -0,0 +1,331 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	controllerutil "github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// newTestAppDetail returns detail of a valid app, changed by given function.
+func newTestAppDetail(change func(detail *pb.TinyAppDetail)) *pb.TinyAppDetail {
+	detail := &pb.TinyAppDetail{
+		Name:         "App",
+		AppType:      pb.AppType_APP_TYPE_STREAM_LIT,
+		SourceType:   pb.SourceType_SOURCE_TYPE_IMAGE,
+		Image:        "registry.example.com/app:1.0",
+		MainFilePath: "app.py",
+	}
+	if change != nil {
+		change(detail)
+	}
+	return detail
+}
+
+// Invalid apps are rejected by server, the same as by validating webhook, instead of failing once controller reconciles them
+var invalidAppDetailTests = []struct {
+	name   string
+	change func(detail *pb.TinyAppDetail)
+}{
+	{name: "missing main file", change: func(detail *pb.TinyAppDetail) { detail.MainFilePath = "" }},
+	{name: "request above limit", change: func(detail *pb.TinyAppDetail) {
+		detail.Resources = &pb.Resources{CpuRequest: "2", CpuLimit: "1"}
+	}},
+	{name: "cpu above maximum", change: func(detail *pb.TinyAppDetail) {
+		detail.Resources = &pb.Resources{CpuLimit: "4"}
+	}},
+	{name: "memory above maximum", change: func(detail *pb.TinyAppDetail) {
+		detail.Resources = &pb.Resources{MemoryRequest: "16Gi"}
+	}},
+}
+
+func newTestAppServer(apps ...*v1alpha1.TinyApp) *Server {
+	server := newTestServer(apps...)
+	server.env.MaxAppCPU = "2"
+	server.env.MaxAppMemory = "8Gi"
+	return server
+}
+
+func TestCreateTinyApp(t *testing.T) {
+	server := newTestAppServer()
+
+	detail := newTestAppDetail(func(detail *pb.TinyAppDetail) {
+		detail.Resources = &pb.Resources{CpuLimit: "2", MemoryLimit: "8Gi"}
+	})
+	if _, err := server.CreateTinyApp(context.Background(), &pb.CreateTinyAppRequest{AppDetail: detail}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestCreateTinyAppInvalid(t *testing.T) {
+	for _, test := range invalidAppDetailTests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestAppServer()
+
+			_, err := server.CreateTinyApp(context.Background(), &pb.CreateTinyAppRequest{AppDetail: newTestAppDetail(test.change)})
+			assertCode(t, err, codes.InvalidArgument)
+
+			apps, err := server.tinyAppClient.TinymultiverseV1alpha1().TinyApps(testNamespace).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to list apps: %s", err)
+			}
+			if len(apps.Items) > 0 {
+				t.Errorf("invalid app was created")
+			}
+		})
+	}
+}
+
+func TestUpdateTinyAppInvalid(t *testing.T) {
+	for _, test := range invalidAppDetailTests {
+		t.Run(test.name, func(t *testing.T) {
+			app := newTestListApp("app", "App", metav1.Now().Time)
+			app.Labels = map[string]string{globalutil.K8sNameLabel: app.Name}
+			server := newTestAppServer(app)
+
+			_, err := server.UpdateTinyApp(context.Background(), &pb.UpdateTinyAppRequest{AppId: app.Name, AppDetail: newTestAppDetail(test.change)})
+			assertCode(t, err, codes.InvalidArgument)
+
+			current, err := server.tinyAppClient.TinymultiverseV1alpha1().TinyApps(testNamespace).Get(context.Background(), app.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get app: %s", err)
+			}
+			if current.Spec.SourceType != v1alpha1.SourceTypeFileSystem {
+				t.Errorf("invalid update was applied")
+			}
+		})
+	}
+}
+
+func TestCreateTinyAppScaling(t *testing.T) {
+	tests := []struct {
+		name            string
+		change          func(detail *pb.TinyAppDetail)
+		wantCode        codes.Code
+		wantReplicas    *int32
+		wantMaxReplicas int32
+	}{
+		{
+			name:         "replicas",
+			change:       func(detail *pb.TinyAppDetail) { detail.Replicas = pointer.Int32(3) },
+			wantReplicas: pointer.Int32(3),
+		},
+		{
+			name:         "zero replicas",
+			change:       func(detail *pb.TinyAppDetail) { detail.Replicas = pointer.Int32(0) },
+			wantReplicas: pointer.Int32(0),
+		},
+		{
+			name: "autoscaling",
+			change: func(detail *pb.TinyAppDetail) {
+				detail.Autoscaling = &pb.Autoscaling{MinReplicas: pointer.Int32(2), MaxReplicas: 5}
+			},
+			wantMaxReplicas: 5,
+		},
+		{
+			name:     "negative replicas",
+			change:   func(detail *pb.TinyAppDetail) { detail.Replicas = pointer.Int32(-1) },
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "autoscaling min above max",
+			change: func(detail *pb.TinyAppDetail) {
+				detail.Autoscaling = &pb.Autoscaling{MinReplicas: pointer.Int32(3), MaxReplicas: 2}
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "autoscaling target above 100%",
+			change: func(detail *pb.TinyAppDetail) {
+				detail.Autoscaling = &pb.Autoscaling{MaxReplicas: 2, TargetCpuUtilizationPercentage: pointer.Int32(150)}
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestAppServer()
+
+			_, err := server.CreateTinyApp(context.Background(), &pb.CreateTinyAppRequest{AppDetail: newTestAppDetail(test.change)})
+			assertCode(t, err, test.wantCode)
+			if test.wantCode != codes.OK {
+				return
+			}
+
+			apps, err := server.tinyAppClient.TinymultiverseV1alpha1().TinyApps(testNamespace).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to list apps: %s", err)
+			}
+			if len(apps.Items) != 1 {
+				t.Fatalf("got %d apps, want 1", len(apps.Items))
+			}
+
+			spec := apps.Items[0].Spec
+			if test.wantReplicas != nil && (spec.Replicas == nil || *spec.Replicas != *test.wantReplicas) {
+				t.Errorf("got replicas %v, want %d", spec.Replicas, *test.wantReplicas)
+			}
+			if test.wantMaxReplicas != 0 && (spec.Autoscaling == nil || spec.Autoscaling.MaxReplicas != test.wantMaxReplicas) {
+				t.Errorf("got autoscaling %v, want max replicas %d", spec.Autoscaling, test.wantMaxReplicas)
+			}
+		})
+	}
+}
+
+// newTestGetApp returns app abc that has not been deployed yet.
+func newTestGetApp() *v1alpha1.TinyApp {
+	return &v1alpha1.TinyApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: testNamespace},
+		Spec: v1alpha1.TinyAppSpec{
+			DisplayName: "App",
+			AppType:     v1alpha1.AppTypeStreamlit,
+			SourceType:  v1alpha1.SourceTypeFileSystem,
+		},
+	}
+}
+
+// newTestGetPod returns pod of app abc created at given time, whose app container runs image with given digest.
+func newTestGetPod(name string, created time.Time, ready bool, digest, commit string) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         testNamespace,
+			Labels:            map[string]string{globalutil.K8sNameLabel: "abc"},
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:         controllerutil.GitRevisionContainerName,
+				RestartCount: 1,
+				State:        corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: commit + "\n"}},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: controllerutil.AppContainerName, RestartCount: 2, ImageID: "docker.io/library/python@" + digest},
+				{Name: controllerutil.GatewayContainerName, RestartCount: 3},
+			},
+		},
+	}
+}
+
+func TestGetTinyApp(t *testing.T) {
+	now := time.Now()
+	app := newTestGetApp()
+	app.Labels = map[string]string{globalutil.K8sNameLabel: "abc"}
+	app.Status.Phase = v1alpha1.TinyAppProgressing
+	app.Status.SetCondition(v1alpha1.Available, apiextensionsv1.ConditionTrue, v1alpha1.ReasonMinimumReplicasAvailable, "")
+	app.Status.SetCondition(v1alpha1.Progressing, apiextensionsv1.ConditionTrue, v1alpha1.ReasonRolloutInProgress, "")
+	server := newTestServer(app)
+
+	// Newest pod is still starting, so the ready one is reported as deployed
+	otherApp := newTestGetPod("def-1", now, true, "sha256:def", "def")
+	otherApp.Labels[globalutil.K8sNameLabel] = "def"
+	objects := []*corev1.Pod{
+		newTestGetPod("abc-1", now.Add(-time.Hour), true, "sha256:old", "old"),
+		newTestGetPod("abc-2", now, false, "sha256:new", "new"),
+		otherApp,
+	}
+	for _, pod := range objects {
+		if _, err := server.k8sClient.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: testNamespace},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses:         []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+			NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.2"}},
+		}},
+	}
+	if _, err := server.k8sClient.CoreV1().Endpoints(testNamespace).Create(context.Background(), endpoints, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := server.GetTinyApp(context.Background(), &pb.GetTinyAppRequest{AppId: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.App.AppRelease.Id != "abc" || res.App.Status.Phase != string(v1alpha1.TinyAppProgressing) {
+		t.Errorf("got app %s in phase %s, want abc in phase %s", res.App.AppRelease.Id, res.App.Status.Phase, v1alpha1.TinyAppProgressing)
+	}
+	if len(res.App.Status.Conditions) != 2 || res.App.Status.Conditions[1].Reason != v1alpha1.ReasonRolloutInProgress {
+		t.Errorf("got conditions %v, want app conditions", res.App.Status.Conditions)
+	}
+
+	if len(res.Pods) != 2 || res.Pods[0].Name != "abc-2" || res.Pods[1].Name != "abc-1" {
+		t.Fatalf("got pods %v, want pods of app newest first", res.Pods)
+	}
+	pod := res.Pods[1]
+	if !pod.Ready || pod.RestartCount != 6 || pod.ImageDigest != "sha256:old" || pod.GitCommit != "old" {
+		t.Errorf("got pod %v, want ready pod with 6 restarts, digest sha256:old and commit old", pod)
+	}
+	if res.ImageDigest != "sha256:old" || res.GitCommit != "old" {
+		t.Errorf("got deployed digest %s and commit %s, want those of ready pod", res.ImageDigest, res.GitCommit)
+	}
+
+	wantServiceURL := "http://abc.tinyapp.svc:" + fmt.Sprint(globalutil.DefaultGatewayPort)
+	if res.Endpoint.ServiceUrl != wantServiceURL || res.Endpoint.AppUrl != res.App.AppRelease.AppUrl {
+		t.Errorf("got endpoint urls %s & %s, want %s & %s", res.Endpoint.ServiceUrl, res.Endpoint.AppUrl, wantServiceURL, res.App.AppRelease.AppUrl)
+	}
+	if res.Endpoint.ReadyAddresses != 1 || res.Endpoint.NotReadyAddresses != 1 {
+		t.Errorf("got %d ready & %d not ready addresses, want 1 & 1", res.Endpoint.ReadyAddresses, res.Endpoint.NotReadyAddresses)
+	}
+}
+
+// Apps that have not been deployed yet have no pods or endpoints.
+func TestGetTinyAppNotDeployed(t *testing.T) {
+	server := newTestServer(newTestGetApp())
+
+	res, err := server.GetTinyApp(context.Background(), &pb.GetTinyAppRequest{AppId: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Pods) != 0 || res.ImageDigest != "" || res.GitCommit != "" {
+		t.Errorf("got pods %v, digest %q and commit %q, want none", res.Pods, res.ImageDigest, res.GitCommit)
+	}
+	if res.Endpoint.ReadyAddresses != 0 || res.Endpoint.ServiceUrl == "" {
+		t.Errorf("got endpoint %v, want service url without addresses", res.Endpoint)
+	}
+}
+
+func TestGetTinyAppInvalid(t *testing.T) {
+	server := newTestServer()
+
+	_, err := server.GetTinyApp(context.Background(), &pb.GetTinyAppRequest{})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = server.GetTinyApp(context.Background(), &pb.GetTinyAppRequest{AppId: "abc"})
+	assertCode(t, err, codes.NotFound)
+}
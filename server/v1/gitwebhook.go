@@ -0,0 +1,231 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"github.com/tinymultiverse/tinyapp/server/gitwebhook"
+	"github.com/tinymultiverse/tinyapp/server/util"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	"github.com/tinymultiverse/tinyapp/util/auth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Git hosts don't send payloads larger than this
+const maxGitWebhookPayloadSize = 25 << 20
+
+// gitWebhookResponse is returned to git hosts, which show it in their delivery history.
+type gitWebhookResponse struct {
+	TriggeredApps []string `json:"triggeredApps"`
+}
+
+// HandleGitWebhook redeploys apps that track a branch or tag which was pushed to.
+// Webhooks must be signed with the shared git webhook secret.
+func (s *Server) HandleGitWebhook(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGitWebhookPayloadSize))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+
+	push, err := gitwebhook.ParsePush(r.Header, body, s.env.GitWebhookSecret)
+	switch {
+	case errors.Is(err, gitwebhook.ErrIgnoredEvent):
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case errors.Is(err, gitwebhook.ErrInvalidSignature):
+		// Unsigned webhooks are only counted, since anyone who can reach the endpoint can send them
+		rejected := s.gitDeliveries.AddRejected()
+		zap.S().Debugw("Rejected git webhook with invalid signature", "rejectedTotal", rejected)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	delivery := gitwebhook.Delivery{
+		ID:           push.DeliveryID,
+		Provider:     push.Provider,
+		RepoURL:      push.RepoURL,
+		Refs:         push.Refs,
+		ReceivedTime: time.Now(),
+	}
+	logger := zap.S().With("provider", push.Provider, "delivery", push.DeliveryID, "repo", push.RepoURL)
+	logger.Infow("Received git push webhook", "refs", push.Refs)
+
+	delivery.TriggeredApps, err = s.redeployPushedApps(r.Context(), push)
+	if err != nil {
+		logger.Errorw("Failed to redeploy apps for git push", "error", err)
+		delivery.Error = err.Error()
+	}
+	s.gitDeliveries.Add(delivery)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Infow("Redeployed apps for git push", "apps", delivery.TriggeredApps)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(gitWebhookResponse{TriggeredApps: delivery.TriggeredApps})
+}
+
+// redeployPushedApps restarts pods of apps whose git repo & ref match the push, so that they check out the new commit.
+// Returns names of apps that were redeployed, including those redeployed before an error.
+func (s *Server) redeployPushedApps(ctx context.Context, push *gitwebhook.Push) ([]string, error) {
+	repo := gitwebhook.NormalizeRepoURL(push.RepoURL)
+	if repo == "" || len(push.Refs) == 0 {
+		return nil, nil
+	}
+
+	appList, err := s.tinyAppClient.TinymultiverseV1alpha1().TinyApps(s.env.TinyAppNamespace).List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list TinyApps")
+	}
+
+	var triggeredApps []string
+	for i := range appList.Items {
+		app := &appList.Items[i]
+		if app.DeletionTimestamp != nil || !matchesPush(app, repo, push.Refs) {
+			continue
+		}
+
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`,
+			globalutil.AnnotationRestartedAt, time.Now().UTC().Format(time.RFC3339Nano))
+		_, err := s.tinyAppClient.TinymultiverseV1alpha1().TinyApps(s.env.TinyAppNamespace).
+			Patch(ctx, app.Name, types.MergePatchType, []byte(patch), v1.PatchOptions{FieldManager: util.FieldManager})
+		if err != nil {
+			return triggeredApps, errors.WithMessagef(err, "failed to redeploy TinyApp %s", app.Name)
+		}
+		triggeredApps = append(triggeredApps, app.Name)
+	}
+
+	return triggeredApps, nil
+}
+
+// matchesPush returns true if app source is the pushed repo, at one of the pushed refs.
+func matchesPush(app *v1alpha1.TinyApp, repo string, refs []gitwebhook.RefUpdate) bool {
+	gitConfig := app.Spec.GitConfig
+	if app.Spec.SourceType != v1alpha1.SourceTypeGit || gitConfig == nil {
+		return false
+	}
+
+	if gitwebhook.NormalizeRepoURL(gitConfig.GitUrl) != repo {
+		return false
+	}
+
+	var appRef string
+	switch gitConfig.GetRefType() {
+	case v1alpha1.GitRefTypeBranch:
+		appRef = "refs/heads/" + gitConfig.GitRef
+	case v1alpha1.GitRefTypeTag:
+		appRef = "refs/tags/" + gitConfig.GitRef
+	default:
+		// Commits never move
+		return false
+	}
+
+	return slices.ContainsFunc(refs, func(ref gitwebhook.RefUpdate) bool {
+		return ref.Ref == appRef
+	})
+}
+
+func (s *Server) ListGitWebhookDeliveries(ctx context.Context, in *pb.ListGitWebhookDeliveriesRequest) (*pb.ListGitWebhookDeliveriesResponse, error) {
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
+	logger.Info("Received request to list git webhook deliveries")
+
+	var filter func(delivery *gitwebhook.Delivery) bool
+	if in.AppId != "" {
+		tinyApp, err := s.getTinyApp(ctx, in.AppId)
+		if err != nil {
+			logger.Errorw("Failed to get TinyApp", "error", err)
+			return nil, err
+		}
+
+		if err := s.authorizeAppAccess(ctx, tinyApp); err != nil {
+			logger.Errorw("Not allowed to list git webhook deliveries of TinyApp", "error", err)
+			return nil, err
+		}
+
+		filter = func(delivery *gitwebhook.Delivery) bool {
+			return slices.Contains(delivery.TriggeredApps, in.AppId)
+		}
+	} else if err := s.authorizeAdmin(ctx); err != nil {
+		logger.Errorw("Not allowed to list all git webhook deliveries", "error", err)
+		return nil, err
+	}
+
+	res := &pb.ListGitWebhookDeliveriesResponse{}
+	if in.AppId == "" {
+		res.RejectedCount = s.gitDeliveries.Rejected()
+	}
+	for _, delivery := range s.gitDeliveries.List(filter) {
+		res.Deliveries = append(res.Deliveries, convertToProtoGitWebhookDelivery(delivery))
+	}
+
+	return res, nil
+}
+
+// authorizeAdmin returns PermissionDenied error unless caller is an admin.
+// Everyone is allowed when authentication is disabled.
+func (s *Server) authorizeAdmin(ctx context.Context) error {
+	if !s.env.AuthEnabled {
+		return nil
+	}
+
+	identity := auth.IdentityFromContext(ctx)
+	if identity == nil {
+		return status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	if !s.isAdmin(identity) {
+		return status.Errorf(codes.PermissionDenied, "%s is not an admin", identity.Username)
+	}
+
+	return nil
+}
+
+func convertToProtoGitWebhookDelivery(delivery gitwebhook.Delivery) *pb.GitWebhookDelivery {
+	protoDelivery := &pb.GitWebhookDelivery{
+		Id:              delivery.ID,
+		Provider:        delivery.Provider,
+		RepoUrl:         delivery.RepoURL,
+		ReceivedTime:    delivery.ReceivedTime.UTC().Format(time.RFC3339),
+		TriggeredAppIds: delivery.TriggeredApps,
+		Error:           delivery.Error,
+	}
+	for _, ref := range delivery.Refs {
+		protoDelivery.Refs = append(protoDelivery.Refs, &pb.GitRefUpdate{Ref: ref.Ref, Commit: ref.Commit})
+	}
+
+	return protoDelivery
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"github.com/tinymultiverse/tinyapp/server/util"
+	"github.com/tinymultiverse/tinyapp/util/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// listTinyApps returns apps matching request filters, up to requested page size.
+// Apps are fetched from Kubernetes in chunks until the page is full, so the returned continue token
+// always points right after the last app checked.
+func (s *Server) listTinyApps(ctx context.Context, req *pb.ListTinyAppsRequest) ([]*v1alpha1.TinyApp, string, error) {
+	listOptions := v1.ListOptions{
+		LabelSelector: req.LabelSelector,
+		Continue:      req.PageToken,
+	}
+	if req.AppId != "" {
+		listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", req.AppId).String()
+	}
+
+	var apps []*v1alpha1.TinyApp
+	for {
+		if req.PageSize > 0 {
+			// Never fetch more than what's left to fill the page
+			listOptions.Limit = int64(req.PageSize) - int64(len(apps))
+		}
+
+		tinyApps, err := s.tinyAppClient.TinymultiverseV1alpha1().TinyApps(s.env.TinyAppNamespace).List(ctx, listOptions)
+		if err != nil {
+			if k8sErrors.IsBadRequest(err) || k8sErrors.IsResourceExpired(err) {
+				return nil, "", status.Error(codes.InvalidArgument, err.Error())
+			}
+			return nil, "", err
+		}
+
+		for i := range tinyApps.Items {
+			if matchesListFilters(ctx, &tinyApps.Items[i], req) {
+				apps = append(apps, &tinyApps.Items[i])
+			}
+		}
+
+		listOptions.Continue = tinyApps.Continue
+		if listOptions.Continue == "" || (req.PageSize > 0 && len(apps) >= int(req.PageSize)) {
+			break
+		}
+	}
+
+	return apps, listOptions.Continue, nil
+}
+
+// sortedPageToken is the page token of sorted lists. Kubernetes can only page apps in name order, so sorted lists are
+// paged by offset into all matching apps instead.
+type sortedPageToken struct {
+	SortOrder pb.ListSortOrder `json:"sortOrder"`
+	Offset    int              `json:"offset"`
+}
+
+// listSortedTinyApps returns the page of apps matching request filters, sorted in request sort order. All matching
+// apps are fetched for each page, so pages may skip or repeat apps that are created or deleted in between.
+func (s *Server) listSortedTinyApps(ctx context.Context, req *pb.ListTinyAppsRequest) ([]*v1alpha1.TinyApp, string, error) {
+	offset := 0
+	if req.PageToken != "" {
+		token, err := decodeSortedPageToken(req.PageToken)
+		if err != nil || token.SortOrder != req.SortOrder || token.Offset < 0 {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token for sort order")
+		}
+		offset = token.Offset
+	}
+
+	allReq := proto.Clone(req).(*pb.ListTinyAppsRequest)
+	allReq.PageSize, allReq.PageToken = 0, ""
+	apps, _, err := s.listTinyApps(ctx, allReq)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sortTinyApps(apps, req.SortOrder)
+
+	if offset >= len(apps) {
+		return nil, "", nil
+	}
+	apps = apps[offset:]
+	if req.PageSize <= 0 || len(apps) <= int(req.PageSize) {
+		return apps, "", nil
+	}
+
+	nextPageToken, err := encodeSortedPageToken(sortedPageToken{SortOrder: req.SortOrder, Offset: offset + int(req.PageSize)})
+	if err != nil {
+		return nil, "", err
+	}
+	return apps[:req.PageSize], nextPageToken, nil
+}
+
+func encodeSortedPageToken(token sortedPageToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSortedPageToken(value string) (sortedPageToken, error) {
+	token := sortedPageToken{}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return token, err
+	}
+	err = json.Unmarshal(data, &token)
+	return token, err
+}
+
+// matchesListFilters returns true if app passes filters of list request that Kubernetes can't apply.
+func matchesListFilters(ctx context.Context, app *v1alpha1.TinyApp, req *pb.ListTinyAppsRequest) bool {
+	if req.Mine {
+		identity := auth.IdentityFromContext(ctx)
+		if identity == nil || !app.Spec.Access.IsOwner(identity.Username, identity.Groups) {
+			return false
+		}
+	}
+
+	if req.AppType != pb.AppType_APP_TYPE_UNKNOWN && util.ConvertToProtoAppType(app.Spec.AppType) != req.AppType {
+		return false
+	}
+
+	if req.SourceType != pb.SourceType_SOURCE_TYPE_UNKNOWN && util.ConvertToProtoSourceType(app.Spec.SourceType) != req.SourceType {
+		return false
+	}
+
+	if req.Phase != "" && !strings.EqualFold(string(app.Status.Phase), req.Phase) {
+		return false
+	}
+
+	if req.NameContains != "" && !strings.Contains(strings.ToLower(app.Spec.DisplayName), strings.ToLower(req.NameContains)) {
+		return false
+	}
+
+	return true
+}
+
+func sortTinyApps(apps []*v1alpha1.TinyApp, sortOrder pb.ListSortOrder) {
+	var less func(a, b *v1alpha1.TinyApp) bool
+	switch sortOrder {
+	case pb.ListSortOrder_LIST_SORT_ORDER_NAME_ASC:
+		less = func(a, b *v1alpha1.TinyApp) bool {
+			return strings.ToLower(a.Spec.DisplayName) < strings.ToLower(b.Spec.DisplayName)
+		}
+	case pb.ListSortOrder_LIST_SORT_ORDER_NAME_DESC:
+		less = func(a, b *v1alpha1.TinyApp) bool {
+			return strings.ToLower(a.Spec.DisplayName) > strings.ToLower(b.Spec.DisplayName)
+		}
+	case pb.ListSortOrder_LIST_SORT_ORDER_CREATED_ASC:
+		less = func(a, b *v1alpha1.TinyApp) bool {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+	case pb.ListSortOrder_LIST_SORT_ORDER_CREATED_DESC:
+		less = func(a, b *v1alpha1.TinyApp) bool {
+			return b.CreationTimestamp.Before(&a.CreationTimestamp)
+		}
+	default:
+		return
+	}
+
+	// Apps that are equal in sort order are kept in name order, so that pages of sorted lists don't overlap
+	sort.SliceStable(apps, func(i, j int) bool {
+		if less(apps[i], apps[j]) {
+			return true
+		}
+		return !less(apps[j], apps[i]) && apps[i].Name < apps[j].Name
+	})
+}
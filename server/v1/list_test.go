@@ -0,0 +1,176 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestListApp(name, displayName string, created time.Time) *v1alpha1.TinyApp {
+	return &v1alpha1.TinyApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         testNamespace,
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Spec: v1alpha1.TinyAppSpec{
+			DisplayName: displayName,
+			AppType:     v1alpha1.AppTypeStreamlit,
+			SourceType:  v1alpha1.SourceTypeFileSystem,
+		},
+	}
+}
+
+// Sorted lists are sorted across pages, not within each page.
+func TestListTinyAppsSortOrderPages(t *testing.T) {
+	now := time.Now()
+	server := newTestServer(
+		newTestListApp("app-a", "Echo", now),
+		newTestListApp("app-b", "delta", now),
+		newTestListApp("app-c", "Charlie", now),
+		newTestListApp("app-d", "bravo", now),
+		newTestListApp("app-e", "Alpha", now),
+	)
+
+	req := &pb.ListTinyAppsRequest{SortOrder: pb.ListSortOrder_LIST_SORT_ORDER_NAME_ASC, PageSize: 2}
+	var pages [][]string
+	for {
+		res, err := server.ListTinyApps(context.Background(), req)
+		if err != nil {
+			t.Fatalf("failed to list apps: %v", err)
+		}
+
+		var page []string
+		for _, app := range res.Apps {
+			page = append(page, app.AppRelease.Id)
+		}
+		pages = append(pages, page)
+
+		if res.NextPageToken == "" {
+			break
+		}
+		if len(pages) > 3 {
+			t.Fatalf("got more than 3 pages: %v", pages)
+		}
+		req.PageToken = res.NextPageToken
+	}
+
+	want := [][]string{{"app-e", "app-d"}, {"app-c", "app-b"}, {"app-a"}}
+	if !reflect.DeepEqual(pages, want) {
+		t.Errorf("got pages %v, want %v", pages, want)
+	}
+}
+
+// Apps created at the same time are paged in name order, so that pages don't overlap.
+func TestListTinyAppsSortOrderTies(t *testing.T) {
+	now := time.Now()
+	server := newTestServer(
+		newTestListApp("app-c", "App", now),
+		newTestListApp("app-a", "App", now),
+		newTestListApp("app-b", "App", now),
+	)
+
+	var got []string
+	req := &pb.ListTinyAppsRequest{SortOrder: pb.ListSortOrder_LIST_SORT_ORDER_CREATED_DESC, PageSize: 1}
+	for i := 0; i < 3; i++ {
+		res, err := server.ListTinyApps(context.Background(), req)
+		if err != nil {
+			t.Fatalf("failed to list apps: %v", err)
+		}
+		for _, app := range res.Apps {
+			got = append(got, app.AppRelease.Id)
+		}
+		req.PageToken = res.NextPageToken
+	}
+
+	if want := []string{"app-a", "app-b", "app-c"}; !reflect.DeepEqual(got, want) || req.PageToken != "" {
+		t.Errorf("got apps %v & next page token %q, want %v & none", got, req.PageToken, want)
+	}
+}
+
+func TestListTinyAppsSortOrderInvalidPageToken(t *testing.T) {
+	server := newTestServer(newTestListApp("app-a", "Alpha", time.Now()), newTestListApp("app-b", "Bravo", time.Now()))
+
+	res, err := server.ListTinyApps(context.Background(), &pb.ListTinyAppsRequest{SortOrder: pb.ListSortOrder_LIST_SORT_ORDER_NAME_ASC, PageSize: 1})
+	if err != nil {
+		t.Fatalf("failed to list apps: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.ListTinyAppsRequest
+	}{
+		{name: "not a page token", req: &pb.ListTinyAppsRequest{SortOrder: pb.ListSortOrder_LIST_SORT_ORDER_NAME_ASC, PageToken: "token"}},
+		{name: "other sort order", req: &pb.ListTinyAppsRequest{SortOrder: pb.ListSortOrder_LIST_SORT_ORDER_NAME_DESC, PageToken: res.NextPageToken}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := server.ListTinyApps(context.Background(), test.req)
+			assertCode(t, err, codes.InvalidArgument)
+		})
+	}
+}
+
+func TestListTinyAppsSortOrder(t *testing.T) {
+	now := time.Now()
+	server := newTestServer(
+		newTestListApp("app-a", "Charlie", now.Add(-time.Hour)),
+		newTestListApp("app-b", "alpha", now),
+		newTestListApp("app-c", "Bravo", now.Add(-2*time.Hour)),
+	)
+
+	tests := []struct {
+		sortOrder pb.ListSortOrder
+		want      []string
+	}{
+		{sortOrder: pb.ListSortOrder_LIST_SORT_ORDER_NAME_ASC, want: []string{"app-b", "app-c", "app-a"}},
+		{sortOrder: pb.ListSortOrder_LIST_SORT_ORDER_NAME_DESC, want: []string{"app-a", "app-c", "app-b"}},
+		{sortOrder: pb.ListSortOrder_LIST_SORT_ORDER_CREATED_ASC, want: []string{"app-c", "app-a", "app-b"}},
+		{sortOrder: pb.ListSortOrder_LIST_SORT_ORDER_CREATED_DESC, want: []string{"app-b", "app-a", "app-c"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.sortOrder.String(), func(t *testing.T) {
+			res, err := server.ListTinyApps(context.Background(), &pb.ListTinyAppsRequest{SortOrder: test.sortOrder})
+			if err != nil {
+				t.Fatalf("failed to list apps: %v", err)
+			}
+
+			var got []string
+			for _, app := range res.Apps {
+				got = append(got, app.AppRelease.Id)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got apps %v, want %v", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("got apps %v, want %v", got, test.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"unicode/utf8"
+
+	controllerutil "github.com/tinymultiverse/tinyapp/controller/util"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"github.com/tinymultiverse/tinyapp/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Max length of a single log line. Longer lines are split into several lines.
+const maxLogLineLength = 1024 * 1024
+
+// Containers whose logs can be streamed
+var logContainerNames = map[string]bool{
+	controllerutil.AppContainerName:         true,
+	controllerutil.GitSyncContainerName:     true,
+	controllerutil.GitRevisionContainerName: true,
+	controllerutil.GatewayContainerName:     true,
+}
+
+func (s *Server) StreamTinyAppLogs(in *pb.StreamTinyAppLogsRequest, stream pb.TinyAppServer_StreamTinyAppLogsServer) error {
+	logger := loggerFromContext(stream.Context()).With("appId", in.AppId, "container", in.Container)
+	logger.Info("Received request to stream app logs")
+
+	if in.AppId == "" {
+		return status.Error(codes.InvalidArgument, "empty app id")
+	}
+
+	container := in.Container
+	if container == "" {
+		container = controllerutil.AppContainerName
+	}
+	if !logContainerNames[container] {
+		return status.Errorf(codes.InvalidArgument, "unknown container %s", container)
+	}
+
+	ctx := stream.Context()
+
+	tinyApp, err := s.getTinyApp(ctx, in.AppId)
+	if err != nil {
+		logger.Errorf("failed to get app: %s", err)
+		return err
+	}
+
+	if err := s.authorizeAppAccess(ctx, tinyApp); err != nil {
+		logger.Errorf("not allowed to stream app logs: %s", err)
+		return err
+	}
+
+	podsList, err := s.k8sClient.CoreV1().Pods(s.env.TinyAppNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", util.K8sNameLabel, in.AppId),
+	})
+	if err != nil {
+		logger.Errorf("failed to get pods list: %s", err)
+		return err
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range podsList.Items {
+		if in.PodName != "" && pod.Name != in.PodName {
+			continue
+		}
+		if hasContainer(&pod, container) {
+			pods = append(pods, pod)
+		}
+	}
+
+	if len(pods) == 0 {
+		logger.Errorf("no pods found with container %s", container)
+		return status.Errorf(codes.NotFound, "no pods found for app id with container %s", container)
+	}
+
+	logOptions := &corev1.PodLogOptions{
+		Container:    container,
+		Follow:       in.Follow,
+		TailLines:    in.TailLines,
+		SinceSeconds: in.SinceSeconds,
+		Timestamps:   in.Timestamps,
+		Previous:     in.Previous,
+	}
+
+	// Lines of all pods are merged into one channel, since stream must only be sent to from one goroutine
+	lines := make(chan *pb.TinyAppLogLine)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, pod := range pods {
+		wg.Add(1)
+		go func(podName string) {
+			defer wg.Done()
+			s.streamPodLogs(ctx, podName, logOptions, lines)
+		}(pod.Name)
+	}
+
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	for line := range lines {
+		if err := stream.Send(line); err != nil {
+			logger.Errorf("failed to send log line: %s", err)
+			return err
+		}
+	}
+
+	logger.Info("Finished streaming app logs")
+
+	return nil
+}
+
+// streamPodLogs sends log lines of a pod container to lines channel until logs end or ctx is cancelled.
+// Failure to stream is sent as a line with error set.
+func (s *Server) streamPodLogs(ctx context.Context, podName string, logOptions *corev1.PodLogOptions, lines chan<- *pb.TinyAppLogLine) {
+	send := func(line *pb.TinyAppLogLine) bool {
+		select {
+		case lines <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	reader, err := s.k8sClient.CoreV1().Pods(s.env.TinyAppNamespace).GetLogs(podName, logOptions).Stream(ctx)
+	if err != nil {
+		send(&pb.TinyAppLogLine{PodName: podName, Container: logOptions.Container, Error: err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	scanner := newLogScanner(reader)
+	for scanner.Scan() {
+		if !send(&pb.TinyAppLogLine{PodName: podName, Container: logOptions.Container, Line: scanner.Text()}) {
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		send(&pb.TinyAppLogLine{PodName: podName, Container: logOptions.Container, Error: err.Error()})
+	}
+}
+
+// newLogScanner returns a scanner of log lines which splits lines longer than maxLogLineLength.
+func newLogScanner(reader io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(reader)
+	// Room for line break after a line of max length
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineLength+2)
+	scanner.Split(scanLogLines)
+	return scanner
+}
+
+// scanLogLines is bufio.ScanLines, except that first maxLogLineLength bytes of a longer line are returned as a line,
+// instead of failing the scanner with bufio.ErrTooLong. Multi-byte characters are not split.
+func scanLogLines(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if advance > 0 || token != nil || err != nil || len(data) <= maxLogLineLength+1 {
+		return advance, token, err
+	}
+
+	n := maxLogLineLength
+	if r, size := utf8.DecodeLastRune(data[:n]); r == utf8.RuneError && size <= 1 {
+		for i := n - 1; i > n-utf8.UTFMax && i > 0; i-- {
+			if utf8.RuneStart(data[i]) {
+				n = i
+				break
+			}
+		}
+	}
+	return n, data[:n], nil
+}
+
+func hasContainer(pod *corev1.Pod, name string) bool {
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == name {
+			return true
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
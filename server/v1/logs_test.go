@@ -0,0 +1,210 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	controllerutil "github.com/tinymultiverse/tinyapp/controller/util"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"github.com/tinymultiverse/tinyapp/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testLogStream collects log lines sent to it.
+type testLogStream struct {
+	grpc.ServerStream
+	lines []*pb.TinyAppLogLine
+}
+
+func (s *testLogStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testLogStream) Send(line *pb.TinyAppLogLine) error {
+	s.lines = append(s.lines, line)
+	return nil
+}
+
+// newTestPod returns pod of app with given containers.
+func newTestPod(name, appId string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{util.K8sNameLabel: appId},
+		},
+	}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+	}
+	return pod
+}
+
+func TestStreamTinyAppLogs(t *testing.T) {
+	server := newTestServer(newTestListApp("abc", "abc", time.Now()), newTestListApp("def", "def", time.Now()))
+	pods := []*corev1.Pod{
+		newTestPod("abc-1", "abc", controllerutil.AppContainerName, controllerutil.GatewayContainerName),
+		newTestPod("abc-2", "abc", controllerutil.AppContainerName),
+		newTestPod("def-1", "def", controllerutil.AppContainerName),
+	}
+	for _, pod := range pods {
+		if _, err := server.k8sClient.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		request  *pb.StreamTinyAppLogsRequest
+		code     codes.Code
+		wantPods []string
+	}{
+		{
+			name:     "all pods of app merged",
+			request:  &pb.StreamTinyAppLogsRequest{AppId: "abc"},
+			wantPods: []string{"abc-1", "abc-2"},
+		},
+		{
+			name:     "pod filter",
+			request:  &pb.StreamTinyAppLogsRequest{AppId: "abc", PodName: "abc-2"},
+			wantPods: []string{"abc-2"},
+		},
+		{
+			name:     "pods without container skipped",
+			request:  &pb.StreamTinyAppLogsRequest{AppId: "abc", Container: controllerutil.GatewayContainerName},
+			wantPods: []string{"abc-1"},
+		},
+		{
+			name:    "unknown pod",
+			request: &pb.StreamTinyAppLogsRequest{AppId: "abc", PodName: "def-1"},
+			code:    codes.NotFound,
+		},
+		{
+			name:    "allowed container missing from pods",
+			request: &pb.StreamTinyAppLogsRequest{AppId: "abc", Container: controllerutil.GitSyncContainerName},
+			code:    codes.NotFound,
+		},
+		{
+			name:    "container not allowed",
+			request: &pb.StreamTinyAppLogsRequest{AppId: "abc", Container: "istio-proxy"},
+			code:    codes.InvalidArgument,
+		},
+		{
+			name:    "empty app id",
+			request: &pb.StreamTinyAppLogsRequest{},
+			code:    codes.InvalidArgument,
+		},
+		{
+			name:    "unknown app",
+			request: &pb.StreamTinyAppLogsRequest{AppId: "xyz"},
+			code:    codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream := &testLogStream{}
+			err := server.StreamTinyAppLogs(test.request, stream)
+			assertCode(t, err, test.code)
+			if test.code != codes.OK {
+				return
+			}
+
+			container := test.request.Container
+			if container == "" {
+				container = controllerutil.AppContainerName
+			}
+			var gotPods []string
+			for _, line := range stream.lines {
+				if line.Error != "" {
+					t.Fatalf("got error line %v", line)
+				}
+				if line.Container != container || line.Line == "" {
+					t.Errorf("got line %v, want line of container %s", line, container)
+				}
+				gotPods = append(gotPods, line.PodName)
+			}
+			sort.Strings(gotPods)
+			if !reflect.DeepEqual(gotPods, test.wantPods) {
+				t.Errorf("got lines of pods %v, want %v", gotPods, test.wantPods)
+			}
+		})
+	}
+}
+
+func TestLogScanner(t *testing.T) {
+	long := strings.Repeat("a", maxLogLineLength)
+	// Multi-byte character crossing max line length
+	multiByte := strings.Repeat("a", maxLogLineLength-1) + "é"
+
+	tests := []struct {
+		name  string
+		logs  string
+		lines []string
+	}{
+		{
+			name:  "short lines",
+			logs:  "first\nsecond\r\nthird",
+			lines: []string{"first", "second", "third"},
+		},
+		{
+			name:  "line of max length",
+			logs:  long + "\nnext\n",
+			lines: []string{long, "next"},
+		},
+		{
+			name:  "long line split",
+			logs:  long + "bc\nnext\n",
+			lines: []string{long, "bc", "next"},
+		},
+		{
+			name:  "multi-byte character not split",
+			logs:  multiByte + "bc\n",
+			lines: []string{multiByte[:maxLogLineLength-1], "ébc"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scanner := newLogScanner(strings.NewReader(test.logs))
+			var lines []string
+			for scanner.Scan() {
+				lines = append(lines, scanner.Text())
+			}
+			if err := scanner.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if len(lines) != len(test.lines) {
+				t.Fatalf("got %d lines, want %d", len(lines), len(test.lines))
+			}
+			for i := range lines {
+				if lines[i] != test.lines[i] {
+					t.Errorf("line %d has length %d, want %d", i, len(lines[i]), len(test.lines[i]))
+				}
+			}
+		})
+	}
+}
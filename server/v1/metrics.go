@@ -19,47 +19,42 @@ package v1
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
 	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"github.com/tinymultiverse/tinyapp/server/metrics"
 	"github.com/tinymultiverse/tinyapp/util"
-	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	restclient "k8s.io/client-go/rest"
 )
 
-// PrometheusSecret is a struct that holds the Prometheus secrets.
-type prometheusSecret struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-}
-
-type PrometheusResults struct {
-	Data DataResults `json:"data"`
-}
-
-type DataResults struct {
-	Result []map[string]interface{} `json:"result"`
-}
-
-type UserNameData struct {
-	Username string `json:"username"`
-}
-
 func (s *Server) GetTinyAppLogs(ctx context.Context, in *pb.GetTinyAppLogsRequest) (*pb.GetTinyAppLogsResponse, error) {
-	logger := zap.S().With("appId", in.AppId)
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
 	logger.Info("Received request to get app logs")
 
 	appId := in.AppId
 
+	tinyApp, err := s.getTinyApp(ctx, appId)
+	if err != nil {
+		logger.Errorf("failed to get app: %s", err)
+		return nil, err
+	}
+
+	if err := s.authorizeAppAccess(ctx, tinyApp); err != nil {
+		logger.Errorf("not allowed to get app logs: %s", err)
+		return nil, err
+	}
+
 	podsList, err := s.k8sClient.CoreV1().Pods(s.env.TinyAppNamespace).List(context.Background(), metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("%s=%s", util.K8sNameLabel, appId),
 	})
@@ -112,24 +107,32 @@ func readLogs(logsRequest *restclient.Request) (string, error) {
 }
 
 func (s *Server) GetTinyAppAccessMetrics(ctx context.Context, in *pb.GetTinyAppAccessMetricsRequest) (*pb.GetTinyAppAccessMetricsResponse, error) {
-	logger := zap.S()
+	logger := loggerFromContext(ctx)
 	logger.Info("Received request to get app access metrics")
 
 	appId := in.AppId
-	timeRange := in.TimePeriod
-	podNameRegex := appId + "-.*"
+	if err := validateMetricsAppId(appId); err != nil {
+		return nil, err
+	}
 
-	userNameQuery := fmt.Sprintf("sum by(username) (increase(username_counter{kubernetes_pod_name=~\"%s\"}[%s]))", podNameRegex, timeRange)
-	accessMap, err := getAppAccessCount(s.promSecret, s.env.PrometheusUrl, userNameQuery)
+	timeRange, err := parseTimePeriod(in.TimePeriod)
 	if err != nil {
-		logger.Errorf("unable to decode query response from prometheus: %s", err)
 		return nil, err
 	}
 
+	accessMap, err := s.metrics.CountPerUser(ctx, appId, metrics.AccessCounter, timeRange)
+	if err != nil {
+		logger.Errorf("unable to get access count from metrics provider: %s", err)
+		return nil, metricsError(err)
+	}
+
+	var numberOfAccess float64
+	for _, count := range accessMap {
+		numberOfAccess += count
+	}
+
 	getTinyAppMetricsResponse := &pb.GetTinyAppAccessMetricsResponse{
-		// Once integrated with OAuth, we will have more granular "per user" access metric.
-		// For now, there will always be one "user".
-		NumberOfAccess: accessMap[util.AnyUserName],
+		NumberOfAccess: roundCount(numberOfAccess),
 	}
 
 	logger.Info("Successfully retrieved app access metrics")
@@ -137,167 +140,194 @@ func (s *Server) GetTinyAppAccessMetrics(ctx context.Context, in *pb.GetTinyAppA
 	return getTinyAppMetricsResponse, nil
 }
 
-func (s *Server) GetTinyAppUsageMetrics(ctx context.Context, in *pb.GetTinyAppUsageMetricsRequest) (*pb.GetTinyAppUsageMetricsResponse, error) {
-	logger := zap.S()
-	logger.Info("Received request to get app usage metrics")
+func (s *Server) GetTinyAppUserMetrics(ctx context.Context, in *pb.GetTinyAppUserMetricsRequest) (*pb.GetTinyAppUserMetricsResponse, error) {
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
+	logger.Info("Received request to get app user metrics")
 
-	timeRange := in.TimePeriod
-	podNameRegex := in.AppId + "-.*"
-
-	// queries to get cpu and memory usage and limits
-	cpuUsageQuery := fmt.Sprintf("sum(rate(container_cpu_usage_seconds_total{container=\"app\", pod=~\"%s\"}[%s]))", podNameRegex, timeRange)
-	cpuLimitsQuery := fmt.Sprintf("sum(kube_pod_container_resource_limits{container=\"app\", resource=\"cpu\", pod=~\"%s\"})", podNameRegex)
-	memoryUsageQuery := fmt.Sprintf("sum(container_memory_working_set_bytes{container=\"app\", pod=~\"%s\"})", podNameRegex)
-	memoryLimitsQuery := fmt.Sprintf("sum(kube_pod_container_resource_limits{container=\"app\", resource=\"memory\", pod=~\"%s\"})", podNameRegex)
-
-	queryList := [4]string{cpuUsageQuery, cpuLimitsQuery, memoryUsageQuery, memoryLimitsQuery}
-	// array to store the results from each query
-	var usageMetricsResult [4]float64
-
-	for i, query := range queryList {
-		// call the decode method, get the result
-		// add result to resulting object
-		usageMetric, err := getUsageMetric(s.promSecret, s.env.PrometheusUrl, query)
-		if err != nil {
-			logger.Errorf("unable to decode query response from prometheus: %s", err)
-			usageMetricsResult[i] = 0
-		} else {
-			usageMetricsResult[i] = *usageMetric
-		}
+	timeRange, err := parseTimePeriod(in.TimePeriod)
+	if err != nil {
+		return nil, err
 	}
 
-	getTinyAppMetricsResponse := &pb.GetTinyAppUsageMetricsResponse{
-		CpuUsage:          usageMetricsResult[0],
-		CpuLimit:          usageMetricsResult[1],
-		MemoryUsage:       usageMetricsResult[2] / math.Pow(10, 9),
-		MemoryLimit:       usageMetricsResult[3] / math.Pow(10, 9),
-		PercentCpuUsed:    usageMetricsResult[0] / usageMetricsResult[1],
-		PercentMemoryUsed: usageMetricsResult[2] / usageMetricsResult[3],
+	tinyApp, err := s.getTinyApp(ctx, in.AppId)
+	if err != nil {
+		logger.Errorf("failed to get app: %s", err)
+		return nil, err
 	}
 
-	logger.Info("Successfully retrieved app usage metrics")
+	// Usernames of app users are only shown to those who manage app
+	if err := s.authorizeAppAccess(ctx, tinyApp); err != nil {
+		logger.Errorf("not allowed to get app user metrics: %s", err)
+		return nil, err
+	}
 
-	return getTinyAppMetricsResponse, nil
-}
+	accessMap, err := s.metrics.CountPerUser(ctx, in.AppId, metrics.AccessCounter, timeRange)
+	if err != nil {
+		logger.Errorf("failed to get access count per user: %s", err)
+		return nil, metricsError(err)
+	}
 
-// getAppAccessCount queries prometheus for app access count per user.
-// Returns a list of usernames and the number of times each user accessed the app.
-func getAppAccessCount(promSecret prometheusSecret, prometheusUrl, query string) (map[string]int32, error) {
-	var resultsArray, err = queryAndDecode(promSecret, prometheusUrl, query)
+	sessionMap, err := s.metrics.CountPerUser(ctx, in.AppId, metrics.SessionCounter, timeRange)
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to make query to prometheus")
+		logger.Errorf("failed to get session count per user: %s", err)
+		return nil, metricsError(err)
 	}
 
-	if len(resultsArray) == 0 {
-		return nil, errors.New("no results found for the given query")
+	response := &pb.GetTinyAppUserMetricsResponse{
+		AnonymousAccessCount:  roundCount(accessMap[util.AnyUserName]),
+		AnonymousSessionCount: roundCount(sessionMap[util.AnyUserName]),
 	}
 
-	userNameCountMap := make(map[string]int32)
-	for _, result := range resultsArray {
-		metricJson, err := json.Marshal(result["metric"])
-		if err != nil {
-			return nil, errors.WithMessage(err, "failed to marshal metric data")
-		}
+	usernames := make(map[string]struct{})
+	for username := range accessMap {
+		usernames[username] = struct{}{}
+	}
+	for username := range sessionMap {
+		usernames[username] = struct{}{}
+	}
 
-		var userNameData UserNameData
-		if err := json.Unmarshal(metricJson, &userNameData); err != nil {
-			return nil, errors.WithMessage(err, "failed to unmarshal username data")
-		}
+	for username := range usernames {
+		accessCount, sessionCount := roundCount(accessMap[username]), roundCount(sessionMap[username])
+		response.TotalAccessCount += accessCount
+		response.TotalSessionCount += sessionCount
 
-		count, err := extractFloatValue(result)
-		if err != nil {
-			return nil, errors.WithMessage(err, "failed to parse username count to float")
+		// Users that gateway counted together once it reached its limit of users are in totals only
+		if username == util.AnyUserName || username == util.OtherUsersName || username == "" ||
+			(accessCount == 0 && sessionCount == 0) {
+			continue
 		}
 
-		userNameCountMap[userNameData.Username] = int32(count)
+		response.Users = append(response.Users, &pb.TinyAppUserAccess{
+			Username:     username,
+			AccessCount:  accessCount,
+			SessionCount: sessionCount,
+		})
 	}
 
-	zap.S().Debug("userNameCountMap: ", userNameCountMap)
-	return userNameCountMap, nil
+	sort.Slice(response.Users, func(i, j int) bool {
+		if response.Users[i].AccessCount != response.Users[j].AccessCount {
+			return response.Users[i].AccessCount > response.Users[j].AccessCount
+		}
+		return response.Users[i].Username < response.Users[j].Username
+	})
+	response.DistinctUsers = int32(len(response.Users))
+
+	logger.Info("Successfully retrieved app user metrics")
+
+	return response, nil
 }
 
-// getUsageMetric queries prometheus for usage metrics (cpu or memory).
-// Returns result in float64.
-func getUsageMetric(promSecret prometheusSecret, prometheusUrl, query string) (*float64, error) {
-	var resultsArray, err = queryAndDecode(promSecret, prometheusUrl, query)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to make query to prometheus")
+func (s *Server) GetTinyAppTrafficMetrics(ctx context.Context, in *pb.GetTinyAppTrafficMetricsRequest) (*pb.GetTinyAppTrafficMetricsResponse, error) {
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
+	logger.Info("Received request to get app traffic metrics")
+
+	if err := validateMetricsAppId(in.AppId); err != nil {
+		return nil, err
 	}
 
-	if len(resultsArray) == 0 {
-		return nil, errors.New("no results found for the given query")
+	timeRange, err := parseTimePeriod(in.TimePeriod)
+	if err != nil {
+		return nil, err
 	}
 
-	metricFloat, err := extractFloatValue(resultsArray[0])
+	traffic, err := s.metrics.Traffic(ctx, in.AppId, timeRange)
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to extract metric value")
+		logger.Errorf("failed to get app traffic: %s", err)
+		return nil, metricsError(err)
 	}
 
-	return &metricFloat, nil
+	logger.Info("Successfully retrieved app traffic metrics")
+
+	return &pb.GetTinyAppTrafficMetricsResponse{
+		P50LatencySeconds:          traffic.P50LatencySeconds,
+		P95LatencySeconds:          traffic.P95LatencySeconds,
+		RequestRate:                traffic.RequestRate,
+		ErrorRate:                  traffic.ErrorRate,
+		ActiveWebsocketConnections: traffic.ActiveWebsocketConnections,
+		ReceivedBytesRate:          traffic.ReceivedBytesRate,
+		SentBytesRate:              traffic.SentBytesRate,
+	}, nil
 }
 
-// queryAndDecode makes given query and returns the decoded response.
-func queryAndDecode(promSecret prometheusSecret, prometheusUrl, query string) ([]map[string]interface{}, error) {
-	var prometheusResults PrometheusResults
-	var resultsArray []map[string]interface{}
-
-	if body, err := getQueryResults(promSecret, prometheusUrl, query); err != nil {
-		return nil, fmt.Errorf("error reading body: %s", err)
-	} else {
-		decoder := json.NewDecoder(strings.NewReader(string(body)))
-		for decoder.More() {
-			if err := decoder.Decode(&prometheusResults); err != nil {
-				return nil, fmt.Errorf("error decoding results: %s", err)
-			}
-			resultsArray = prometheusResults.Data.Result
-		}
+func (s *Server) GetTinyAppUsageMetrics(ctx context.Context, in *pb.GetTinyAppUsageMetricsRequest) (*pb.GetTinyAppUsageMetricsResponse, error) {
+	logger := loggerFromContext(ctx)
+	logger.Info("Received request to get app usage metrics")
+
+	if err := validateMetricsAppId(in.AppId); err != nil {
+		return nil, err
 	}
 
-	return resultsArray, nil
-}
+	timeRange, err := parseTimePeriod(in.TimePeriod)
+	if err != nil {
+		return nil, err
+	}
 
-func extractIntValue(queryResult map[string]interface{}) (int32, error) {
-	// "value" array contains timestamp at index 0 and count at index 1.
-	metric := queryResult["value"].([]any)[1].(string)
-	metricInt, err := strconv.ParseInt(metric, 10, 32)
+	usage, err := s.metrics.Usage(ctx, in.AppId, timeRange)
 	if err != nil {
-		return 0, errors.WithMessage(err, "failed to convert string to int")
+		logger.Errorf("failed to get app usage: %s", err)
+		return nil, metricsError(err)
 	}
 
-	return int32(metricInt), nil
+	getTinyAppMetricsResponse := &pb.GetTinyAppUsageMetricsResponse{
+		CpuUsage:          usage.CPUUsage,
+		CpuLimit:          usage.CPULimit,
+		MemoryUsage:       usage.MemoryUsage / math.Pow(10, 9),
+		MemoryLimit:       usage.MemoryLimit / math.Pow(10, 9),
+		PercentCpuUsed:    getRatio(usage.CPUUsage, usage.CPULimit),
+		PercentMemoryUsed: getRatio(usage.MemoryUsage, usage.MemoryLimit),
+	}
+
+	logger.Info("Successfully retrieved app usage metrics")
+
+	return getTinyAppMetricsResponse, nil
 }
 
-func extractFloatValue(queryResult map[string]interface{}) (float64, error) {
-	metric := queryResult["value"].([]any)[1].(string)
-	metricFloat, err := strconv.ParseFloat(metric, 64)
-	if err != nil {
-		return 0, errors.WithMessage(err, "failed to convert string to float")
+// parseTimePeriod validates time period given as Prometheus duration (ex. 5m, 1h, 7d), so that it can't alter queries.
+func parseTimePeriod(timePeriod string) (time.Duration, error) {
+	return parsePrometheusDuration("time period", timePeriod)
+}
+
+// parsePrometheusDuration parses a positive duration that may use Prometheus units like d & w.
+func parsePrometheusDuration(name, value string) (time.Duration, error) {
+	duration, err := model.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s %q, expected a duration such as 5m, 1h or 7d", name, value)
 	}
 
-	return metricFloat, nil
+	return time.Duration(duration), nil
 }
 
-// getQueryResults builds and sends the query to the Prometheus API and returns the response body.
-func getQueryResults(promSecret prometheusSecret, prometheusUrl, query string) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, prometheusUrl, nil)
-	q := req.URL.Query()
-
-	// Adding and encoding query to URL
-	q.Add("query", query)
-	req.URL.RawQuery = q.Encode()
+// validateMetricsAppId makes sure app id is a valid object name, since it is put into queries.
+func validateMetricsAppId(appId string) error {
+	if appId == "" {
+		return status.Error(codes.InvalidArgument, "empty app id")
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request to Prometheus API, err:  %s", err.Error())
+	if errs := validation.IsDNS1123Subdomain(appId); len(errs) > 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid app id: %s", strings.Join(errs, ", "))
 	}
 
-	req.SetBasicAuth(promSecret.Username, promSecret.Password)
+	return nil
+}
 
-	response, err := (&http.Client{}).Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to complete request to Prometheus API, err:  %s", err.Error())
+// metricsError tells clients when metrics are not available with configured metrics provider.
+func metricsError(err error) error {
+	if errors.Is(err, metrics.ErrNotSupported) {
+		return status.Error(codes.Unimplemented, err.Error())
 	}
+	return err
+}
+
+// getRatio returns 0 instead of NaN or Inf when denominator is 0, ex. when limits are unknown.
+func getRatio(numerator, denominator float64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+
+	return numerator / denominator
+}
 
-	zap.S().Debug("Finished querying results")
-	defer response.Body.Close()
-	return io.ReadAll(response.Body)
+// roundCount rounds counts from metrics provider, which are not always whole numbers since increase() extrapolates.
+func roundCount(count float64) int32 {
+	return int32(math.Round(count))
 }
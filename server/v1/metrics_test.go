@@ -0,0 +1,309 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"github.com/tinymultiverse/tinyapp/server/metrics"
+	"github.com/tinymultiverse/tinyapp/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetTinyAppMetricsRange(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		provider *metrics.FakeProvider
+		series   []string
+		want     []*pb.MetricSeries
+	}{
+		{
+			name: "points",
+			provider: &metrics.FakeProvider{SeriesPoints: map[metrics.Series][]metrics.Point{
+				metrics.CPUUsageSeries:    {{Time: start, Value: 0.25}, {Time: start.Add(time.Minute), Value: 0.5}},
+				metrics.AccessCountSeries: {{Time: start, Value: 3}},
+			}},
+			// Returned in order of all series, not in requested order
+			series: []string{"access_count", "cpu_usage"},
+			want: []*pb.MetricSeries{
+				{Name: "cpu_usage", Unit: "cores", Points: []*pb.MetricPoint{
+					{Timestamp: start.Unix(), Value: 0.25},
+					{Timestamp: start.Add(time.Minute).Unix(), Value: 0.5},
+				}},
+				{Name: "access_count", Unit: "accesses", Points: []*pb.MetricPoint{{Timestamp: start.Unix(), Value: 3}}},
+			},
+		},
+		{
+			name:     "empty result",
+			provider: &metrics.FakeProvider{},
+			want: []*pb.MetricSeries{
+				{Name: "cpu_usage", Unit: "cores"},
+				{Name: "memory_usage", Unit: "bytes"},
+				{Name: "request_rate", Unit: "requests/s"},
+				{Name: "access_count", Unit: "accesses"},
+			},
+		},
+		{
+			name:     "provider error",
+			provider: &metrics.FakeProvider{Err: metrics.ErrNotSupported},
+			series:   []string{"memory_usage"},
+			want:     []*pb.MetricSeries{{Name: "memory_usage", Unit: "bytes", Error: metrics.ErrNotSupported.Error()}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer()
+			server.metrics = test.provider
+
+			res, err := server.GetTinyAppMetricsRange(context.Background(), &pb.GetTinyAppMetricsRangeRequest{
+				AppId:  "app",
+				Start:  start.Format(time.RFC3339),
+				End:    start.Add(time.Hour).Format(time.RFC3339),
+				Step:   "1m",
+				Series: test.series,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want := &pb.GetTinyAppMetricsRangeResponse{
+				Start:  "2024-05-01T10:00:00Z",
+				End:    "2024-05-01T11:00:00Z",
+				Step:   "1m",
+				Series: test.want,
+			}
+			if !proto.Equal(res, want) {
+				t.Errorf("got %v, want %v", res, want)
+			}
+		})
+	}
+}
+
+func TestGetTinyAppMetricsRangeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.GetTinyAppMetricsRangeRequest
+	}{
+		{name: "empty app id", req: &pb.GetTinyAppMetricsRangeRequest{}},
+		{name: "invalid app id", req: &pb.GetTinyAppMetricsRangeRequest{AppId: "app\"}"}},
+		{name: "invalid start", req: &pb.GetTinyAppMetricsRangeRequest{AppId: "app", Start: "yesterday"}},
+		{name: "start after end", req: &pb.GetTinyAppMetricsRangeRequest{
+			AppId: "app", Start: "2024-05-01T11:00:00Z", End: "2024-05-01T10:00:00Z",
+		}},
+		{name: "too many points", req: &pb.GetTinyAppMetricsRangeRequest{
+			AppId: "app", Start: "2024-01-01T00:00:00Z", End: "2024-05-01T00:00:00Z", Step: "15s",
+		}},
+		{name: "unknown series", req: &pb.GetTinyAppMetricsRangeRequest{AppId: "app", Series: []string{"disk_usage"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer()
+			server.metrics = &metrics.FakeProvider{}
+
+			_, err := server.GetTinyAppMetricsRange(context.Background(), test.req)
+			assertCode(t, err, codes.InvalidArgument)
+		})
+	}
+}
+
+func TestGetTinyAppUsageMetrics(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage metrics.Usage
+		want  *pb.GetTinyAppUsageMetricsResponse
+	}{
+		{
+			name:  "usage",
+			usage: metrics.Usage{CPUUsage: 0.5, CPULimit: 2, MemoryUsage: 5e8, MemoryLimit: 2e9},
+			want: &pb.GetTinyAppUsageMetricsResponse{
+				CpuUsage: 0.5, CpuLimit: 2, MemoryUsage: 0.5, MemoryLimit: 2, PercentCpuUsed: 0.25, PercentMemoryUsed: 0.25,
+			},
+		},
+		{
+			// Ratios are 0 rather than NaN, which can't be encoded as JSON
+			name: "empty result",
+			want: &pb.GetTinyAppUsageMetricsResponse{},
+		},
+		{
+			name:  "unknown limits",
+			usage: metrics.Usage{CPUUsage: 0.5, MemoryUsage: 1e9},
+			want:  &pb.GetTinyAppUsageMetricsResponse{CpuUsage: 0.5, MemoryUsage: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer()
+			server.metrics = &metrics.FakeProvider{UsageResult: test.usage}
+
+			res, err := server.GetTinyAppUsageMetrics(context.Background(), &pb.GetTinyAppUsageMetricsRequest{AppId: "app", TimePeriod: "1h"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !proto.Equal(res, test.want) {
+				t.Errorf("got %v, want %v", res, test.want)
+			}
+		})
+	}
+}
+
+func TestGetTinyAppAccessMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[string]float64
+		want   int32
+	}{
+		// increase() extrapolates, so counts are not whole numbers
+		{name: "counts", counts: map[string]float64{"alice": 2.4, "bob": 1.3, util.AnyUserName: 0.6}, want: 4},
+		{name: "empty result", want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer()
+			server.metrics = &metrics.FakeProvider{CountsPerUser: map[metrics.UserCounter]map[string]float64{
+				metrics.AccessCounter: test.counts,
+			}}
+
+			res, err := server.GetTinyAppAccessMetrics(context.Background(), &pb.GetTinyAppAccessMetricsRequest{AppId: "app", TimePeriod: "7d"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if res.NumberOfAccess != test.want {
+				t.Errorf("got %d accesses, want %d", res.NumberOfAccess, test.want)
+			}
+		})
+	}
+}
+
+func TestGetTinyAppUserMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[metrics.UserCounter]map[string]float64
+		want   *pb.GetTinyAppUserMetricsResponse
+	}{
+		{
+			name: "counts",
+			counts: map[metrics.UserCounter]map[string]float64{
+				metrics.AccessCounter:  {"bob": 2, "alice": 2.2, "carol": 5, "dave": 0.2, util.AnyUserName: 3, util.OtherUsersName: 4},
+				metrics.SessionCounter: {"alice": 1, "carol": 2, util.AnyUserName: 1.8},
+			},
+			// Sorted by access count, then username. Users rounded to no access or session are left out, as are users
+			// that gateway counted together
+			want: &pb.GetTinyAppUserMetricsResponse{
+				Users: []*pb.TinyAppUserAccess{
+					{Username: "carol", AccessCount: 5, SessionCount: 2},
+					{Username: "alice", AccessCount: 2, SessionCount: 1},
+					{Username: "bob", AccessCount: 2},
+				},
+				DistinctUsers:         3,
+				TotalAccessCount:      16,
+				TotalSessionCount:     5,
+				AnonymousAccessCount:  3,
+				AnonymousSessionCount: 2,
+			},
+		},
+		{
+			name: "empty result",
+			want: &pb.GetTinyAppUserMetricsResponse{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(newTestListApp("app", "App", time.Now()))
+			server.metrics = &metrics.FakeProvider{CountsPerUser: test.counts}
+
+			res, err := server.GetTinyAppUserMetrics(context.Background(), &pb.GetTinyAppUserMetricsRequest{AppId: "app", TimePeriod: "1d"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !proto.Equal(res, test.want) {
+				t.Errorf("got %v, want %v", res, test.want)
+			}
+		})
+	}
+}
+
+func TestGetTinyAppTrafficMetrics(t *testing.T) {
+	traffic := metrics.Traffic{
+		P50LatencySeconds:          0.05,
+		P95LatencySeconds:          0.4,
+		RequestRate:                12,
+		ErrorRate:                  0.5,
+		ActiveWebsocketConnections: 3,
+		ReceivedBytesRate:          1024,
+		SentBytesRate:              4096,
+	}
+
+	server := newTestServer()
+	server.metrics = &metrics.FakeProvider{TrafficResult: traffic}
+
+	res, err := server.GetTinyAppTrafficMetrics(context.Background(), &pb.GetTinyAppTrafficMetricsRequest{AppId: "app", TimePeriod: "5m"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := &pb.GetTinyAppTrafficMetricsResponse{
+		P50LatencySeconds:          0.05,
+		P95LatencySeconds:          0.4,
+		RequestRate:                12,
+		ErrorRate:                  0.5,
+		ActiveWebsocketConnections: 3,
+		ReceivedBytesRate:          1024,
+		SentBytesRate:              4096,
+	}
+	if !proto.Equal(res, want) {
+		t.Errorf("got %v, want %v", res, want)
+	}
+}
+
+func TestMetricsNotSupported(t *testing.T) {
+	server := newTestServer(newTestListApp("app", "App", time.Now()))
+	server.metrics = &metrics.FakeProvider{Err: metrics.ErrNotSupported}
+	ctx := context.Background()
+
+	_, err := server.GetTinyAppAccessMetrics(ctx, &pb.GetTinyAppAccessMetricsRequest{AppId: "app", TimePeriod: "1h"})
+	assertCode(t, err, codes.Unimplemented)
+
+	_, err = server.GetTinyAppUserMetrics(ctx, &pb.GetTinyAppUserMetricsRequest{AppId: "app", TimePeriod: "1h"})
+	assertCode(t, err, codes.Unimplemented)
+
+	_, err = server.GetTinyAppTrafficMetrics(ctx, &pb.GetTinyAppTrafficMetricsRequest{AppId: "app", TimePeriod: "1h"})
+	assertCode(t, err, codes.Unimplemented)
+
+	_, err = server.GetTinyAppUsageMetrics(ctx, &pb.GetTinyAppUsageMetricsRequest{AppId: "app", TimePeriod: "1h"})
+	assertCode(t, err, codes.Unimplemented)
+}
+
+func TestMetricsInvalidTimePeriod(t *testing.T) {
+	server := newTestServer()
+	server.metrics = &metrics.FakeProvider{}
+
+	for _, timePeriod := range []string{"", "1h) or vector(1", "-5m", "0s"} {
+		_, err := server.GetTinyAppUsageMetrics(context.Background(), &pb.GetTinyAppUsageMetricsRequest{AppId: "app", TimePeriod: timePeriod})
+		assertCode(t, err, codes.InvalidArgument)
+	}
+}
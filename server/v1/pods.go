@@ -0,0 +1,138 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	controllerutil "github.com/tinymultiverse/tinyapp/controller/util"
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// getTinyAppPods returns pods of given app, newest first.
+func (s *Server) getTinyAppPods(ctx context.Context, app *v1alpha1.TinyApp) ([]*pb.TinyAppPod, error) {
+	podList, err := s.k8sClient.CoreV1().Pods(s.env.TinyAppNamespace).List(ctx, v1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(app.Labels).String(),
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list app pods")
+	}
+
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+
+	protoPods := make([]*pb.TinyAppPod, 0, len(pods))
+	for _, pod := range pods {
+		protoPods = append(protoPods, convertToProtoPod(&pod))
+	}
+
+	return protoPods, nil
+}
+
+func convertToProtoPod(pod *corev1.Pod) *pb.TinyAppPod {
+	protoPod := &pb.TinyAppPod{
+		Name:  pod.Name,
+		Phase: string(pod.Status.Phase),
+	}
+
+	if pod.Status.StartTime != nil {
+		protoPod.StartTime = pod.Status.StartTime.Time.String()
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			protoPod.Ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	for _, containerStatus := range pod.Status.InitContainerStatuses {
+		protoPod.RestartCount += containerStatus.RestartCount
+
+		// Init container records deployed commit as its termination message
+		if containerStatus.Name == controllerutil.GitRevisionContainerName && containerStatus.State.Terminated != nil {
+			protoPod.GitCommit = strings.TrimSpace(containerStatus.State.Terminated.Message)
+		}
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		protoPod.RestartCount += containerStatus.RestartCount
+
+		if containerStatus.Name == controllerutil.AppContainerName {
+			protoPod.ImageDigest = getImageDigest(containerStatus.ImageID)
+		}
+	}
+
+	return protoPod
+}
+
+// getImageDigest extracts digest from container image id (ex. docker.io/library/python@sha256:abc).
+func getImageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i != -1 {
+		return imageID[i+1:]
+	}
+	return imageID
+}
+
+// getDeployedPod returns the newest ready pod, or the newest pod if none is ready.
+// Pods are expected to be sorted newest first.
+func getDeployedPod(pods []*pb.TinyAppPod) *pb.TinyAppPod {
+	for _, pod := range pods {
+		if pod.Ready {
+			return pod
+		}
+	}
+
+	if len(pods) > 0 {
+		return pods[0]
+	}
+	return nil
+}
+
+// getTinyAppEndpoint returns where app can be reached, and how many pods are receiving its traffic.
+func (s *Server) getTinyAppEndpoint(ctx context.Context, app *v1alpha1.TinyApp, appUrl string) (*pb.TinyAppEndpoint, error) {
+	endpoint := &pb.TinyAppEndpoint{
+		AppUrl:     appUrl,
+		ServiceUrl: fmt.Sprintf("http://%s.%s.svc:%d", app.Name, s.env.TinyAppNamespace, globalutil.DefaultGatewayPort),
+	}
+
+	endpoints, err := s.k8sClient.CoreV1().Endpoints(s.env.TinyAppNamespace).Get(ctx, app.Name, v1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return endpoint, nil // Service not created yet
+		}
+		return nil, errors.WithMessage(err, "failed to get app endpoints")
+	}
+
+	for _, subset := range endpoints.Subsets {
+		endpoint.ReadyAddresses += int32(len(subset.Addresses))
+		endpoint.NotReadyAddresses += int32(len(subset.NotReadyAddresses))
+	}
+
+	return endpoint, nil
+}
@@ -17,25 +17,29 @@ limitations under the License.
 package v1
 
 import (
-	"os"
+	"context"
 
-	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
 	"github.com/tinymultiverse/tinyapp/pkg/k8s/client/tinyapp/clientset/versioned"
+	"github.com/tinymultiverse/tinyapp/server/gitwebhook"
 	"github.com/tinymultiverse/tinyapp/server/internal"
-	"github.com/tinymultiverse/tinyapp/server/util"
+	"github.com/tinymultiverse/tinyapp/server/metrics"
+	globalutil "github.com/tinymultiverse/tinyapp/util"
+	"github.com/tinymultiverse/tinyapp/util/auth"
+	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
 )
 
 type Server struct {
 	tinyAppClient versioned.Interface
 	k8sClient     kubernetes.Interface
-	promSecret    prometheusSecret
+	metrics       metrics.Provider
+	gitDeliveries *gitwebhook.DeliveryLog
 	env           internal.EnvVars
 }
 
 func NewServer(env internal.EnvVars) (*Server, error) {
-	kubeConfig, err := util.GetKubeConfig(env.KubeConfigPath)
+	kubeConfig, err := globalutil.GetKubeConfig(env.KubeConfigPath)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to get Kubernetes configuration")
 	}
@@ -50,36 +54,24 @@ func NewServer(env internal.EnvVars) (*Server, error) {
 		return nil, errors.WithMessage(err, "failed to create k8s client")
 	}
 
-	var promSecret prometheusSecret
-	if env.PrometheusSecretPath != "" {
-		promSecret, err = readPrometheusSecret(env.PrometheusSecretPath)
-		if err != nil {
-			return nil, errors.WithMessage(err, "failed to read Prometheus secret")
-		}
-	} else {
-		promSecret = prometheusSecret{
-			Username: env.PrometheusUserName,
-			Password: env.PrometheusPassword,
-		}
+	metricsProvider, err := metrics.NewProvider(env, k8sClient)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create metrics provider")
 	}
 
 	return &Server{
 		tinyAppClient: tinyAppClient,
 		k8sClient:     k8sClient,
-		promSecret:    promSecret,
+		metrics:       metricsProvider,
+		gitDeliveries: gitwebhook.NewDeliveryLog(env.GitWebhookDeliveryLogSize),
 		env:           env,
 	}, nil
 }
 
-func readPrometheusSecret(filepath string) (prometheusSecret, error) {
-	promSecret := prometheusSecret{}
-	file, err := os.ReadFile(filepath)
-	if err != nil {
-		return promSecret, err
-	}
-	err = yaml.Unmarshal(file, &promSecret)
-	if err != nil {
-		return promSecret, err
+// loggerFromContext returns logger annotated with the caller of the request, if known.
+func loggerFromContext(ctx context.Context) *zap.SugaredLogger {
+	if identity := auth.IdentityFromContext(ctx); identity != nil {
+		return zap.S().With("user", identity.Username)
 	}
-	return promSecret, nil
+	return zap.S()
 }
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/tinymultiverse/tinyapp/pkg/k8s/api/tinyapp/v1alpha1"
+	fakeversioned "github.com/tinymultiverse/tinyapp/pkg/k8s/client/tinyapp/clientset/versioned/fake"
+	"github.com/tinymultiverse/tinyapp/server/gitwebhook"
+	"github.com/tinymultiverse/tinyapp/server/internal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "tinyapp"
+
+// newTestServer returns server backed by fake Kubernetes clients, which hold given TinyApps.
+func newTestServer(apps ...*v1alpha1.TinyApp) *Server {
+	objects := make([]runtime.Object, 0, len(apps))
+	for _, app := range apps {
+		objects = append(objects, app)
+	}
+
+	return &Server{
+		tinyAppClient: fakeversioned.NewSimpleClientset(objects...),
+		k8sClient:     fakekubernetes.NewSimpleClientset(),
+		gitDeliveries: gitwebhook.NewDeliveryLog(10),
+		env: internal.EnvVars{
+			TinyAppNamespace: testNamespace,
+			DefaultAppImage:  "python:3.11",
+			AppIngressDomain: "apps.example.com",
+		},
+	}
+}
+
+// assertCode fails test unless err is a gRPC status error with given code.
+func assertCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != code {
+		t.Fatalf("got code %s (error %v), want %s", got, err, code)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/common/model"
+	pb "github.com/tinymultiverse/tinyapp/pkg/server/api/v1/proto"
+	"github.com/tinymultiverse/tinyapp/server/metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultMetricsRange = time.Hour
+	// Default step gives about this many points per series
+	defaultMetricsPoints = 120
+	minMetricsStep       = 15 * time.Second
+	// Prometheus rejects range queries with more points than this
+	maxMetricsPoints = 11000
+)
+
+func (s *Server) GetTinyAppMetricsRange(ctx context.Context, in *pb.GetTinyAppMetricsRangeRequest) (*pb.GetTinyAppMetricsRangeResponse, error) {
+	logger := loggerFromContext(ctx).With("appId", in.AppId)
+	logger.Info("Received request to get app metrics range")
+
+	if err := validateMetricsAppId(in.AppId); err != nil {
+		return nil, err
+	}
+
+	start, end, step, err := parseMetricsRange(in.Start, in.End, in.Step)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesList, err := getMetricSeries(in.Series)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetTinyAppMetricsRangeResponse{
+		Start: start.Format(time.RFC3339),
+		End:   end.Format(time.RFC3339),
+		Step:  model.Duration(step).String(),
+	}
+
+	for _, series := range seriesList {
+		points, err := s.metrics.Range(ctx, in.AppId, series, start, end, step)
+
+		result := &pb.MetricSeries{Name: string(series), Unit: series.Unit()}
+		if err != nil {
+			logger.Errorf("failed to query %s: %s", series, err)
+			result.Error = err.Error()
+		}
+		for _, point := range points {
+			result.Points = append(result.Points, &pb.MetricPoint{Timestamp: point.Time.Unix(), Value: point.Value})
+		}
+		response.Series = append(response.Series, result)
+	}
+
+	logger.Info("Successfully retrieved app metrics range")
+
+	return response, nil
+}
+
+// parseMetricsRange validates requested range & step, filling in defaults.
+func parseMetricsRange(startValue, endValue, stepValue string) (start, end time.Time, step time.Duration, err error) {
+	end = time.Now()
+	if endValue != "" {
+		if end, err = time.Parse(time.RFC3339, endValue); err != nil {
+			return start, end, step, status.Errorf(codes.InvalidArgument, "invalid end: %s", err)
+		}
+	}
+
+	start = end.Add(-defaultMetricsRange)
+	if startValue != "" {
+		if start, err = time.Parse(time.RFC3339, startValue); err != nil {
+			return start, end, step, status.Errorf(codes.InvalidArgument, "invalid start: %s", err)
+		}
+	}
+
+	if !start.Before(end) {
+		return start, end, step, status.Error(codes.InvalidArgument, "start must be before end")
+	}
+
+	if stepValue == "" {
+		step = (end.Sub(start) / defaultMetricsPoints).Round(time.Second)
+		if step < minMetricsStep {
+			step = minMetricsStep
+		}
+	} else if step, err = parsePrometheusDuration("step", stepValue); err != nil {
+		return start, end, step, err
+	}
+
+	if end.Sub(start)/step > maxMetricsPoints {
+		return start, end, step, status.Errorf(codes.InvalidArgument,
+			"too many points, step must be at least %s for this range", (end.Sub(start)/maxMetricsPoints).Truncate(time.Second)+time.Second)
+	}
+
+	return start, end, step, nil
+}
+
+// getMetricSeries returns requested series in order, which is all series if none were requested.
+func getMetricSeries(requested []string) ([]metrics.Series, error) {
+	if len(requested) == 0 {
+		return metrics.AllSeries, nil
+	}
+
+	requestedSet := make(map[metrics.Series]bool)
+	for _, name := range requested {
+		requestedSet[metrics.Series(name)] = true
+	}
+
+	var seriesList []metrics.Series
+	for _, series := range metrics.AllSeries {
+		if requestedSet[series] {
+			seriesList = append(seriesList, series)
+			delete(requestedSet, series)
+		}
+	}
+
+	for series := range requestedSet {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown series %s", series)
+	}
+
+	return seriesList, nil
+}
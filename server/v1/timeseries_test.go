@@ -0,0 +1,132 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestParseMetricsRange(t *testing.T) {
+	end := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		step      string
+		wantStart time.Time
+		wantStep  time.Duration
+		code      codes.Code
+	}{
+		{
+			name:      "default range",
+			end:       "2024-05-01T10:00:00Z",
+			wantStart: end.Add(-defaultMetricsRange),
+			wantStep:  30 * time.Second,
+		},
+		{
+			name:      "default step",
+			start:     "2024-04-01T10:00:00Z",
+			end:       "2024-05-01T10:00:00Z",
+			wantStart: end.AddDate(0, -1, 0),
+			wantStep:  6 * time.Hour,
+		},
+		{
+			name:      "default step not below minimum",
+			start:     "2024-05-01T09:59:00Z",
+			end:       "2024-05-01T10:00:00Z",
+			wantStart: end.Add(-time.Minute),
+			wantStep:  minMetricsStep,
+		},
+		{
+			name:      "prometheus step",
+			start:     "2024-04-01T10:00:00Z",
+			end:       "2024-05-01T10:00:00Z",
+			step:      "1d",
+			wantStart: end.AddDate(0, -1, 0),
+			wantStep:  24 * time.Hour,
+		},
+		{name: "invalid start", start: "2024-05-01", code: codes.InvalidArgument},
+		{name: "invalid end", end: "10:00", code: codes.InvalidArgument},
+		{name: "start at end", start: "2024-05-01T10:00:00Z", end: "2024-05-01T10:00:00Z", code: codes.InvalidArgument},
+		{name: "invalid step", end: "2024-05-01T10:00:00Z", step: "1m]) or vector(1", code: codes.InvalidArgument},
+		{name: "zero step", end: "2024-05-01T10:00:00Z", step: "0s", code: codes.InvalidArgument},
+		{name: "too many points", start: "2024-04-01T10:00:00Z", end: "2024-05-01T10:00:00Z", step: "1m", code: codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start, gotEnd, step, err := parseMetricsRange(test.start, test.end, test.step)
+			assertCode(t, err, test.code)
+			if test.code != codes.OK {
+				return
+			}
+
+			if !start.Equal(test.wantStart) || !gotEnd.Equal(end) {
+				t.Errorf("got range %s - %s, want %s - %s", start, gotEnd, test.wantStart, end)
+			}
+			if step != test.wantStep {
+				t.Errorf("got step %s, want %s", step, test.wantStep)
+			}
+		})
+	}
+}
+
+// Durations put into queries must not be able to alter them.
+func TestParsePrometheusDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		code  codes.Code
+	}{
+		{value: "5m", want: 5 * time.Minute},
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "1h30m", want: 90 * time.Minute},
+		{value: "", code: codes.InvalidArgument},
+		{value: "0m", code: codes.InvalidArgument},
+		{value: "-1h", code: codes.InvalidArgument},
+		{value: "1.5h", code: codes.InvalidArgument},
+		{value: "1h]) or vector(1)", code: codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		got, err := parsePrometheusDuration("time period", test.value)
+		assertCode(t, err, test.code)
+		if got != test.want {
+			t.Errorf("got %s for %q, want %s", got, test.value, test.want)
+		}
+	}
+}
+
+func TestValidateMetricsAppId(t *testing.T) {
+	tests := []struct {
+		appId string
+		code  codes.Code
+	}{
+		{appId: "my-app"},
+		{appId: "", code: codes.InvalidArgument},
+		{appId: "my-app\"} or up{", code: codes.InvalidArgument},
+		{appId: "My_App", code: codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		assertCode(t, validateMetricsAppId(test.appId), test.code)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoToken is returned when request carries no bearer token.
+var ErrNoToken = errors.New("no bearer token")
+
+// Identity is the authenticated caller of a request.
+type Identity struct {
+	// Subject uniquely identifies the caller with its identity provider.
+	Subject string
+	// Username is the human readable name of the caller (ex. email).
+	Username string
+	// Groups the caller belongs to.
+	Groups []string
+}
+
+// Authenticator verifies a bearer token and returns the identity it was issued to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Identity, error)
+}
+
+// Authenticators tries each authenticator in order, and returns the identity from the first that accepts the token.
+type Authenticators []Authenticator
+
+func (a Authenticators) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	err := errors.New("no authenticator configured")
+	for _, authenticator := range a {
+		var identity *Identity
+		identity, err = authenticator.Authenticate(ctx, token)
+		if err == nil {
+			return identity, nil
+		}
+	}
+	return nil, err
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying caller identity.
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns caller identity stored in ctx, or nil if request was not authenticated.
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpc-gateway forwards the HTTP Authorization header under this metadata key
+const authorizationMetadataKey = "authorization"
+
+// UnaryServerInterceptor rejects unauthenticated gRPC calls, and stores caller identity in the call context.
+func UnaryServerInterceptor(authenticator Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects unauthenticated gRPC streams, and stores caller identity in the stream context.
+func StreamServerInterceptor(authenticator Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, authenticator Authenticator, method string) (context.Context, error) {
+	token, err := getBearerToken(ctx)
+	if err != nil {
+		zap.S().Infow("Rejected unauthenticated call", "method", method, "error", err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	identity, err := authenticator.Authenticate(ctx, token)
+	if err != nil {
+		zap.S().Infow("Rejected call with invalid token", "method", method, "error", err)
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	zap.S().Debugw("Authenticated call", "method", method, "user", identity.Username)
+
+	return WithIdentity(ctx, identity), nil
+}
+
+func getBearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationMetadataKey) {
+		scheme, token, found := strings.Cut(value, " ")
+		if found && strings.EqualFold(scheme, "Bearer") && token != "" {
+			return strings.TrimSpace(token), nil
+		}
+	}
+	return "", ErrNoToken
+}
+
+// authenticatedStream overrides stream context with one carrying caller identity.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
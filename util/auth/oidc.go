@@ -0,0 +1,270 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// Leeway for token expiry & not-before checks, to allow for clock skew
+	clockSkewLeeway = time.Minute
+	// Min time between fetching keys because a token was signed with an unknown key
+	minKeyRefreshInterval = time.Minute
+	httpTimeout           = 10 * time.Second
+)
+
+// Asymmetric algorithms an identity provider may sign tokens with
+var supportedSigningAlgorithms = map[string]bool{
+	string(jose.RS256): true, string(jose.RS384): true, string(jose.RS512): true,
+	string(jose.PS256): true, string(jose.PS384): true, string(jose.PS512): true,
+	string(jose.ES256): true, string(jose.ES384): true, string(jose.ES512): true,
+	string(jose.EdDSA): true,
+}
+
+type OIDCConfig struct {
+	// IssuerURL is the identity provider url. Tokens must be issued by it.
+	IssuerURL string
+	// Audience is the client id tokens must be issued for. Not checked if empty.
+	Audience string
+	// JWKSURL is where signing keys are fetched from. Discovered from issuer if empty.
+	JWKSURL string
+	// JWKSFile is a local file holding signing keys. Used instead of fetching keys if set.
+	JWKSFile string
+	// UsernameClaim is the token claim holding username. Subject is used if claim is missing.
+	UsernameClaim string
+	// GroupsClaim is the token claim holding groups of the user.
+	GroupsClaim string
+}
+
+// ProviderMetadata is the part of an OpenID Connect discovery document used by tinyapp.
+type ProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+// DiscoverProvider fetches OpenID Connect discovery document of issuer.
+func DiscoverProvider(ctx context.Context, issuerURL string) (*ProviderMetadata, error) {
+	metadata := &ProviderMetadata{}
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, &http.Client{Timeout: httpTimeout}, discoveryURL, metadata); err != nil {
+		return nil, errors.WithMessage(err, "failed to get OIDC discovery document")
+	}
+	return metadata, nil
+}
+
+// OIDCAuthenticator verifies JWTs issued by an OpenID Connect identity provider.
+type OIDCAuthenticator struct {
+	config     OIDCConfig
+	httpClient *http.Client
+
+	mu          sync.RWMutex
+	keys        *jose.JSONWebKeySet
+	lastRefresh time.Time
+}
+
+// NewOIDCAuthenticator returns authenticator with signing keys loaded.
+func NewOIDCAuthenticator(ctx context.Context, config OIDCConfig) (*OIDCAuthenticator, error) {
+	if config.IssuerURL == "" {
+		return nil, errors.New("OIDC issuer url is required")
+	}
+
+	a := &OIDCAuthenticator{
+		config:     config,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+
+	if err := a.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	parsedToken, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, errors.WithMessage(err, "malformed token")
+	}
+
+	if len(parsedToken.Headers) != 1 {
+		return nil, errors.New("token must have exactly one signature")
+	}
+	header := parsedToken.Headers[0]
+	if !supportedSigningAlgorithms[header.Algorithm] {
+		return nil, errors.Errorf("unsupported token signing algorithm %s", header.Algorithm)
+	}
+
+	keys, err := a.getKeys(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.Claims{}
+	extraClaims := map[string]interface{}{}
+	verified := false
+	for _, key := range keys {
+		if err := parsedToken.Claims(key.Key, &claims, &extraClaims); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("invalid token signature")
+	}
+
+	expected := jwt.Expected{
+		Issuer: a.config.IssuerURL,
+		Time:   time.Now(),
+	}
+	if a.config.Audience != "" {
+		expected.Audience = jwt.Audience{a.config.Audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, clockSkewLeeway); err != nil {
+		return nil, errors.WithMessage(err, "invalid token claims")
+	}
+
+	identity := &Identity{
+		Subject:  claims.Subject,
+		Username: claims.Subject,
+		Groups:   getStringsClaim(extraClaims, a.config.GroupsClaim),
+	}
+	if username, ok := extraClaims[a.config.UsernameClaim].(string); ok && username != "" {
+		identity.Username = username
+	}
+
+	return identity, nil
+}
+
+// getKeys returns signing keys matching key id, or all keys if token doesn't name one.
+// Keys are refreshed when key id is unknown, since identity providers rotate keys.
+func (a *OIDCAuthenticator) getKeys(ctx context.Context, keyID string) ([]jose.JSONWebKey, error) {
+	a.mu.RLock()
+	keys, lastRefresh := a.keys, a.lastRefresh
+	a.mu.RUnlock()
+
+	if keyID == "" {
+		return keys.Keys, nil
+	}
+
+	if matches := keys.Key(keyID); len(matches) > 0 {
+		return matches, nil
+	}
+
+	if time.Since(lastRefresh) < minKeyRefreshInterval {
+		return nil, errors.Errorf("unknown signing key %s", keyID)
+	}
+
+	if err := a.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if matches := a.keys.Key(keyID); len(matches) > 0 {
+		return matches, nil
+	}
+
+	return nil, errors.Errorf("unknown signing key %s", keyID)
+}
+
+func (a *OIDCAuthenticator) refreshKeys(ctx context.Context) error {
+	keys := &jose.JSONWebKeySet{}
+
+	if a.config.JWKSFile != "" {
+		file, err := os.ReadFile(a.config.JWKSFile)
+		if err != nil {
+			return errors.WithMessage(err, "failed to read JWKS file")
+		}
+		if err := json.Unmarshal(file, keys); err != nil {
+			return errors.WithMessage(err, "failed to parse JWKS file")
+		}
+	} else {
+		jwksURL := a.config.JWKSURL
+		if jwksURL == "" {
+			metadata, err := DiscoverProvider(ctx, a.config.IssuerURL)
+			if err != nil {
+				return err
+			}
+			jwksURL = metadata.JWKSURI
+		}
+
+		if err := getJSON(ctx, a.httpClient, jwksURL, keys); err != nil {
+			return errors.WithMessage(err, "failed to get JWKS")
+		}
+	}
+
+	a.mu.Lock()
+	a.keys = keys
+	a.lastRefresh = time.Now()
+	a.mu.Unlock()
+
+	zap.S().Infow("Loaded OIDC signing keys", "count", len(keys.Keys))
+
+	return nil
+}
+
+func getJSON(ctx context.Context, httpClient *http.Client, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", res.StatusCode, url)
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// getStringsClaim returns claim that is either a list of strings or a single string.
+func getStringsClaim(claims map[string]interface{}, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var values []string
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
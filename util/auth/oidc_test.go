@@ -0,0 +1,199 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const testIssuer = "https://idp.example.com"
+
+func newTestKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+// writeJWKSFile writes public keys to a JWKS file, and returns its path.
+func writeJWKSFile(t *testing.T, keys map[string]*rsa.PrivateKey) string {
+	t.Helper()
+
+	jwks := jose.JSONWebKeySet{}
+	for keyID, key := range keys {
+		jwks.Keys = append(jwks.Keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"})
+	}
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write JWKS file: %v", err)
+	}
+	return path
+}
+
+// signToken returns JWT with given claims, signed with key & key id.
+func signToken(t *testing.T, key *rsa.PrivateKey, keyID string, claims jwt.Claims, extraClaims map[string]interface{}) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", keyID))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	token, err := jwt.Signed(signer).Claims(claims).Claims(extraClaims).CompactSerialize()
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestOIDCAuthenticatorJWKSFile(t *testing.T) {
+	key, otherKey, unknownKey := newTestKey(t), newTestKey(t), newTestKey(t)
+	jwksFile := writeJWKSFile(t, map[string]*rsa.PrivateKey{"key-1": key, "key-2": otherKey})
+
+	authenticator, err := NewOIDCAuthenticator(context.Background(), OIDCConfig{
+		IssuerURL:     testIssuer,
+		Audience:      "tinyapp",
+		JWKSFile:      jwksFile,
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+	})
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	now := time.Now()
+	validClaims := jwt.Claims{
+		Issuer:   testIssuer,
+		Subject:  "user-1",
+		Audience: jwt.Audience{"tinyapp"},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+	userClaims := map[string]interface{}{"email": "jane@example.com", "groups": []string{"research", "admins"}}
+
+	withClaims := func(update func(claims *jwt.Claims)) jwt.Claims {
+		claims := validClaims
+		update(&claims)
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *Identity
+		wantErr bool
+	}{
+		{
+			name:  "valid token",
+			token: signToken(t, key, "key-1", validClaims, userClaims),
+			want:  &Identity{Subject: "user-1", Username: "jane@example.com", Groups: []string{"research", "admins"}},
+		},
+		{
+			name:  "second key",
+			token: signToken(t, otherKey, "key-2", validClaims, userClaims),
+			want:  &Identity{Subject: "user-1", Username: "jane@example.com", Groups: []string{"research", "admins"}},
+		},
+		{
+			name:  "no username claim",
+			token: signToken(t, key, "key-1", validClaims, map[string]interface{}{"groups": "research"}),
+			want:  &Identity{Subject: "user-1", Username: "user-1", Groups: []string{"research"}},
+		},
+		{
+			name:    "signed with other key of file",
+			token:   signToken(t, otherKey, "key-1", validClaims, userClaims),
+			wantErr: true,
+		},
+		{
+			name:    "signed with key not in file",
+			token:   signToken(t, unknownKey, "key-1", validClaims, userClaims),
+			wantErr: true,
+		},
+		{
+			name:    "unknown key id",
+			token:   signToken(t, unknownKey, "key-3", validClaims, userClaims),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   signToken(t, key, "key-1", withClaims(func(c *jwt.Claims) { c.Expiry = jwt.NewNumericDate(now.Add(-time.Hour)) }), userClaims),
+			wantErr: true,
+		},
+		{
+			name:    "other issuer",
+			token:   signToken(t, key, "key-1", withClaims(func(c *jwt.Claims) { c.Issuer = "https://other.example.com" }), userClaims),
+			wantErr: true,
+		},
+		{
+			name:    "other audience",
+			token:   signToken(t, key, "key-1", withClaims(func(c *jwt.Claims) { c.Audience = jwt.Audience{"other"} }), userClaims),
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			token:   "not-a-jwt",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identity, err := authenticator.Authenticate(context.Background(), test.token)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %t", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(identity, test.want) {
+				t.Errorf("got identity %+v, want %+v", identity, test.want)
+			}
+		})
+	}
+}
+
+func TestNewOIDCAuthenticatorInvalidJWKSFile(t *testing.T) {
+	invalidFile := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(invalidFile, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS file: %v", err)
+	}
+
+	for name, path := range map[string]string{"missing": filepath.Join(t.TempDir(), "missing.json"), "invalid": invalidFile} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewOIDCAuthenticator(context.Background(), OIDCConfig{IssuerURL: testIssuer, JWKSFile: path})
+			if err == nil {
+				t.Error("got no error for JWKS file")
+			}
+		})
+	}
+}
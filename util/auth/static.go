@@ -0,0 +1,78 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// StaticToken is a long-lived token assigned to a service account.
+type StaticToken struct {
+	Token    string   `json:"token"`
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+}
+
+// StaticTokenAuthenticator accepts a fixed set of tokens, for service accounts that can't do OIDC login.
+type StaticTokenAuthenticator struct {
+	tokens []StaticToken
+}
+
+// NewStaticTokenAuthenticatorFromFile loads static tokens from a YAML or JSON file holding a list of StaticToken.
+func NewStaticTokenAuthenticatorFromFile(path string) (*StaticTokenAuthenticator, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read static tokens file")
+	}
+
+	var tokens []StaticToken
+	if err := yaml.Unmarshal(file, &tokens); err != nil {
+		return nil, errors.WithMessage(err, "failed to parse static tokens file")
+	}
+
+	for i, token := range tokens {
+		if token.Token == "" || token.Username == "" {
+			return nil, errors.Errorf("static token %d must have token and username", i)
+		}
+	}
+
+	return &StaticTokenAuthenticator{tokens: tokens}, nil
+}
+
+func (a *StaticTokenAuthenticator) Authenticate(_ context.Context, token string) (*Identity, error) {
+	// Check every token in constant time, so that timing doesn't reveal how much of a token matched
+	var match *StaticToken
+	for i := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(a.tokens[i].Token), []byte(token)) == 1 {
+			match = &a.tokens[i]
+		}
+	}
+
+	if match == nil {
+		return nil, errors.New("unknown static token")
+	}
+
+	return &Identity{
+		Subject:  match.Username,
+		Username: match.Username,
+		Groups:   match.Groups,
+	}, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeStaticTokensFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "tokens.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write static tokens file: %v", err)
+	}
+	return path
+}
+
+func TestStaticTokenAuthenticator(t *testing.T) {
+	path := writeStaticTokensFile(t, `
+- token: ci-token
+  username: ci-bot
+  groups: [admins, ci]
+- token: report-token
+  username: report-bot
+`)
+
+	authenticator, err := NewStaticTokenAuthenticatorFromFile(path)
+	if err != nil {
+		t.Fatalf("failed to load static tokens: %v", err)
+	}
+
+	tests := []struct {
+		token   string
+		want    *Identity
+		wantErr bool
+	}{
+		{token: "ci-token", want: &Identity{Subject: "ci-bot", Username: "ci-bot", Groups: []string{"admins", "ci"}}},
+		{token: "report-token", want: &Identity{Subject: "report-bot", Username: "report-bot"}},
+		{token: "ci-token-suffix", wantErr: true},
+		{token: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.token, func(t *testing.T) {
+			identity, err := authenticator.Authenticate(context.Background(), test.token)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %t", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(identity, test.want) {
+				t.Errorf("got identity %+v, want %+v", identity, test.want)
+			}
+		})
+	}
+}
+
+func TestNewStaticTokenAuthenticatorFromFileInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no username": "- token: ci-token\n",
+		"no token":    "- username: ci-bot\n",
+		"not a list":  "token: ci-token\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewStaticTokenAuthenticatorFromFile(writeStaticTokensFile(t, content)); err == nil {
+				t.Error("got no error for invalid static tokens file")
+			}
+		})
+	}
+}
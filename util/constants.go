@@ -18,6 +18,9 @@ package util
 
 const AnyUserName = "anyuser"
 
+// OtherUsersName is the username gateway counts users under once it has counted as many distinct users as it may.
+const OtherUsersName = "otherusers"
+
 const (
 	TLSSecretVolumeName = "tls-secret"
 	TLSSecretMountPath  = "/tls-secret"
@@ -35,6 +38,32 @@ const (
 	K8sMaxNameSize = 253
 )
 
+const (
+	// AnnotationWakeRequestedAt is set on a sleeping TinyApp by the activator when a request comes in for it.
+	AnnotationWakeRequestedAt = "tinymultiverse.ai/wake-requested-at"
+	// AnnotationRestartedAt can be set on a TinyApp to any new value (ex. current time) to restart its pods.
+	AnnotationRestartedAt = "tinymultiverse.ai/restarted-at"
+)
+
+const (
+	// Ports of gateway, which proxies app traffic on default port & serves its own endpoints on admin port
+	DefaultGatewayPort      int32 = 8080
+	DefaultGatewayAdminPort int32 = 8081
+)
+
+const (
+	// Paths served by gateway on its admin port
+	GatewayActivityPath  = "/activity"
+	GatewayLivenessPath  = "/healthz"
+	GatewayReadinessPath = "/readyz"
+)
+
+const (
+	// Paths handled by gateway login, relative to app url
+	LoginCallbackPath = "/_tinyapp/oauth2/callback"
+	LogoutPath        = "/_tinyapp/oauth2/logout"
+)
+
 const (
 	Https = "https://"
 	Http  = "http://"
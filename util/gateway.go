@@ -0,0 +1,28 @@
+/*
+Copyright 2024 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import "time"
+
+// GatewayActivity is app traffic as seen by a gateway, served on its admin port for the controller to tell when app is
+// idle.
+type GatewayActivity struct {
+	// LastRequestTime is when the last request came in. Zero if there has been none since gateway started.
+	LastRequestTime time.Time `json:"lastRequestTime"`
+	// ActiveRequests is the number of requests in flight, including open websocket connections.
+	ActiveRequests int64 `json:"activeRequests"`
+}
@@ -18,6 +18,9 @@ package util
 
 import (
 	"math/rand"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func GenerateTinyAppObjName() string {
@@ -37,3 +40,11 @@ func randomString(len int) string {
 func randomInt(min, max int) int {
 	return min + rand.Intn(max-min)
 }
+
+// GetKubeConfig returns config from given kubeconfig file path, or in-cluster config if path is empty.
+func GetKubeConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	return rest.InClusterConfig()
+}